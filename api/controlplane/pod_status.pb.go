@@ -0,0 +1,440 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        v3.11.4
+// source: controlplane/pod_status.proto
+
+package controlplane
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// The status of all pod configurations on a single apatelet
+type ApateletPodStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The UUID of the apatelet reporting its status
+	NodeUuid string `protobuf:"bytes,1,opt,name=node_uuid,json=nodeUuid,proto3" json:"node_uuid,omitempty"`
+	// The status of every pod configuration known to the apatelet
+	Configurations []*PodConfigurationStatus `protobuf:"bytes,2,rep,name=configurations,proto3" json:"configurations,omitempty"`
+}
+
+func (x *ApateletPodStatus) Reset() {
+	*x = ApateletPodStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controlplane_pod_status_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApateletPodStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApateletPodStatus) ProtoMessage() {}
+
+func (x *ApateletPodStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_pod_status_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApateletPodStatus.ProtoReflect.Descriptor instead.
+func (*ApateletPodStatus) Descriptor() ([]byte, []int) {
+	return file_controlplane_pod_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApateletPodStatus) GetNodeUuid() string {
+	if x != nil {
+		return x.NodeUuid
+	}
+	return ""
+}
+
+func (x *ApateletPodStatus) GetConfigurations() []*PodConfigurationStatus {
+	if x != nil {
+		return x.Configurations
+	}
+	return nil
+}
+
+// The status of a single pod configuration on a single apatelet
+type PodConfigurationStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The label of the pod configuration (<namespace>/<name>)
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// The amount of pods on the apatelet which match the pod configuration
+	MatchedPods int64 `protobuf:"varint,2,opt,name=matched_pods,json=matchedPods,proto3" json:"matched_pods,omitempty"`
+	// The tasks which have been executed
+	Tasks []*TaskStatus `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// The error which occurred while parsing the pod configuration, if any
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *PodConfigurationStatus) Reset() {
+	*x = PodConfigurationStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controlplane_pod_status_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodConfigurationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodConfigurationStatus) ProtoMessage() {}
+
+func (x *PodConfigurationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_pod_status_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodConfigurationStatus.ProtoReflect.Descriptor instead.
+func (*PodConfigurationStatus) Descriptor() ([]byte, []int) {
+	return file_controlplane_pod_status_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PodConfigurationStatus) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PodConfigurationStatus) GetMatchedPods() int64 {
+	if x != nil {
+		return x.MatchedPods
+	}
+	return 0
+}
+
+func (x *PodConfigurationStatus) GetTasks() []*TaskStatus {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *PodConfigurationStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// The status of a single task of a pod configuration
+type TaskStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the task in the pod configuration
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The amount of pods on which the task has been executed
+	FiredPods int64 `protobuf:"varint,2,opt,name=fired_pods,json=firedPods,proto3" json:"fired_pods,omitempty"`
+}
+
+func (x *TaskStatus) Reset() {
+	*x = TaskStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controlplane_pod_status_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatus) ProtoMessage() {}
+
+func (x *TaskStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_pod_status_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatus.ProtoReflect.Descriptor instead.
+func (*TaskStatus) Descriptor() ([]byte, []int) {
+	return file_controlplane_pod_status_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskStatus) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TaskStatus) GetFiredPods() int64 {
+	if x != nil {
+		return x.FiredPods
+	}
+	return 0
+}
+
+var File_controlplane_pod_status_proto protoreflect.FileDescriptor
+
+var file_controlplane_pod_status_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70,
+	0x6f, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x12, 0x61, 0x70, 0x61, 0x74, 0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x84, 0x01, 0x0a, 0x11, 0x41, 0x70, 0x61, 0x74, 0x65, 0x6c, 0x65, 0x74, 0x50, 0x6f, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x75,
+	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x55,
+	0x75, 0x69, 0x64, 0x12, 0x52, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x70,
+	0x61, 0x74, 0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x16, 0x50, 0x6f, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x70, 0x61,
+	0x74, 0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x41, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x69, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x66, 0x69, 0x72, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x73, 0x32, 0x5f, 0x0a, 0x09, 0x50, 0x6f,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x0f, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x2e, 0x61, 0x70, 0x61,
+	0x74, 0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x41, 0x70, 0x61, 0x74, 0x65, 0x6c, 0x65, 0x74, 0x50, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x34, 0x5a, 0x32, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x74, 0x6c, 0x61, 0x72, 0x67,
+	0x65, 0x2d, 0x72, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2f, 0x61, 0x70, 0x61, 0x74, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controlplane_pod_status_proto_rawDescOnce sync.Once
+	file_controlplane_pod_status_proto_rawDescData = file_controlplane_pod_status_proto_rawDesc
+)
+
+func file_controlplane_pod_status_proto_rawDescGZIP() []byte {
+	file_controlplane_pod_status_proto_rawDescOnce.Do(func() {
+		file_controlplane_pod_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_controlplane_pod_status_proto_rawDescData)
+	})
+	return file_controlplane_pod_status_proto_rawDescData
+}
+
+var file_controlplane_pod_status_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_controlplane_pod_status_proto_goTypes = []interface{}{
+	(*ApateletPodStatus)(nil),      // 0: apate.controlplane.ApateletPodStatus
+	(*PodConfigurationStatus)(nil), // 1: apate.controlplane.PodConfigurationStatus
+	(*TaskStatus)(nil),             // 2: apate.controlplane.TaskStatus
+	(*empty.Empty)(nil),            // 3: google.protobuf.Empty
+}
+var file_controlplane_pod_status_proto_depIdxs = []int32{
+	1, // 0: apate.controlplane.ApateletPodStatus.configurations:type_name -> apate.controlplane.PodConfigurationStatus
+	2, // 1: apate.controlplane.PodConfigurationStatus.tasks:type_name -> apate.controlplane.TaskStatus
+	0, // 2: apate.controlplane.PodStatus.reportPodStatus:input_type -> apate.controlplane.ApateletPodStatus
+	3, // 3: apate.controlplane.PodStatus.reportPodStatus:output_type -> google.protobuf.Empty
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_controlplane_pod_status_proto_init() }
+func file_controlplane_pod_status_proto_init() {
+	if File_controlplane_pod_status_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controlplane_pod_status_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApateletPodStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controlplane_pod_status_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PodConfigurationStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controlplane_pod_status_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controlplane_pod_status_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_controlplane_pod_status_proto_goTypes,
+		DependencyIndexes: file_controlplane_pod_status_proto_depIdxs,
+		MessageInfos:      file_controlplane_pod_status_proto_msgTypes,
+	}.Build()
+	File_controlplane_pod_status_proto = out.File
+	file_controlplane_pod_status_proto_rawDesc = nil
+	file_controlplane_pod_status_proto_goTypes = nil
+	file_controlplane_pod_status_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PodStatusClient is the client API for PodStatus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PodStatusClient interface {
+	ReportPodStatus(ctx context.Context, in *ApateletPodStatus, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type podStatusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPodStatusClient(cc grpc.ClientConnInterface) PodStatusClient {
+	return &podStatusClient{cc}
+}
+
+func (c *podStatusClient) ReportPodStatus(ctx context.Context, in *ApateletPodStatus, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/apate.controlplane.PodStatus/reportPodStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PodStatusServer is the server API for PodStatus service.
+type PodStatusServer interface {
+	ReportPodStatus(context.Context, *ApateletPodStatus) (*empty.Empty, error)
+}
+
+// UnimplementedPodStatusServer can be embedded to have forward compatible implementations.
+type UnimplementedPodStatusServer struct {
+}
+
+func (*UnimplementedPodStatusServer) ReportPodStatus(context.Context, *ApateletPodStatus) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPodStatus not implemented")
+}
+
+func RegisterPodStatusServer(s *grpc.Server, srv PodStatusServer) {
+	s.RegisterService(&_PodStatus_serviceDesc, srv)
+}
+
+func _PodStatus_ReportPodStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApateletPodStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodStatusServer).ReportPodStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apate.controlplane.PodStatus/ReportPodStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodStatusServer).ReportPodStatus(ctx, req.(*ApateletPodStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PodStatus_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "apate.controlplane.PodStatus",
+	HandlerType: (*PodStatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "reportPodStatus",
+			Handler:    _PodStatus_ReportPodStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controlplane/pod_status.proto",
+}
@@ -23,6 +23,7 @@ require (
 	k8s.io/apimachinery v0.18.2 // Will be replaced
 	k8s.io/client-go v10.0.0+incompatible // Will be replaced
 	k8s.io/kubernetes v1.15.2
+	k8s.io/utils v0.0.0-20190221042446-c2654d5206da
 	sigs.k8s.io/kind v0.7.0
 )
 
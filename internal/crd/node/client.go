@@ -124,6 +124,50 @@ func (e *ConfigurationClient) watch(opts metav1.ListOptions) (watch.Interface, e
 	return wi, nil
 }
 
+// Get retrieves the NodeConfiguration with the given namespace and name
+func (e *ConfigurationClient) Get(namespace, name string) (*nodeconfigv1.NodeConfiguration, error) {
+	result := nodeconfigv1.NodeConfiguration{}
+
+	err := e.restClient.Get().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get node configuration %v/%v", namespace, name)
+	}
+
+	return &result, nil
+}
+
+// UpdateStatus writes the status of the given NodeConfiguration to kubernetes using the status subresource
+func (e *ConfigurationClient) UpdateStatus(cfg *nodeconfigv1.NodeConfiguration) (*nodeconfigv1.NodeConfiguration, error) {
+	result := nodeconfigv1.NodeConfiguration{}
+
+	err := e.restClient.Put().
+		Namespace(cfg.Namespace).
+		Resource(resource).
+		Name(cfg.Name).
+		SubResource("status").
+		Body(cfg).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update status of node configuration %v/%v", cfg.Namespace, cfg.Name)
+	}
+
+	return &result, nil
+}
+
+// IsStatusUpdate returns true if the given NodeConfigurations only differ in their status (or metadata),
+// which means the spec does not have to be handled again
+func IsStatusUpdate(oldCfg, newCfg *nodeconfigv1.NodeConfiguration) bool {
+	return oldCfg.ResourceVersion != newCfg.ResourceVersion && oldCfg.Generation == newCfg.Generation
+}
+
 // GetCrdLabel concatenates the namespace and name to create a unique label
 func GetCrdLabel(cfg *nodeconfigv1.NodeConfiguration) string {
 	return cfg.Namespace + "/" + cfg.Name
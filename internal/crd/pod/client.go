@@ -123,3 +123,57 @@ func (e *ConfigurationClient) watch(opts metav1.ListOptions) (watch.Interface, e
 
 	return wi, nil
 }
+
+// List retrieves all PodConfigurations in the namespace of this client
+func (e *ConfigurationClient) List() (*podconfigv1.PodConfigurationList, error) {
+	return e.list(metav1.ListOptions{})
+}
+
+// Get retrieves the PodConfiguration with the given namespace and name
+func (e *ConfigurationClient) Get(namespace, name string) (*podconfigv1.PodConfiguration, error) {
+	result := podconfigv1.PodConfiguration{}
+
+	err := e.restClient.Get().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pod configuration %v/%v", namespace, name)
+	}
+
+	return &result, nil
+}
+
+// UpdateStatus writes the status of the given PodConfiguration to kubernetes using the status subresource
+func (e *ConfigurationClient) UpdateStatus(cfg *podconfigv1.PodConfiguration) (*podconfigv1.PodConfiguration, error) {
+	result := podconfigv1.PodConfiguration{}
+
+	err := e.restClient.Put().
+		Namespace(cfg.Namespace).
+		Resource(resource).
+		Name(cfg.Name).
+		SubResource("status").
+		Body(cfg).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update status of pod configuration %v/%v", cfg.Namespace, cfg.Name)
+	}
+
+	return &result, nil
+}
+
+// IsStatusUpdate returns true if the given PodConfigurations only differ in their status (or metadata),
+// which means the spec does not have to be handled again
+func IsStatusUpdate(oldCfg, newCfg *podconfigv1.PodConfiguration) bool {
+	return oldCfg.ResourceVersion != newCfg.ResourceVersion && oldCfg.Generation == newCfg.Generation
+}
+
+// GetCrdLabel concatenates the namespace and name to create a unique label
+func GetCrdLabel(cfg *podconfigv1.PodConfiguration) string {
+	return cfg.Namespace + "/" + cfg.Name
+}
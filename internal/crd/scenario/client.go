@@ -0,0 +1,90 @@
+// Package scenario defines utilities for the Scenario CRD
+package scenario
+
+import (
+	"log"
+	"sync"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	scenariov1 "github.com/atlarge-research/apate/pkg/apis/scenario/v1"
+)
+
+const resource = "scenarios"
+
+var schemeLock sync.Once
+
+// Client is the client for the Scenario CRD
+type Client struct {
+	restClient rest.Interface
+	namespace  string
+}
+
+// NewForConfig creates a new Client based on the given restConfig and namespace
+func NewForConfig(c *rest.Config, namespace string) (*Client, error) {
+	schemeLock.Do(func() {
+		if err := scenariov1.AddToScheme(scheme.Scheme); err != nil {
+			log.Panicf("%+v", errors.Wrap(err, "failed to add scenario crd information to the scheme"))
+		}
+	})
+
+	config := *c
+	config.ContentConfig.GroupVersion = &scenariov1.SchemeGroupVersion
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.NewCodecFactory(scheme.Scheme)
+	config.UserAgent = rest.DefaultKubernetesUserAgent()
+
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create new scenario crd client for config")
+	}
+
+	return &Client{restClient: client, namespace: namespace}, nil
+}
+
+// List retrieves all Scenarios in the namespace of this client
+func (e *Client) List() (*scenariov1.ScenarioList, error) {
+	result := scenariov1.ScenarioList{}
+
+	err := e.restClient.Get().
+		Namespace(e.namespace).
+		Resource(resource).
+		VersionedParams(&metav1.ListOptions{}, scheme.ParameterCodec).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list scenarios")
+	}
+
+	return &result, nil
+}
+
+// UpdateStatus writes the status of the given Scenario to kubernetes using the status subresource
+func (e *Client) UpdateStatus(sc *scenariov1.Scenario) (*scenariov1.Scenario, error) {
+	result := scenariov1.Scenario{}
+
+	err := e.restClient.Put().
+		Namespace(sc.Namespace).
+		Resource(resource).
+		Name(sc.Name).
+		SubResource("status").
+		Body(sc).
+		Do().
+		Into(&result)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update status of scenario %v/%v", sc.Namespace, sc.Name)
+	}
+
+	return &result, nil
+}
+
+// GetCrdLabel concatenates the namespace and name to create a unique label
+func GetCrdLabel(sc *scenariov1.Scenario) string {
+	return sc.Namespace + "/" + sc.Name
+}
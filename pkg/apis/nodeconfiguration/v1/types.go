@@ -1,6 +1,9 @@
 package v1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 const (
 	// NodeConfigurationLabel defines the label which can used to find out to which node configuration crd a node belongs
@@ -20,11 +23,20 @@ const (
 // NodeConfiguration is a definition of a NodeConfiguration resource
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:path=nodeconfigurations,shortName=nc,singular=nodeconfiguration
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.spec.replicas`
+// +kubebuilder:printcolumn:name="Current",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.ready_replicas`
+// +kubebuilder:printcolumn:name="Unhealthy",type=integer,JSONPath=`.status.unhealthy_replicas`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type NodeConfiguration struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
 
 	Spec NodeConfigurationSpec `json:"spec"`
+
+	// +kubebuilder:validation:Optional
+	Status NodeConfigurationStatus `json:"status,omitempty"`
 }
 
 // NodeConfigurationList is a list of NodeConfiguration
@@ -54,6 +66,63 @@ type NodeConfigurationSpec struct {
 	Tasks []NodeConfigurationTask `json:"tasks,omitempty"`
 }
 
+// NodeConfigurationStatus is the status of the apatelets belonging to a NodeConfiguration, as seen by the control plane
+type NodeConfigurationStatus struct {
+	// Replicas is the amount of apatelets which have joined the apate cluster
+	// +kubebuilder:validation:Optional
+	Replicas int64 `json:"replicas"`
+
+	// ReadyReplicas is the amount of apatelets which report themselves as healthy
+	// +kubebuilder:validation:Optional
+	ReadyReplicas int64 `json:"ready_replicas"`
+
+	// UnhealthyReplicas is the amount of apatelets which are unhealthy and will be replaced by the control plane
+	// +kubebuilder:validation:Optional
+	UnhealthyReplicas int64 `json:"unhealthy_replicas"`
+
+	// ObservedGeneration is the generation of the spec which was last handled by the control plane
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observed_generation,omitempty"`
+
+	// LastError is the error which occurred while the control plane last handled the spec, if any
+	// +kubebuilder:validation:Optional
+	LastError string `json:"last_error,omitempty"`
+
+	// Conditions describe the current state of the NodeConfiguration
+	// +kubebuilder:validation:Optional
+	Conditions []NodeConfigurationCondition `json:"conditions,omitempty"`
+}
+
+// NodeConfigurationConditionType is the type of a NodeConfigurationCondition
+type NodeConfigurationConditionType string
+
+// Enum variants for NodeConfigurationConditionType
+const (
+	// NodeConfigurationAvailable is true if all desired apatelets have joined and are healthy
+	NodeConfigurationAvailable NodeConfigurationConditionType = "Available"
+
+	// NodeConfigurationReplicaFailure is true if the control plane failed to spawn or stop apatelets
+	NodeConfigurationReplicaFailure NodeConfigurationConditionType = "ReplicaFailure"
+)
+
+// NodeConfigurationCondition is a single condition of a NodeConfiguration
+type NodeConfigurationCondition struct {
+	// +kubebuilder:validation:Required
+	Type NodeConfigurationConditionType `json:"type"`
+
+	// +kubebuilder:validation:Required
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +kubebuilder:validation:Optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
 // NodeResources specifies the resources the node has available
 type NodeResources struct {
 	// +kubebuilder:validation:Required
@@ -82,6 +151,72 @@ type NodeConfigurationTask struct {
 	// The desired state of the node after this task
 	// +kubebuilder:validation:Required
 	State NodeConfigurationState `json:"state"`
+
+	// Repeat makes the task execute again after its timestamp
+	// +kubebuilder:validation:Optional
+	Repeat *TaskRepeat `json:"repeat,omitempty"`
+
+	// How long the state of this task lasts, after which the previous state is restored
+	// If not given, the state lasts until it is changed by another task
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	Duration string `json:"duration,omitempty"`
+
+	// Target selects the replicas this task applies to, if not given it applies to all replicas
+	// +kubebuilder:validation:Optional
+	Target *TaskTarget `json:"target,omitempty"`
+	// Jitter is the distribution from which a delay added to the timestamp is sampled, once for every replica
+	// Repetitions of the task keep the delay of their replica
+	// +kubebuilder:validation:Optional
+	Jitter *Latency `json:"jitter,omitempty"`
+}
+
+// TaskTarget selects a subset of the replicas by their index
+// Replicas are numbered from 0 by the control plane when they join, a replacement of a removed replica takes over its index
+// Exactly one of count, percentage and indices should be given
+type TaskTarget struct {
+	// Count selects this amount of replicas
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Count int64 `json:"count,omitempty"`
+
+	// Percentage selects this percentage of the desired replicas, rounded to the nearest replica
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Optional
+	Percentage int64 `json:"percentage,omitempty"`
+
+	// Offset is the amount of replicas skipped before selecting the count, or the percentage skipped before selecting the percentage
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Offset int64 `json:"offset,omitempty"`
+
+	// Indices selects replicas by their index, as a comma separated list of indices and inclusive ranges such as "0-4,7"
+	// +kubebuilder:validation:Optional
+	Indices string `json:"indices,omitempty"`
+}
+
+// TaskRepeat describes how a task is repeated after its timestamp
+// Exactly one of interval and cron should be given
+type TaskRepeat struct {
+	// The time between two executions of the task
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	Interval string `json:"interval,omitempty"`
+
+	// A cron schedule with five fields, such as "*/10 * * * *", on which the task is executed after its timestamp
+	// +kubebuilder:validation:Optional
+	Cron string `json:"cron,omitempty"`
+
+	// The total amount of times the task is executed, including the execution at its timestamp
+	// The default of 0 means the task is repeated indefinitely, unless until is given
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Count int `json:"count,omitempty"`
+
+	// The timestamp after which the task is no longer executed, in the same format as the timestamp of the task
+	// +kubebuilder:validation:Optional
+	Until string `json:"until,omitempty"`
 }
 
 // NodeConfigurationState is the state of the node, used for determining how to respond to request from kubernetes.
@@ -101,6 +236,11 @@ type NodeConfigurationState struct {
 	// +kubebuilder:validation:Optional
 	NetworkLatency string `json:"network_latency,omitempty"`
 
+	// Latency determines the distribution from which the latency added to requests by kubernetes is sampled.
+	// A new latency is sampled for every request. If set, this takes precedence over NetworkLatency
+	// +kubebuilder:validation:Optional
+	Latency *Latency `json:"latency,omitempty"`
+
 	// If set, HeartbeatFailed will result in the node no longer responding to pings
 	// +kubebuilder:default=false
 	// +kubebuilder:validation:Optional
@@ -109,6 +249,164 @@ type NodeConfigurationState struct {
 	// CustomState specifies a custom state
 	// +kubebuilder:validation:Optional
 	CustomState *NodeConfigurationCustomState `json:"custom_state,omitempty"`
+
+	// PodStartup sets the default durations of the phases the pods on the node go through before their containers run
+	// +kubebuilder:validation:Optional
+	PodStartup *PodStartup `json:"pod_startup,omitempty"`
+
+	// Images determines how the node pulls the images of its pods
+	// +kubebuilder:validation:Optional
+	Images *NodeImages `json:"images,omitempty"`
+
+	// Conditions determines when the node reports resource pressure, and which conditions it is forced to report
+	// The conditions replace those set by earlier states
+	// +kubebuilder:validation:Optional
+	Conditions *NodeConditions `json:"conditions,omitempty"`
+
+	// Capacity changes the resources of the node, such as to emulate hardware degradation or resizing the node
+	// Resources which are not set keep their value
+	// +kubebuilder:validation:Optional
+	Capacity *NodeCapacity `json:"capacity,omitempty"`
+
+	// Labels are added to the labels of the node, such as to emulate node pools
+	// The labels replace those set by earlier states, the labels Apate sets itself can't be changed
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the annotations of the node
+	// The annotations replace those set by earlier states
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Taints are added to the taints of the node, such as to emulate dedicated nodes or evict pods with NoExecute
+	// The taints replace those set by earlier states, the taint Apate sets itself can't be changed
+	// +kubebuilder:validation:Optional
+	Taints []Taint `json:"taints,omitempty"`
+}
+
+// Taint is a taint of a node, see https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/
+type Taint struct {
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +kubebuilder:validation:Required
+	Effect string `json:"effect"`
+}
+
+// NodeCapacity changes the resources of a node while it runs
+type NodeCapacity struct {
+	// +kubebuilder:validation:Optional
+	Memory string `json:"memory,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	CPU *int64 `json:"cpu,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Storage string `json:"storage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EphemeralStorage string `json:"ephemeral_storage,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxPods *int64 `json:"max_pods,omitempty"`
+}
+
+// NodeConditions determines the conditions reported by a node
+// Thresholds are fractions of the resources of the node, such as "0.85"
+type NodeConditions struct {
+	// MemoryPressure is the fraction of the memory above which the node reports MemoryPressure
+	// +kubebuilder:default="0.85"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	MemoryPressure string `json:"memory_pressure,omitempty"`
+
+	// DiskPressure is the fraction of the ephemeral storage above which the node reports DiskPressure
+	// +kubebuilder:default="0.85"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	DiskPressure string `json:"disk_pressure,omitempty"`
+
+	// OutOfDisk is the fraction of the ephemeral storage above which the node reports OutOfDisk and is no longer ready
+	// +kubebuilder:default="0.96"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	OutOfDisk string `json:"out_of_disk,omitempty"`
+
+	// PIDPressure is the fraction of the maximum amount of processes above which the node reports PIDPressure
+	// +kubebuilder:default="0.85"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	PIDPressure string `json:"pid_pressure,omitempty"`
+
+	// MaxPIDs is the maximum amount of processes which can run on the node
+	// +kubebuilder:default=32768
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxPIDs int64 `json:"max_pids,omitempty"`
+
+	// Forced are conditions which are reported regardless of the resources used on the node
+	// These may be any of the standard conditions, or custom ones such as those set by the node problem detector
+	// +kubebuilder:validation:Optional
+	Forced []ForcedNodeCondition `json:"forced,omitempty"`
+}
+
+// ForcedNodeCondition is a condition reported by a node regardless of its resources
+type ForcedNodeCondition struct {
+	// Type is the type of the condition, such as "Ready" or "KernelDeadlock"
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// Status is the status of the condition
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	// +kubebuilder:validation:Required
+	Status string `json:"status"`
+
+	// Reason is a brief reason for the status of the condition
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message about the status of the condition
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodeImages determines how a node pulls the images of its pods
+// Images are cached on the node once they have been pulled, and only one image is pulled at a time
+type NodeImages struct {
+	// Bandwidth is the amount of bytes per second with which images are pulled, such as "10M"
+	// If not set, pulling an image only takes the image pull latency of the pod startup
+	// +kubebuilder:validation:Optional
+	Bandwidth string `json:"bandwidth,omitempty"`
+
+	// DefaultSize is the size of the images of pods which don't specify the size of their images
+	// +kubebuilder:default="0B"
+	// +kubebuilder:validation:Optional
+	DefaultSize string `json:"default_size,omitempty"`
+
+	// Cached are the names of the images which are present on the node without being pulled, such as "nginx:1.19"
+	// +kubebuilder:validation:Optional
+	Cached []string `json:"cached,omitempty"`
+}
+
+// PodStartup describes the durations of the phases a pod goes through before its containers run
+type PodStartup struct {
+	// Scheduled is the time between the pod being scheduled on the node and the node starting to pull its images
+	// +kubebuilder:validation:Optional
+	Scheduled *Latency `json:"scheduled,omitempty"`
+
+	// ImagePull is the time it takes to pull the images of the pod
+	// +kubebuilder:validation:Optional
+	ImagePull *Latency `json:"image_pull,omitempty"`
+
+	// ContainerCreating is the time it takes to create the containers of the pod once their images have been pulled
+	// +kubebuilder:validation:Optional
+	ContainerCreating *Latency `json:"container_creating,omitempty"`
 }
 
 // NodeConfigurationCustomState is the state of the node, used for determining how to respond to request from kubernetes.
@@ -148,10 +446,97 @@ type NodeConfigurationCustomState struct {
 	// +kubebuilder:default=UNSET
 	// +kubebuilder:validation:Optional
 	NodePingResponse NodeResponse `json:"node_ping_response,omitempty"`
+
+	// CreatePodLatency determines the latency added to the CreatePod request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	CreatePodLatency *Latency `json:"create_pod_latency,omitempty"`
+
+	// UpdatePodLatency determines the latency added to the UpdatePod request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	UpdatePodLatency *Latency `json:"update_pod_latency,omitempty"`
+
+	// DeletePodLatency determines the latency added to the DeletePod request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	DeletePodLatency *Latency `json:"delete_pod_latency,omitempty"`
+
+	// GetPodLatency determines the latency added to the GetPod request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	GetPodLatency *Latency `json:"get_pod_latency,omitempty"`
+
+	// GetPodsLatency determines the latency added to the GetPods request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	GetPodsLatency *Latency `json:"get_pods_latency,omitempty"`
+
+	// GetPodStatusLatency determines the latency added to the GetPodStatus request, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	GetPodStatusLatency *Latency `json:"get_pod_status_latency,omitempty"`
+
+	// NodePingLatency determines the latency added to a heartbeat ping, on top of the latency of the node state
+	// +kubebuilder:validation:Optional
+	NodePingLatency *Latency `json:"node_ping_latency,omitempty"`
+}
+
+// Latency describes a distribution of latencies
+// Any time.ParseDuration format is accepted for the latencies, such as "10ms" or "42s"
+type Latency struct {
+	// The kind of distribution, which determines which of the other fields are used
+	// +kubebuilder:validation:Required
+	Distribution LatencyDistribution `json:"distribution"`
+
+	// The latency of a constant distribution
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+
+	// The lowest latency of a uniform distribution
+	// +kubebuilder:validation:Optional
+	Min string `json:"min,omitempty"`
+
+	// The highest latency of a uniform distribution
+	// +kubebuilder:validation:Optional
+	Max string `json:"max,omitempty"`
+
+	// The mean latency of a normal, exponential or log-normal distribution
+	// +kubebuilder:validation:Optional
+	Mean string `json:"mean,omitempty"`
+
+	// The standard deviation of the latency of a normal or log-normal distribution
+	// +kubebuilder:validation:Optional
+	StdDev string `json:"std_dev,omitempty"`
+
+	// The percentiles of an empirical distribution, the latency is interpolated linearly between them
+	// +kubebuilder:validation:Optional
+	Percentiles []LatencyPercentile `json:"percentiles,omitempty"`
+}
+
+// LatencyDistribution can be CONSTANT, UNIFORM, NORMAL, EXPONENTIAL, LOGNORMAL or EMPIRICAL
+// +kubebuilder:validation:Enum=CONSTANT;UNIFORM;NORMAL;EXPONENTIAL;LOGNORMAL;EMPIRICAL
+type LatencyDistribution string
+
+// Enum variants for LatencyDistribution
+const (
+	LatencyConstant    LatencyDistribution = "CONSTANT"
+	LatencyUniform     LatencyDistribution = "UNIFORM"
+	LatencyNormal      LatencyDistribution = "NORMAL"
+	LatencyExponential LatencyDistribution = "EXPONENTIAL"
+	LatencyLogNormal   LatencyDistribution = "LOGNORMAL"
+	LatencyEmpirical   LatencyDistribution = "EMPIRICAL"
+)
+
+// LatencyPercentile is a single percentile of an empirical latency distribution
+type LatencyPercentile struct {
+	// The percentile, between 0 and 100, such as "50" or "99.9"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:Required
+	Percentile string `json:"percentile"`
+
+	// The latency at this percentile
+	// +kubebuilder:validation:Required
+	Latency string `json:"latency"`
 }
 
 // NodeResponse can be NORMAL, TIMEOUT, ERROR or UNSET, and describes how a node should respond to a pod related request
-// +kubebuilder:validation:Enum=NORMAL;TIMEOUT;ERROR;UNSET
+// It can also be a weighted mix of responses, such as ERROR=5%,TIMEOUT=2%, in which case the remainder is NORMAL
+// +kubebuilder:validation:Pattern=`^(NORMAL|TIMEOUT|ERROR|UNSET|(NORMAL|TIMEOUT|ERROR)=[0-9]+(\.[0-9]+)?%(,(NORMAL|TIMEOUT|ERROR)=[0-9]+(\.[0-9]+)?%)*)$`
 type NodeResponse string
 
 // Enum variants for PodResponse
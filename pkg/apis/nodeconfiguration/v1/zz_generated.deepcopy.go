@@ -8,12 +8,108 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForcedNodeCondition) DeepCopyInto(out *ForcedNodeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForcedNodeCondition.
+func (in *ForcedNodeCondition) DeepCopy() *ForcedNodeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ForcedNodeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Latency) DeepCopyInto(out *Latency) {
+	*out = *in
+	if in.Percentiles != nil {
+		in, out := &in.Percentiles, &out.Percentiles
+		*out = make([]LatencyPercentile, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Latency.
+func (in *Latency) DeepCopy() *Latency {
+	if in == nil {
+		return nil
+	}
+	out := new(Latency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyPercentile) DeepCopyInto(out *LatencyPercentile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyPercentile.
+func (in *LatencyPercentile) DeepCopy() *LatencyPercentile {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyPercentile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCapacity) DeepCopyInto(out *NodeCapacity) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCapacity.
+func (in *NodeCapacity) DeepCopy() *NodeCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConditions) DeepCopyInto(out *NodeConditions) {
+	*out = *in
+	if in.Forced != nil {
+		in, out := &in.Forced, &out.Forced
+		*out = make([]ForcedNodeCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConditions.
+func (in *NodeConditions) DeepCopy() *NodeConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfiguration) DeepCopyInto(out *NodeConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfiguration.
@@ -34,9 +130,60 @@ func (in *NodeConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigurationCondition) DeepCopyInto(out *NodeConfigurationCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigurationCondition.
+func (in *NodeConfigurationCondition) DeepCopy() *NodeConfigurationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigurationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfigurationCustomState) DeepCopyInto(out *NodeConfigurationCustomState) {
 	*out = *in
+	if in.CreatePodLatency != nil {
+		in, out := &in.CreatePodLatency, &out.CreatePodLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdatePodLatency != nil {
+		in, out := &in.UpdatePodLatency, &out.UpdatePodLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletePodLatency != nil {
+		in, out := &in.DeletePodLatency, &out.DeletePodLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GetPodLatency != nil {
+		in, out := &in.GetPodLatency, &out.GetPodLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GetPodsLatency != nil {
+		in, out := &in.GetPodsLatency, &out.GetPodsLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GetPodStatusLatency != nil {
+		in, out := &in.GetPodStatusLatency, &out.GetPodStatusLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePingLatency != nil {
+		in, out := &in.NodePingLatency, &out.NodePingLatency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigurationCustomState.
@@ -108,10 +255,54 @@ func (in *NodeConfigurationSpec) DeepCopy() *NodeConfigurationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfigurationState) DeepCopyInto(out *NodeConfigurationState) {
 	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomState != nil {
 		in, out := &in.CustomState, &out.CustomState
 		*out = new(NodeConfigurationCustomState)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodStartup != nil {
+		in, out := &in.PodStartup, &out.PodStartup
+		*out = new(PodStartup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(NodeImages)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = new(NodeConditions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(NodeCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]Taint, len(*in))
+		copy(*out, *in)
 	}
 }
 
@@ -125,10 +316,47 @@ func (in *NodeConfigurationState) DeepCopy() *NodeConfigurationState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigurationStatus) DeepCopyInto(out *NodeConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]NodeConfigurationCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigurationStatus.
+func (in *NodeConfigurationStatus) DeepCopy() *NodeConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfigurationTask) DeepCopyInto(out *NodeConfigurationTask) {
 	*out = *in
 	in.State.DeepCopyInto(&out.State)
+	if in.Repeat != nil {
+		in, out := &in.Repeat, &out.Repeat
+		*out = new(TaskRepeat)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(TaskTarget)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigurationTask.
@@ -141,6 +369,26 @@ func (in *NodeConfigurationTask) DeepCopy() *NodeConfigurationTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImages) DeepCopyInto(out *NodeImages) {
+	*out = *in
+	if in.Cached != nil {
+		in, out := &in.Cached, &out.Cached
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImages.
+func (in *NodeImages) DeepCopy() *NodeImages {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResources) DeepCopyInto(out *NodeResources) {
 	*out = *in
@@ -155,3 +403,78 @@ func (in *NodeResources) DeepCopy() *NodeResources {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStartup) DeepCopyInto(out *PodStartup) {
+	*out = *in
+	if in.Scheduled != nil {
+		in, out := &in.Scheduled, &out.Scheduled
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePull != nil {
+		in, out := &in.ImagePull, &out.ImagePull
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerCreating != nil {
+		in, out := &in.ContainerCreating, &out.ContainerCreating
+		*out = new(Latency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStartup.
+func (in *PodStartup) DeepCopy() *PodStartup {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStartup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Taint) DeepCopyInto(out *Taint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taint.
+func (in *Taint) DeepCopy() *Taint {
+	if in == nil {
+		return nil
+	}
+	out := new(Taint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRepeat) DeepCopyInto(out *TaskRepeat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRepeat.
+func (in *TaskRepeat) DeepCopy() *TaskRepeat {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRepeat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTarget) DeepCopyInto(out *TaskTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTarget.
+func (in *TaskTarget) DeepCopy() *TaskTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTarget)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,6 +2,8 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 )
 
 const (
@@ -11,14 +13,19 @@ const (
 
 // PodConfiguration is a definition of PodConfiguration resource.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:path=podconfigurations,shortName=pc,singular=podconfiguration
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pods",type=integer,JSONPath=`.status.matched_pods`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type PodConfiguration struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
 
 	Spec PodConfigurationSpec `json:"spec"`
+
+	// +kubebuilder:validation:Optional
+	Status PodConfigurationStatus `json:"status,omitempty"`
 }
 
 // PodConfigurationList is a list of PodConfigurations.
@@ -32,6 +39,21 @@ type PodConfigurationList struct {
 
 // PodConfigurationSpec is the spec which belongs to the PodConfiguration CRD
 type PodConfigurationSpec struct {
+	// Selector selects the pods in the same namespace this configuration applies to, besides the pods with the apate label
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Owner selects the pods in the same namespace which are owned by the given resource.
+	// If both a selector and an owner are given, pods have to match both
+	// +kubebuilder:validation:Optional
+	Owner *PodConfigurationOwner `json:"owner,omitempty"`
+
+	// Priority determines which configuration is used if several selectors or owners match the same pod,
+	// the configuration with the highest priority is used. Pods with the apate label always use that configuration
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Optional
+	Priority int `json:"priority,omitempty"`
+
 	// A direct way to update state, this will circumvent the timestamps / scenario
 	// +kubebuilder:validation:Optional
 	PodConfigurationState `json:",inline,omitempty"`
@@ -39,6 +61,74 @@ type PodConfigurationSpec struct {
 	// The tasks to be executed
 	// +kubebuilder:validation:Optional
 	Tasks []PodConfigurationTask `json:"tasks,omitempty"`
+
+	// Trace replays recorded resource usage relative to the start of every pod
+	// +kubebuilder:validation:Optional
+	Trace *ResourceTrace `json:"trace,omitempty"`
+}
+
+// ResourceTrace is a CSV file containing recorded resource usage
+// Exactly one of data and config_map should be given
+type ResourceTrace struct {
+	// Data is the CSV data of the trace
+	// +kubebuilder:validation:Optional
+	Data string `json:"data,omitempty"`
+
+	// ConfigMap selects a key of a ConfigMap in the same namespace which contains the CSV data of the trace
+	// +kubebuilder:validation:Optional
+	ConfigMap *ConfigMapKeySelector `json:"config_map,omitempty"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap
+type ConfigMapKeySelector struct {
+	// Name is the name of the ConfigMap
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key is the key in the data of the ConfigMap
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// PodConfigurationOwner identifies the resource owning the pods a PodConfiguration applies to
+type PodConfigurationOwner struct {
+	// Kind is the kind of the owner, such as Deployment, StatefulSet, ReplicaSet, DaemonSet or Job
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Name is the name of the owner
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// PodConfigurationStatus is the status of a PodConfiguration, aggregated by the control plane from all apatelets
+type PodConfigurationStatus struct {
+	// MatchedPods is the amount of pods which currently match this PodConfiguration
+	// +kubebuilder:validation:Optional
+	MatchedPods int64 `json:"matched_pods"`
+
+	// Tasks contains, for every task in the spec, on how many pods it has been executed
+	// +kubebuilder:validation:Optional
+	Tasks []PodConfigurationTaskStatus `json:"tasks,omitempty"`
+
+	// Errors contains the errors which occurred while the apatelets parsed the spec, if any
+	// +kubebuilder:validation:Optional
+	Errors []string `json:"errors,omitempty"`
+}
+
+// PodConfigurationTaskStatus describes how often a single task of a PodConfiguration has been executed
+type PodConfigurationTaskStatus struct {
+	// Index is the index of the task in the spec
+	// +kubebuilder:validation:Required
+	Index int `json:"index"`
+
+	// Timestamp is the timestamp of the task in the spec
+	// +kubebuilder:validation:Required
+	Timestamp string `json:"timestamp"`
+
+	// FiredPods is the amount of pods on which the task has been executed
+	// +kubebuilder:validation:Optional
+	FiredPods int64 `json:"fired_pods"`
 }
 
 // PodConfigurationTask is a single task which updates a pod state and is executed at a timestamp
@@ -57,6 +147,69 @@ type PodConfigurationTask struct {
 	// The state to be set
 	// +kubebuilder:validation:Required
 	State PodConfigurationState `json:"state"`
+
+	// Repeat makes the task execute again after its timestamp
+	// If the task is relative to the pod, it should be limited by count or until and cron is not supported
+	// +kubebuilder:validation:Optional
+	Repeat *TaskRepeat `json:"repeat,omitempty"`
+
+	// How long the state of this task lasts, after which the previous state is restored
+	// If not given, the state lasts until it is changed by another task
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	Duration string `json:"duration,omitempty"`
+
+	// Target limits the task to a fraction of the pods, if not given it applies to all pods
+	// +kubebuilder:validation:Optional
+	Target *TaskTarget `json:"target,omitempty"`
+	// Jitter is the distribution from which a delay added to the timestamp is sampled, separately for every pod
+	// For tasks relative to the pod the whole duration is delayed, for other tasks only the start of it
+	// +kubebuilder:validation:Optional
+	Jitter *nodeconfigv1.Latency `json:"jitter,omitempty"`
+}
+
+// TaskTarget selects a fraction of the pods
+// Whether a pod is selected is determined by hashing the seed together with the uid of the pod
+type TaskTarget struct {
+	// Percentage is the percentage of pods which are selected
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	Percentage int64 `json:"percentage"`
+
+	// Offset is the percentage of pods skipped before selecting, targets with the same seed and consecutive offsets select different pods
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:validation:Optional
+	Offset int64 `json:"offset,omitempty"`
+
+	// Seed determines which pods are selected, targets with different seeds select independent fractions of pods
+	// +kubebuilder:validation:Optional
+	Seed string `json:"seed,omitempty"`
+}
+
+// TaskRepeat describes how a task is repeated after its timestamp
+// Exactly one of interval and cron should be given
+type TaskRepeat struct {
+	// The time between two executions of the task
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	Interval string `json:"interval,omitempty"`
+
+	// A cron schedule with five fields, such as "*/10 * * * *", on which the task is executed after its timestamp
+	// +kubebuilder:validation:Optional
+	Cron string `json:"cron,omitempty"`
+
+	// The total amount of times the task is executed, including the execution at its timestamp
+	// The default of 0 means the task is repeated indefinitely, unless until is given
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Count int `json:"count,omitempty"`
+
+	// The timestamp after which the task is no longer executed, in the same format as the timestamp of the task
+	// +kubebuilder:validation:Optional
+	Until string `json:"until,omitempty"`
 }
 
 // PodConfigurationState is the state to be set for the related pods
@@ -86,6 +239,26 @@ type PodConfigurationState struct {
 	// +kubebuilder:validation:Optional
 	GetPodStatusResponse PodResponse `json:"get_pod_status_response,omitempty"`
 
+	// CreatePodLatency determines the latency added to the CreatePod request, on top of the latency on node level
+	// +kubebuilder:validation:Optional
+	CreatePodLatency *nodeconfigv1.Latency `json:"create_pod_latency,omitempty"`
+
+	// UpdatePodLatency determines the latency added to the UpdatePod request, on top of the latency on node level
+	// +kubebuilder:validation:Optional
+	UpdatePodLatency *nodeconfigv1.Latency `json:"update_pod_latency,omitempty"`
+
+	// DeletePodLatency determines the latency added to the DeletePod request, on top of the latency on node level
+	// +kubebuilder:validation:Optional
+	DeletePodLatency *nodeconfigv1.Latency `json:"delete_pod_latency,omitempty"`
+
+	// GetPodLatency determines the latency added to the GetPod request, on top of the latency on node level
+	// +kubebuilder:validation:Optional
+	GetPodLatency *nodeconfigv1.Latency `json:"get_pod_latency,omitempty"`
+
+	// GetPodStatusLatency determines the latency added to the GetPodStatus request, on top of the latency on node level
+	// +kubebuilder:validation:Optional
+	GetPodStatusLatency *nodeconfigv1.Latency `json:"get_pod_status_latency,omitempty"`
+
 	// PodResources sets the amount of resources the related pods are using
 	// +kubebuilder:validation:Optional
 	PodResources *PodResources `json:"pod_resources,omitempty"`
@@ -93,6 +266,257 @@ type PodConfigurationState struct {
 	// PodStatus updates the current pod status
 	// +kubebuilder:default=UNSET
 	PodStatus PodStatus `json:"pod_status,omitempty"`
+
+	// Crash determines when the containers of running pods crash, after which they are restarted according to the restart policy of the pod
+	// +kubebuilder:validation:Optional
+	Crash *PodCrash `json:"crash,omitempty"`
+
+	// Containers sets the state of individual containers of the related pods, overriding the pod state for them
+	// The containers replace those set by earlier states, containers which are not listed follow the pod state
+	// +kubebuilder:validation:Optional
+	Containers []ContainerState `json:"containers,omitempty"`
+
+	// Probes sets the results of the readiness and liveness probes of the containers of the related pods
+	// +kubebuilder:validation:Optional
+	Probes *PodProbes `json:"probes,omitempty"`
+
+	// Startup sets the durations of the phases the related pods go through before their containers run
+	// Phases which are not set use the pod startup of the node state
+	// +kubebuilder:validation:Optional
+	Startup *PodStartup `json:"startup,omitempty"`
+
+	// ImagePull determines how the images of the related pods are pulled
+	// +kubebuilder:validation:Optional
+	ImagePull *PodImagePull `json:"image_pull,omitempty"`
+
+	// Runtime makes the related pods succeed or fail once they have run for some time, like the pods of a job
+	// +kubebuilder:validation:Optional
+	Runtime *PodRuntime `json:"runtime,omitempty"`
+
+	// Logs sets the synthetic log lines written by the containers of the related pods
+	// +kubebuilder:validation:Optional
+	Logs *PodLogs `json:"logs,omitempty"`
+
+	// Exec scripts the responses to commands executed in the containers of the related pods, the first matching rule applies
+	// The rules replace those set by earlier states, commands which match no rule succeed without output
+	// +kubebuilder:validation:Optional
+	Exec []ExecRule `json:"exec,omitempty"`
+
+	// Processes is the amount of processes running in the related pods, which counts towards the PID pressure of their node
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Processes *int64 `json:"processes,omitempty"`
+}
+
+// ExecRule scripts the response to commands executed in the containers of a pod
+type ExecRule struct {
+	// Command is a regular expression which should match the whole command and its arguments separated by spaces, such as "cat /tmp/.*"
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+
+	// Container is the name of the container to which the rule applies, if not set it applies to all containers
+	// +kubebuilder:validation:Optional
+	Container string `json:"container,omitempty"`
+
+	// Stdout is written to the standard output of the command
+	// +kubebuilder:validation:Optional
+	Stdout string `json:"stdout,omitempty"`
+
+	// Stderr is written to the standard error of the command
+	// +kubebuilder:validation:Optional
+	Stderr string `json:"stderr,omitempty"`
+
+	// ExitCode is the exit code of the command
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +kubebuilder:validation:Optional
+	ExitCode int32 `json:"exit_code,omitempty"`
+
+	// Delay is the time it takes for the command to finish
+	// +kubebuilder:validation:Optional
+	Delay *nodeconfigv1.Latency `json:"delay,omitempty"`
+}
+
+// PodLogs describes the synthetic log lines written by the containers of a pod
+// Exactly one of template and replay should be given
+type PodLogs struct {
+	// Template is a Go template from which every line is generated, such as "{{.Time}} handled request {{.Line}}"
+	// The fields Pod, Namespace, Container, Line and Time are available
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty"`
+
+	// Replay contains the lines which are replayed, starting over once all have been written
+	// +kubebuilder:validation:Optional
+	Replay *LogReplay `json:"replay,omitempty"`
+
+	// Rate is the amount of lines written per second, such as "0.5"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:default="1"
+	// +kubebuilder:validation:Optional
+	Rate string `json:"rate,omitempty"`
+
+	// FailureBurst is the amount of lines written at once when a container fails
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Optional
+	FailureBurst int32 `json:"failure_burst,omitempty"`
+
+	// FailureTemplate is the Go template from which the lines written when a container fails are generated
+	// Besides the fields of the template, the field ExitCode is available
+	// +kubebuilder:validation:Optional
+	FailureTemplate string `json:"failure_template,omitempty"`
+}
+
+// LogReplay contains log lines which are replayed
+// Exactly one of data and config_map should be given
+type LogReplay struct {
+	// Data contains the lines, separated by newlines
+	// +kubebuilder:validation:Optional
+	Data string `json:"data,omitempty"`
+
+	// ConfigMap selects a key of a ConfigMap in the same namespace which contains the lines
+	// +kubebuilder:validation:Optional
+	ConfigMap *ConfigMapKeySelector `json:"config_map,omitempty"`
+}
+
+// PodRuntime describes how long a pod runs before it completes
+// At least one of latency and annotation should be given
+type PodRuntime struct {
+	// Latency is the distribution from which the runtime of a pod is sampled, counting from when its containers started
+	// +kubebuilder:validation:Optional
+	Latency *nodeconfigv1.Latency `json:"latency,omitempty"`
+
+	// Annotation is the annotation of a pod containing its runtime, such as "42s", which takes precedence over the latency
+	// +kubebuilder:validation:Optional
+	Annotation string `json:"annotation,omitempty"`
+
+	// The probability that a pod fails instead of succeeds once its runtime has elapsed, such as "0.01"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	FailureProbability string `json:"failure_probability,omitempty"`
+
+	// The exit code of the containers of a failed pod
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Optional
+	ExitCode int32 `json:"exit_code,omitempty"`
+}
+
+// PodImagePull determines how the images of a pod are pulled
+type PodImagePull struct {
+	// Size is the size of every image of the pod, if not set the default size of the node is used
+	// +kubebuilder:validation:Optional
+	Size string `json:"size,omitempty"`
+
+	// Fail makes pulling images which are not cached on the node fail, after which the pull is retried with a backoff
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Fail bool `json:"fail,omitempty"`
+}
+
+// PodStartup describes the durations of the phases a pod goes through before its containers run
+type PodStartup struct {
+	// Scheduled is the time between the pod being scheduled on the node and the node starting to pull its images
+	// +kubebuilder:validation:Optional
+	Scheduled *nodeconfigv1.Latency `json:"scheduled,omitempty"`
+
+	// ImagePull is the time it takes to pull the images of the pod
+	// +kubebuilder:validation:Optional
+	ImagePull *nodeconfigv1.Latency `json:"image_pull,omitempty"`
+
+	// ContainerCreating is the time it takes to create the containers of the pod once their images have been pulled
+	// +kubebuilder:validation:Optional
+	ContainerCreating *nodeconfigv1.Latency `json:"container_creating,omitempty"`
+}
+
+// PodProbes are the results of the probes in the pod spec, which are run with the timings given in the pod spec
+type PodProbes struct {
+	// Readiness is the result of readiness probes, containers of which the readiness probe fails become unready
+	// +kubebuilder:default=SUCCEED
+	// +kubebuilder:validation:Optional
+	Readiness ProbeResult `json:"readiness,omitempty"`
+
+	// Liveness is the result of liveness probes, containers of which the liveness probe fails are killed and restarted
+	// +kubebuilder:default=SUCCEED
+	// +kubebuilder:validation:Optional
+	Liveness ProbeResult `json:"liveness,omitempty"`
+}
+
+// ProbeResult can be SUCCEED or FAIL, and describes the result of a probe
+// +kubebuilder:validation:Enum=SUCCEED;FAIL
+type ProbeResult string
+
+// Enum variants for ProbeResult
+const (
+	ProbeResultSucceed ProbeResult = "SUCCEED"
+	ProbeResultFail    ProbeResult = "FAIL"
+)
+
+// ContainerState is the state of a single container of the related pods
+type ContainerState struct {
+	// Name is the name of the container in the pod spec
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Status overrides the status of the container, if not given it follows the pod status
+	// +kubebuilder:default=UNSET
+	// +kubebuilder:validation:Optional
+	Status ContainerStatus `json:"status,omitempty"`
+
+	// Ready determines whether the container is ready while it is running
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Ready *bool `json:"ready,omitempty"`
+
+	// ExitCode is the exit code of a TERMINATED container
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Optional
+	ExitCode int32 `json:"exit_code,omitempty"`
+
+	// Reason is why a WAITING or TERMINATED container is in its state, such as "ImagePullBackOff" or "OOMKilled"
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// Resources sets the amount of resources the container uses, which is added to the usage of the pod
+	// If it exceeds the limits of the container, the container is killed
+	// +kubebuilder:validation:Optional
+	Resources *PodResources `json:"resources,omitempty"`
+}
+
+// ContainerStatus can be RUNNING, WAITING, TERMINATED or UNSET, and describes the state of a container
+// +kubebuilder:validation:Enum=RUNNING;WAITING;TERMINATED;UNSET
+type ContainerStatus string
+
+// Enum variants for ContainerStatus
+const (
+	ContainerStatusRunning    ContainerStatus = "RUNNING"
+	ContainerStatusWaiting    ContainerStatus = "WAITING"
+	ContainerStatusTerminated ContainerStatus = "TERMINATED"
+	ContainerStatusUnset      ContainerStatus = "UNSET"
+)
+
+// PodCrash describes when the containers of a running pod crash
+// At least one of probability and after should be given
+type PodCrash struct {
+	// The probability that a container crashes during every interval it is running, such as "0.01"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	Probability string `json:"probability,omitempty"`
+
+	// The interval to which the probability applies
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:default="1m"
+	// +kubebuilder:validation:Optional
+	Interval string `json:"interval,omitempty"`
+
+	// The time after which a running container crashes
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	After string `json:"after,omitempty"`
+
+	// The exit code of a crashed container
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Optional
+	ExitCode int32 `json:"exit_code,omitempty"`
 }
 
 // PodResources defines the current resource usage of the pod
@@ -112,6 +536,37 @@ type PodResources struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="0B"
 	EphemeralStorage string `json:"ephemeral_storage,omitempty"`
+
+	// Curve determines how the usage changes over time, by default the usage is set immediately
+	// +kubebuilder:validation:Optional
+	Curve *UsageCurve `json:"curve,omitempty"`
+}
+
+// UsageCurve describes how the resource usage of a pod changes over time
+type UsageCurve struct {
+	// The shape of the curve
+	// STEP sets the usage immediately, LINEAR moves the usage linearly from its previous value to the new one,
+	// SINE oscillates around the new usage and RANDOM_WALK wanders randomly around the new usage
+	// +kubebuilder:validation:Enum=STEP;LINEAR;SINE;RANDOM_WALK
+	// +kubebuilder:validation:Required
+	Shape string `json:"shape"`
+
+	// The time it takes a LINEAR curve to reach the new usage
+	// +kubebuilder:validation:Optional
+	Ramp string `json:"ramp,omitempty"`
+
+	// The time it takes a SINE curve to complete a single oscillation
+	// +kubebuilder:validation:Optional
+	Period string `json:"period,omitempty"`
+
+	// The maximum deviation of a SINE curve or RANDOM_WALK, as a fraction of the new usage, such as "0.2"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	Amplitude string `json:"amplitude,omitempty"`
+
+	// The time between two steps of a RANDOM_WALK
+	// +kubebuilder:validation:Optional
+	Interval string `json:"interval,omitempty"`
 }
 
 // PodStatus can be PENDING, RUNNING, SUCCEEDED, FAILED, UNKNOWN or UNSET, and describes the state of a pod.
@@ -129,7 +584,8 @@ const (
 )
 
 // PodResponse can be NORMAL, TIMEOUT, ERROR or UNSET, and describes how a pod should respond
-// +kubebuilder:validation:Enum=NORMAL;TIMEOUT;ERROR;UNSET
+// It can also be a weighted mix of responses, such as ERROR=5%,TIMEOUT=2%, in which case the remainder is NORMAL
+// +kubebuilder:validation:Pattern=`^(NORMAL|TIMEOUT|ERROR|UNSET|(NORMAL|TIMEOUT|ERROR)=[0-9]+(\.[0-9]+)?%(,(NORMAL|TIMEOUT|ERROR)=[0-9]+(\.[0-9]+)?%)*)$`
 type PodResponse string
 
 // Enum variants for PodResponse
@@ -5,15 +5,98 @@
 package v1
 
 import (
+	nodeconfigurationv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerState) DeepCopyInto(out *ContainerState) {
+	*out = *in
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(PodResources)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerState.
+func (in *ContainerState) DeepCopy() *ContainerState {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecRule) DeepCopyInto(out *ExecRule) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecRule.
+func (in *ExecRule) DeepCopy() *ExecRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogReplay) DeepCopyInto(out *LogReplay) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogReplay.
+func (in *LogReplay) DeepCopy() *LogReplay {
+	if in == nil {
+		return nil
+	}
+	out := new(LogReplay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfiguration) DeepCopyInto(out *PodConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfiguration.
@@ -66,9 +149,34 @@ func (in *PodConfigurationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigurationOwner) DeepCopyInto(out *PodConfigurationOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationOwner.
+func (in *PodConfigurationOwner) DeepCopy() *PodConfigurationOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigurationOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfigurationSpec) DeepCopyInto(out *PodConfigurationSpec) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(PodConfigurationOwner)
+		**out = **in
+	}
 	in.PodConfigurationState.DeepCopyInto(&out.PodConfigurationState)
 	if in.Tasks != nil {
 		in, out := &in.Tasks, &out.Tasks
@@ -77,6 +185,11 @@ func (in *PodConfigurationSpec) DeepCopyInto(out *PodConfigurationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Trace != nil {
+		in, out := &in.Trace, &out.Trace
+		*out = new(ResourceTrace)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationSpec.
@@ -92,9 +205,83 @@ func (in *PodConfigurationSpec) DeepCopy() *PodConfigurationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfigurationState) DeepCopyInto(out *PodConfigurationState) {
 	*out = *in
+	if in.CreatePodLatency != nil {
+		in, out := &in.CreatePodLatency, &out.CreatePodLatency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdatePodLatency != nil {
+		in, out := &in.UpdatePodLatency, &out.UpdatePodLatency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletePodLatency != nil {
+		in, out := &in.DeletePodLatency, &out.DeletePodLatency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GetPodLatency != nil {
+		in, out := &in.GetPodLatency, &out.GetPodLatency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GetPodStatusLatency != nil {
+		in, out := &in.GetPodStatusLatency, &out.GetPodStatusLatency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodResources != nil {
 		in, out := &in.PodResources, &out.PodResources
 		*out = new(PodResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Crash != nil {
+		in, out := &in.Crash, &out.Crash
+		*out = new(PodCrash)
+		**out = **in
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(PodProbes)
+		**out = **in
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(PodStartup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePull != nil {
+		in, out := &in.ImagePull, &out.ImagePull
+		*out = new(PodImagePull)
+		**out = **in
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(PodRuntime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(PodLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = make([]ExecRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = new(int64)
 		**out = **in
 	}
 }
@@ -109,10 +296,50 @@ func (in *PodConfigurationState) DeepCopy() *PodConfigurationState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigurationStatus) DeepCopyInto(out *PodConfigurationStatus) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]PodConfigurationTaskStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationStatus.
+func (in *PodConfigurationStatus) DeepCopy() *PodConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfigurationTask) DeepCopyInto(out *PodConfigurationTask) {
 	*out = *in
 	in.State.DeepCopyInto(&out.State)
+	if in.Repeat != nil {
+		in, out := &in.Repeat, &out.Repeat
+		*out = new(TaskRepeat)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(TaskTarget)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationTask.
@@ -125,9 +352,94 @@ func (in *PodConfigurationTask) DeepCopy() *PodConfigurationTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigurationTaskStatus) DeepCopyInto(out *PodConfigurationTaskStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationTaskStatus.
+func (in *PodConfigurationTaskStatus) DeepCopy() *PodConfigurationTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigurationTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCrash) DeepCopyInto(out *PodCrash) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCrash.
+func (in *PodCrash) DeepCopy() *PodCrash {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCrash)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodImagePull) DeepCopyInto(out *PodImagePull) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodImagePull.
+func (in *PodImagePull) DeepCopy() *PodImagePull {
+	if in == nil {
+		return nil
+	}
+	out := new(PodImagePull)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLogs) DeepCopyInto(out *PodLogs) {
+	*out = *in
+	if in.Replay != nil {
+		in, out := &in.Replay, &out.Replay
+		*out = new(LogReplay)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLogs.
+func (in *PodLogs) DeepCopy() *PodLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodProbes) DeepCopyInto(out *PodProbes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodProbes.
+func (in *PodProbes) DeepCopy() *PodProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(PodProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodResources) DeepCopyInto(out *PodResources) {
 	*out = *in
+	if in.Curve != nil {
+		in, out := &in.Curve, &out.Curve
+		*out = new(UsageCurve)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodResources.
@@ -139,3 +451,118 @@ func (in *PodResources) DeepCopy() *PodResources {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRuntime) DeepCopyInto(out *PodRuntime) {
+	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRuntime.
+func (in *PodRuntime) DeepCopy() *PodRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStartup) DeepCopyInto(out *PodStartup) {
+	*out = *in
+	if in.Scheduled != nil {
+		in, out := &in.Scheduled, &out.Scheduled
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePull != nil {
+		in, out := &in.ImagePull, &out.ImagePull
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerCreating != nil {
+		in, out := &in.ContainerCreating, &out.ContainerCreating
+		*out = new(nodeconfigurationv1.Latency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStartup.
+func (in *PodStartup) DeepCopy() *PodStartup {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStartup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTrace) DeepCopyInto(out *ResourceTrace) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTrace.
+func (in *ResourceTrace) DeepCopy() *ResourceTrace {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTrace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRepeat) DeepCopyInto(out *TaskRepeat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRepeat.
+func (in *TaskRepeat) DeepCopy() *TaskRepeat {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRepeat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTarget) DeepCopyInto(out *TaskTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTarget.
+func (in *TaskTarget) DeepCopy() *TaskTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageCurve) DeepCopyInto(out *UsageCurve) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageCurve.
+func (in *UsageCurve) DeepCopy() *UsageCurve {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageCurve)
+	in.DeepCopyInto(out)
+	return out
+}
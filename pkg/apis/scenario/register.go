@@ -0,0 +1,7 @@
+// Package scenario contains the different API versions of the Scenario CRD.
+package scenario
+
+const (
+	// GroupName is the groupname of the CRD
+	GroupName = "apate.opendc.org"
+)
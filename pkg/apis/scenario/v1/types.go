@@ -0,0 +1,109 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Scenario is a definition of Scenario resource.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=scenarios,shortName=sc,singular=scenario
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.start_time`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type Scenario struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec ScenarioSpec `json:"spec"`
+
+	// +kubebuilder:validation:Optional
+	Status ScenarioStatus `json:"status,omitempty"`
+}
+
+// ScenarioList is a list of Scenarios.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ScenarioList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Scenario `json:"items"`
+}
+
+// ScenarioSpec is the spec which belongs to the Scenario CRD
+type ScenarioSpec struct {
+	// NodeConfigurations are the names of the node configurations in the same namespace which are part of this scenario
+	// +kubebuilder:validation:Optional
+	NodeConfigurations []string `json:"node_configurations,omitempty"`
+
+	// PodConfigurations are the names of the pod configurations in the same namespace which are part of this scenario
+	// +kubebuilder:validation:Optional
+	PodConfigurations []string `json:"pod_configurations,omitempty"`
+
+	// StartPolicy determines when the control plane starts the scenario
+	// +kubebuilder:default=WHEN_READY
+	// +kubebuilder:validation:Optional
+	StartPolicy ScenarioStartPolicy `json:"start_policy,omitempty"`
+
+	// Duration is how long the scenario runs before it is considered finished, if not given it never finishes
+	// Any time.ParseDuration format is accepted, such as "10ms" or "42s"
+	// +kubebuilder:validation:Optional
+	Duration string `json:"duration,omitempty"`
+
+	// DisableWatchers stops the watchers of the node and pod configurations once the scenario has started,
+	// which means changes to them are no longer picked up
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	DisableWatchers bool `json:"disable_watchers,omitempty"`
+}
+
+// ScenarioStartPolicy determines when a scenario is started
+// +kubebuilder:validation:Enum=IMMEDIATE;WHEN_READY;MANUAL
+type ScenarioStartPolicy string
+
+// Enum variants for ScenarioStartPolicy
+const (
+	// StartImmediate starts the scenario as soon as all referenced configurations exist
+	StartImmediate ScenarioStartPolicy = "IMMEDIATE"
+
+	// StartWhenReady starts the scenario once all referenced configurations exist and all their apatelets are ready
+	StartWhenReady ScenarioStartPolicy = "WHEN_READY"
+
+	// StartManual waits for the scenario to be started using apate-cli run
+	StartManual ScenarioStartPolicy = "MANUAL"
+)
+
+// ScenarioPhase is the phase a scenario is in
+// +kubebuilder:validation:Enum=PENDING;RUNNING;FINISHED;FAILED
+type ScenarioPhase string
+
+// Enum variants for ScenarioPhase
+const (
+	// ScenarioPending means the scenario has not been started yet
+	ScenarioPending ScenarioPhase = "PENDING"
+
+	// ScenarioRunning means the scenario has been started and its duration has not passed yet
+	ScenarioRunning ScenarioPhase = "RUNNING"
+
+	// ScenarioFinished means the duration of the scenario has passed
+	ScenarioFinished ScenarioPhase = "FINISHED"
+
+	// ScenarioFailed means the scenario could not be started
+	ScenarioFailed ScenarioPhase = "FAILED"
+)
+
+// ScenarioStatus is the progress of a Scenario, as seen by the control plane
+type ScenarioStatus struct {
+	// Phase is the phase the scenario is in
+	// +kubebuilder:validation:Optional
+	Phase ScenarioPhase `json:"phase,omitempty"`
+
+	// StartTime is the time at which the scenario started, or will start, on the apatelets
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"start_time,omitempty"`
+
+	// Message explains why the scenario is in its phase, such as what it is waiting for
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,112 @@
+// +build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scenario) DeepCopyInto(out *Scenario) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scenario.
+func (in *Scenario) DeepCopy() *Scenario {
+	if in == nil {
+		return nil
+	}
+	out := new(Scenario)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Scenario) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioList) DeepCopyInto(out *ScenarioList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Scenario, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioList.
+func (in *ScenarioList) DeepCopy() *ScenarioList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScenarioList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioSpec) DeepCopyInto(out *ScenarioSpec) {
+	*out = *in
+	if in.NodeConfigurations != nil {
+		in, out := &in.NodeConfigurations, &out.NodeConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodConfigurations != nil {
+		in, out := &in.PodConfigurations, &out.PodConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioSpec.
+func (in *ScenarioSpec) DeepCopy() *ScenarioSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStatus) DeepCopyInto(out *ScenarioStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStatus.
+func (in *ScenarioStatus) DeepCopy() *ScenarioStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -69,6 +69,7 @@ func (c *ClusterOperationClient) JoinCluster(ctx context.Context, listenPort int
 		EphemeralStorage: res.Hardware.EphemeralStorage,
 		MaxPods:          res.Hardware.MaxPods,
 		Label:            res.NodeLabel,
+		Index:            res.NodeIndex,
 	}, res.StartTime, nil
 }
 
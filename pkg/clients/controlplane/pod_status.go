@@ -0,0 +1,39 @@
+package controlplane
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+	"github.com/atlarge-research/apate/internal/service"
+)
+
+// PodStatusClient is the client for the PodStatusService containing the connection and gRPC client
+type PodStatusClient struct {
+	Conn   *grpc.ClientConn
+	Client controlplane.PodStatusClient
+}
+
+// GetPodStatusClient returns client for the PodStatusService
+func GetPodStatusClient(info *service.ConnectionInfo) (*PodStatusClient, error) {
+	conn, err := service.CreateClientConnection(info)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create client connection")
+	}
+
+	return &PodStatusClient{
+		Conn:   conn,
+		Client: controlplane.NewPodStatusClient(conn),
+	}, nil
+}
+
+// ReportPodStatus sends the status of the pod configurations on the apatelet with the given uuid to the control plane
+func (c *PodStatusClient) ReportPodStatus(ctx context.Context, uuid string, statuses []*controlplane.PodConfigurationStatus) error {
+	_, err := c.Client.ReportPodStatus(ctx, &controlplane.ApateletPodStatus{
+		NodeUuid:       uuid,
+		Configurations: statuses,
+	})
+	return errors.Wrap(err, "failed to report pod status")
+}
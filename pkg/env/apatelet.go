@@ -36,6 +36,9 @@ const (
 
 	// ApateletDebugEnabledDefault default for DebugEnabled
 	ApateletDebugEnabledDefault = false
+
+	// ApateletSeedDefault is the default for Seed, which means the current time is used as seed
+	ApateletSeedDefault = 0
 )
 
 // ApateletEnvironment represents the environment variables of the apatelet
@@ -67,6 +70,9 @@ type ApateletEnvironment struct {
 
 	// DebugEnabled determines if extra messages and profiling tools should be enabled
 	DebugEnabled bool `env:"APATELET_ENABLE_DEBUG"`
+
+	// Seed is the seed of the random number generator used for probabilistic behaviour, such as weighted responses
+	Seed int64 `env:"APATELET_SEED"`
 }
 
 // defaultApateletEnvironment returns the default apate environment
@@ -87,6 +93,8 @@ func defaultApateletEnvironment() ApateletEnvironment {
 		DisableTaints: ApateletDisableTaintsDefault,
 
 		DebugEnabled: ApateletDebugEnabledDefault,
+
+		Seed: ApateletSeedDefault,
 	}
 }
 
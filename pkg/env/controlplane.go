@@ -44,6 +44,8 @@ const (
 	CPNodeCRDLocationDefault = "config/crd/apate.opendc.org_nodeconfigurations.yaml"
 	// CPPodCRDLocationDefault CRD default location
 	CPPodCRDLocationDefault = "config/crd/apate.opendc.org_podconfigurations.yaml"
+	// CPScenarioCRDLocationDefault CRD default location
+	CPScenarioCRDLocationDefault = "config/crd/apate.opendc.org_scenarios.yaml"
 
 	// CPKinDClusterNameDefault default cluster name
 	CPKinDClusterNameDefault = "apate"
@@ -98,8 +100,9 @@ type ControlPlaneEnvironment struct {
 	PrometheusConfigLocation string `env:"CP_PROMETHEUS_CONFIG_LOCATION"`
 
 	// CRD Locations
-	PodCRDLocation  string `env:"CP_POD_CRD_LOCATION"`
-	NodeCRDLocation string `env:"CP_NODE_CRD_LOCATION"`
+	PodCRDLocation      string `env:"CP_POD_CRD_LOCATION"`
+	NodeCRDLocation     string `env:"CP_NODE_CRD_LOCATION"`
+	ScenarioCRDLocation string `env:"CP_SCENARIO_CRD_LOCATION"`
 
 	// (KinD) Cluster Name
 	KinDClusterName string `env:"CP_KIND_CLUSTER_NAME"`
@@ -133,8 +136,9 @@ func DefaultControlPlaneEnvironment() ControlPlaneEnvironment {
 		PrometheusNamespace:      CPPrometheusNamespace,
 		PrometheusConfigLocation: CPPrometheusConfigLocation,
 
-		NodeCRDLocation: CPNodeCRDLocationDefault,
-		PodCRDLocation:  CPPodCRDLocationDefault,
+		NodeCRDLocation:     CPNodeCRDLocationDefault,
+		PodCRDLocation:      CPPodCRDLocationDefault,
+		ScenarioCRDLocation: CPScenarioCRDLocationDefault,
 
 		KinDClusterName: CPKinDClusterNameDefault,
 
@@ -0,0 +1,110 @@
+package scenario
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMemoryPressure = 0.85
+	defaultDiskPressure   = 0.85
+	defaultOutOfDisk      = 0.96
+	defaultPIDPressure    = 0.85
+	defaultMaxPIDs        = 32768
+)
+
+// NodeConditions determines when a node reports resource pressure, and which conditions it is forced to report
+type NodeConditions struct {
+	// The fractions of the resources of the node above which it reports pressure
+	MemoryPressure float64
+	DiskPressure   float64
+	OutOfDisk      float64
+	PIDPressure    float64
+
+	// The maximum amount of processes which can run on the node
+	MaxPIDs int64
+
+	// The conditions which are reported regardless of the resources used on the node
+	Forced []ForcedCondition
+}
+
+// ForcedCondition is a condition reported by a node regardless of its resources
+type ForcedCondition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// NodeConditionsSpec describes node conditions as they are configured in a CRD
+type NodeConditionsSpec struct {
+	MemoryPressure string
+	DiskPressure   string
+	OutOfDisk      string
+	PIDPressure    string
+	MaxPIDs        int64
+	Forced         []ForcedCondition
+}
+
+// DefaultNodeConditions returns the thresholds of a node of which the conditions have not been configured
+func DefaultNodeConditions() *NodeConditions {
+	return &NodeConditions{
+		MemoryPressure: defaultMemoryPressure,
+		DiskPressure:   defaultDiskPressure,
+		OutOfDisk:      defaultOutOfDisk,
+		PIDPressure:    defaultPIDPressure,
+		MaxPIDs:        defaultMaxPIDs,
+	}
+}
+
+// ParseNodeConditions parses the given spec into node conditions, thresholds which are not set get their default
+func ParseNodeConditions(spec NodeConditionsSpec) (*NodeConditions, error) {
+	conditions := DefaultNodeConditions()
+
+	thresholds := []struct {
+		name   string
+		value  string
+		target *float64
+	}{
+		{"memory pressure", spec.MemoryPressure, &conditions.MemoryPressure},
+		{"disk pressure", spec.DiskPressure, &conditions.DiskPressure},
+		{"out of disk", spec.OutOfDisk, &conditions.OutOfDisk},
+		{"pid pressure", spec.PIDPressure, &conditions.PIDPressure},
+	}
+
+	for _, threshold := range thresholds {
+		if threshold.value == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(threshold.value, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %v threshold %v", threshold.name, threshold.value)
+		} else if value < 0 || value > 1 {
+			return nil, errors.Errorf("%v threshold %v should be between 0 and 1", threshold.name, value)
+		}
+		*threshold.target = value
+	}
+
+	if spec.MaxPIDs < 0 {
+		return nil, errors.Errorf("max pids %v should not be negative", spec.MaxPIDs)
+	} else if spec.MaxPIDs > 0 {
+		conditions.MaxPIDs = spec.MaxPIDs
+	}
+
+	for _, forced := range spec.Forced {
+		if forced.Type == "" {
+			return nil, errors.New("forced conditions should have a type")
+		}
+
+		switch forced.Status {
+		case "True", "False", "Unknown":
+		default:
+			return nil, errors.Errorf("invalid status %v of forced condition %v", forced.Status, forced.Type)
+		}
+	}
+	conditions.Forced = spec.Forced
+
+	return conditions, nil
+}
@@ -0,0 +1,45 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNodeConditions(t *testing.T) {
+	t.Parallel()
+
+	forced := []ForcedCondition{{Type: "KernelDeadlock", Status: "True", Reason: "DockerHung"}}
+	conditions, err := ParseNodeConditions(NodeConditionsSpec{DiskPressure: "0.5", MaxPIDs: 100, Forced: forced})
+	assert.NoError(t, err)
+	assert.Equal(t, &NodeConditions{
+		MemoryPressure: defaultMemoryPressure,
+		DiskPressure:   0.5,
+		OutOfDisk:      defaultOutOfDisk,
+		PIDPressure:    defaultPIDPressure,
+		MaxPIDs:        100,
+		Forced:         forced,
+	}, conditions)
+
+	// Unset thresholds get their default
+	conditions, err = ParseNodeConditions(NodeConditionsSpec{})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultNodeConditions(), conditions)
+}
+
+func TestParseNodeConditionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []NodeConditionsSpec{
+		{MemoryPressure: "high"},
+		{OutOfDisk: "1.5"},
+		{MaxPIDs: -1},
+		{Forced: []ForcedCondition{{Status: "True"}}},
+		{Forced: []ForcedCondition{{Type: "Ready", Status: "Maybe"}}},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseNodeConditions(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
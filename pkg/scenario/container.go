@@ -0,0 +1,40 @@
+package scenario
+
+import (
+	"github.com/finitum/node-cli/stats"
+)
+
+// ContainerStatus specifies the status of a single container
+type ContainerStatus int
+
+const (
+	// ContainerStatusUnset means the container follows the status of the pod
+	ContainerStatusUnset ContainerStatus = iota
+	// ContainerStatusRunning means the container is running, regardless of the status of the pod
+	ContainerStatusRunning
+	// ContainerStatusWaiting means the container is waiting to be started
+	ContainerStatusWaiting
+	// ContainerStatusTerminated means the container has terminated
+	ContainerStatusTerminated
+)
+
+// ContainerState is the emulated state of a single container, which overrides the state of the pod for it
+type ContainerState struct {
+	Status ContainerStatus
+
+	// Whether the container is ready while it is running
+	Ready bool
+
+	// The exit code of a terminated container
+	ExitCode int32
+
+	// Why the container is waiting or has terminated, empty means a default reason is used
+	Reason string
+
+	// The resources used by the container while it is running, nil means it uses no resources of its own
+	Resources *stats.PodStats
+}
+
+// ContainerStates are the states of the containers of a pod by their name
+// Containers without a state follow the state of the pod
+type ContainerStates map[string]*ContainerState
@@ -0,0 +1,88 @@
+package scenario
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CrashModel determines when the containers of a running pod crash
+// The zero value never crashes
+type CrashModel struct {
+	// The probability that a container crashes during every interval it is running
+	Probability float64
+	Interval    time.Duration
+
+	// The time after which a running container crashes, 0 means it doesn't crash after a fixed time
+	After time.Duration
+
+	// The exit code of a crashed container
+	ExitCode int32
+}
+
+// CrashModelSpec describes a crash model as it is configured in a CRD
+// All durations are in time.ParseDuration format, such as "10ms" or "42s"
+type CrashModelSpec struct {
+	Probability string
+	Interval    string
+	After       string
+	ExitCode    int32
+}
+
+// ParseCrashModel parses the given spec into a crash model
+func ParseCrashModel(spec CrashModelSpec) (*CrashModel, error) {
+	model := &CrashModel{ExitCode: spec.ExitCode}
+
+	if spec.Probability == "" && spec.After == "" {
+		return nil, errors.New("a crash model needs a probability or an after duration")
+	}
+
+	if spec.ExitCode == 0 {
+		return nil, errors.New("crashed containers should have a non-zero exit code")
+	}
+
+	if spec.Probability != "" {
+		probability, err := strconv.ParseFloat(spec.Probability, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid probability %v", spec.Probability)
+		} else if probability < 0 || probability > 1 {
+			return nil, errors.Errorf("probability %v should be between 0 and 1", probability)
+		}
+		model.Probability = probability
+
+		if model.Interval, err = parsePositiveDuration("interval", spec.Interval); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.After != "" {
+		var err error
+		if model.After, err = parsePositiveDuration("after", spec.After); err != nil {
+			return nil, err
+		}
+	}
+
+	return model, nil
+}
+
+// SampleCrash returns how long after it started a container crashes, or false if it never crashes
+func (c *CrashModel) SampleCrash(random RandomSource) (time.Duration, bool) {
+	crashAfter, crashes := c.After, c.After > 0
+
+	if c.Probability > 0 && c.Interval > 0 {
+		// The amount of intervals until the first crash follows a geometric distribution
+		intervals := 1.0
+		if c.Probability < 1 {
+			intervals = math.Floor(math.Log(1-random.Float64())/math.Log(1-c.Probability)) + 1
+		}
+
+		// Prevent overflows for very unlikely crashes
+		if sampled := time.Duration(math.Min(intervals*float64(c.Interval), math.MaxInt64/2)); !crashes || sampled < crashAfter {
+			crashAfter, crashes = sampled, true
+		}
+	}
+
+	return crashAfter, crashes
+}
@@ -0,0 +1,55 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCrashModel(t *testing.T) {
+	t.Parallel()
+
+	model, err := ParseCrashModel(CrashModelSpec{Probability: "0.5", Interval: "1m", After: "10m", ExitCode: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &CrashModel{Probability: 0.5, Interval: time.Minute, After: 10 * time.Minute, ExitCode: 2}, model)
+}
+
+func TestParseCrashModelInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []CrashModelSpec{
+		{ExitCode: 1},
+		{After: "1m"},
+		{After: "-1m", ExitCode: 1},
+		{Probability: "2", Interval: "1m", ExitCode: 1},
+		{Probability: "0.5", ExitCode: 1},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseCrashModel(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
+
+func TestSampleCrash(t *testing.T) {
+	t.Parallel()
+
+	_, crashes := (&CrashModel{}).SampleCrash(fixedRandom{uniform: 0.5})
+	assert.False(t, crashes)
+
+	after, crashes := (&CrashModel{After: time.Minute}).SampleCrash(fixedRandom{uniform: 0.5})
+	assert.True(t, crashes)
+	assert.Equal(t, time.Minute, after)
+
+	// A probability of a half crashes in the first interval half of the time
+	model := &CrashModel{Probability: 0.5, Interval: 10 * time.Second, After: time.Minute}
+	after, _ = model.SampleCrash(fixedRandom{uniform: 0.4})
+	assert.Equal(t, 10*time.Second, after)
+	after, _ = model.SampleCrash(fixedRandom{uniform: 0.6})
+	assert.Equal(t, 20*time.Second, after)
+
+	// The fixed time is used if it comes first
+	after, _ = model.SampleCrash(fixedRandom{uniform: 0.9999999})
+	assert.Equal(t, time.Minute, after)
+}
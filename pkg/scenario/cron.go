@@ -0,0 +1,150 @@
+package scenario
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cronSearchLimit is how far ahead Next looks for a matching time before giving up
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// CronSchedule is a parsed cron expression with the five standard fields:
+// minute, hour, day of month, month and day of week
+type CronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// Whether the day of month or day of week field is restricted, if both are a time matches if either matches
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCronSchedule parses a cron expression such as "*/10 * * * *"
+// Every field supports *, single values, ranges (1-5), lists (1,3,5) and steps (*/10 or 0-30/5)
+// For the day of week, both 0 and 7 are Sunday
+func ParseCronSchedule(input string) (*CronSchedule, error) {
+	parts := strings.Fields(input)
+	if len(parts) != len(cronFields) {
+		return nil, errors.Errorf("cron expression %v should have %v fields", input, len(cronFields))
+	}
+
+	var masks [5]uint64
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cron expression %v", input)
+		}
+		masks[i] = mask
+	}
+
+	// Sunday can be written as both 0 and 7
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	return &CronSchedule{
+		minutes:            masks[0],
+		hours:              masks[1],
+		days:               masks[2],
+		months:             masks[3],
+		weekdays:           masks[4],
+		daysRestricted:     parts[2] != "*",
+		weekdaysRestricted: parts[4] != "*",
+	}, nil
+}
+
+func parseCronField(input string, field cronField) (uint64, error) {
+	var mask uint64
+
+	for _, part := range strings.Split(input, ",") {
+		step := 1
+		if split := strings.SplitN(part, "/", 2); len(split) == 2 {
+			var err error
+			if step, err = strconv.Atoi(split[1]); err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step %v in %v field", split[1], field.name)
+			}
+			part = split[0]
+		}
+
+		low, high := field.min, field.max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.Errorf("invalid value %v in %v field", bounds[0], field.name)
+			}
+
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.Errorf("invalid value %v in %v field", bounds[1], field.name)
+				}
+			}
+		}
+
+		if low < field.min || high > field.max || low > high {
+			return 0, errors.Errorf("range %v-%v out of bounds in %v field", low, high, field.name)
+		}
+
+		for i := low; i <= high; i += step {
+			mask |= 1 << uint(i)
+		}
+	}
+
+	return mask, nil
+}
+
+// Next returns the first time strictly after the given time which matches the schedule
+// The second return value is false if no such time exists within the next five years
+func (c *CronSchedule) Next(after time.Time) (time.Time, bool) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		switch {
+		case c.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	day := c.days&(1<<uint(t.Day())) != 0
+	weekday := c.weekdays&(1<<uint(t.Weekday())) != 0
+
+	if c.daysRestricted && c.weekdaysRestricted {
+		return day || weekday
+	}
+
+	return day && weekday
+}
@@ -0,0 +1,83 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, time.June, 1, 12, 3, 30, 0, time.UTC)
+
+	schedule, err := ParseCronSchedule("*/10 * * * *")
+	assert.NoError(t, err)
+
+	next, ok := schedule.Next(start)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 1, 12, 10, 0, 0, time.UTC), next)
+
+	// The next time is strictly after the given time
+	next, ok = schedule.Next(next)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 1, 12, 20, 0, 0, time.UTC), next)
+}
+
+func TestCronScheduleRangesAndLists(t *testing.T) {
+	t.Parallel()
+
+	// 1 June 2020 is a Monday
+	start := time.Date(2020, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	schedule, err := ParseCronSchedule("0,30 9-17/4 * * 6,7")
+	assert.NoError(t, err)
+
+	next, ok := schedule.Next(start)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 6, 9, 0, 0, 0, time.UTC), next)
+
+	next, ok = schedule.Next(next)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 6, 9, 30, 0, 0, time.UTC), next)
+
+	next, ok = schedule.Next(time.Date(2020, time.June, 6, 17, 30, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 7, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestCronScheduleDayOfMonthOrWeek(t *testing.T) {
+	t.Parallel()
+
+	// Either the 15th or a Monday
+	schedule, err := ParseCronSchedule("0 0 15 * 1")
+	assert.NoError(t, err)
+
+	next, ok := schedule.Next(time.Date(2020, time.June, 9, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC), next)
+
+	next, ok = schedule.Next(time.Date(2020, time.June, 2, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, time.June, 8, 0, 0, 0, 0, time.UTC), next)
+}
+
+func TestCronScheduleNever(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseCronSchedule("0 0 31 2 *")
+	assert.NoError(t, err)
+
+	_, ok := schedule.Next(time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := ParseCronSchedule(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -34,9 +34,60 @@ const (
 	// NodePingResponse determines how to respond to the Ping request
 	NodePingResponse
 
-	// NodeAddedLatency is the amount of nanoseconds of latency
-	// Will default to 0 nano seconds
+	// NodeAddedLatency is the distribution from which the latency added to every request is sampled
+	// Will default to a constant latency of 0 nano seconds
 	NodeAddedLatency
+
+	// NodeCreatePodLatency is the distribution from which the latency added to the CreatePod request is sampled
+	// Added to NodeAddedLatency, can also be influenced on pod level
+	NodeCreatePodLatency
+
+	// NodeUpdatePodLatency is the distribution from which the latency added to the UpdatePod request is sampled
+	// Added to NodeAddedLatency, can also be influenced on pod level
+	NodeUpdatePodLatency
+
+	// NodeDeletePodLatency is the distribution from which the latency added to the DeletePod request is sampled
+	// Added to NodeAddedLatency, can also be influenced on pod level
+	NodeDeletePodLatency
+
+	// NodeGetPodLatency is the distribution from which the latency added to the GetPod request is sampled
+	// Added to NodeAddedLatency, can also be influenced on pod level
+	NodeGetPodLatency
+
+	// NodeGetPodStatusLatency is the distribution from which the latency added to the GetPodStatus request is sampled
+	// Added to NodeAddedLatency, can also be influenced on pod level
+	NodeGetPodStatusLatency
+
+	// NodeGetPodsLatency is the distribution from which the latency added to the GetPods request is sampled
+	// Added to NodeAddedLatency
+	NodeGetPodsLatency
+
+	// NodePingLatency is the distribution from which the latency added to the Ping request is sampled
+	// Added to NodeAddedLatency
+	NodePingLatency
+
+	// NodePodStartup are the default durations of the startup phases of the pods on the node. See scenario.StartupPhases
+	// Can be overridden on pod level
+	NodePodStartup
+
+	// NodeImages determines how the node pulls the images of its pods. See scenario.NodeImages
+	NodeImages
+
+	// NodeConditions determines when the node reports resource pressure, and which conditions it is forced to report.
+	// See scenario.NodeConditions
+	NodeConditions
+
+	// NodeCapacity changes the resources of the node while it runs. See scenario.NodeCapacity
+	NodeCapacity
+
+	// NodeLabels are the labels added to the node. Of type map[string]string
+	NodeLabels
+
+	// NodeAnnotations are the annotations added to the node. Of type map[string]string
+	NodeAnnotations
+
+	// NodeTaints are the taints added to the node. Of type []corev1.Taint
+	NodeTaints
 )
 
 // PodEventFlag is a pod specific flag to be used by the Apatelet
@@ -70,4 +121,52 @@ const (
 	// Can be left empty to keep the status unchanged
 	// If left empty, the pod_status_percentage will be ignored
 	PodStatus
+
+	// PodCreatePodLatency is the distribution from which the latency added to the CreatePod request is sampled
+	// Added to the latency on node level
+	PodCreatePodLatency
+
+	// PodUpdatePodLatency is the distribution from which the latency added to the UpdatePod request is sampled
+	// Added to the latency on node level
+	PodUpdatePodLatency
+
+	// PodDeletePodLatency is the distribution from which the latency added to the DeletePod request is sampled
+	// Added to the latency on node level
+	PodDeletePodLatency
+
+	// PodGetPodLatency is the distribution from which the latency added to the GetPod request is sampled
+	// Added to the latency on node level
+	PodGetPodLatency
+
+	// PodGetPodStatusLatency is the distribution from which the latency added to the GetPodStatus request is sampled
+	// Added to the latency on node level
+	PodGetPodStatusLatency
+
+	// PodCrash determines when the containers of a running pod crash. See scenario.CrashModel
+	PodCrash
+
+	// PodContainers are the states of individual containers of the pod. See scenario.ContainerStates
+	PodContainers
+
+	// PodProbes are the results of the readiness and liveness probes of the containers of the pod. See scenario.Probes
+	PodProbes
+
+	// PodStartup are the durations of the startup phases of the pod. See scenario.StartupPhases
+	// Phases which are not set use the default of the node
+	PodStartup
+
+	// PodImagePull determines how the images of the pod are pulled. See scenario.PodImagePull
+	PodImagePull
+
+	// PodRuntime determines how long the pod runs before it succeeds or fails. See scenario.RuntimeModel
+	PodRuntime
+
+	// PodLogs determines the synthetic log lines written by the containers of the pod. See scenario.LogModel
+	PodLogs
+
+	// PodExec scripts the responses to commands executed in the containers of the pod. See scenario.ExecRules
+	PodExec
+
+	// PodProcesses is the amount of processes running in the pod, which counts towards the PID pressure of the node
+	PodProcesses
 )
@@ -0,0 +1,74 @@
+package scenario
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExecRule scripts the response to commands executed in the containers of a pod
+type ExecRule struct {
+	// The commands to which the rule applies, matched against the command and its arguments separated by spaces
+	Command *regexp.Regexp
+
+	// The container to which the rule applies, empty if it applies to all containers
+	Container string
+
+	// What the command writes to its standard output and error streams
+	Stdout string
+	Stderr string
+
+	// The exit code of the command
+	ExitCode int32
+
+	// The time it takes for the command to finish, nil if it finishes immediately
+	Delay LatencyDistribution
+}
+
+// ExecRules are the rules scripting the responses to commands, of which the first matching one applies
+type ExecRules []ExecRule
+
+// ExecRuleSpec describes an exec rule as it is configured in a CRD
+type ExecRuleSpec struct {
+	Command   string
+	Container string
+	Stdout    string
+	Stderr    string
+	ExitCode  int32
+	Delay     LatencyDistribution
+}
+
+// ParseExecRule parses the given spec into an exec rule
+func ParseExecRule(spec ExecRuleSpec) (ExecRule, error) {
+	if spec.ExitCode < 0 || spec.ExitCode > 255 {
+		return ExecRule{}, errors.Errorf("exit code %v should be between 0 and 255", spec.ExitCode)
+	}
+
+	// The whole command should match, like a shell pattern would
+	command, err := regexp.Compile("^(?:" + spec.Command + ")$")
+	if err != nil {
+		return ExecRule{}, errors.Wrapf(err, "invalid command pattern %v", spec.Command)
+	}
+
+	return ExecRule{
+		Command:   command,
+		Container: spec.Container,
+		Stdout:    spec.Stdout,
+		Stderr:    spec.Stderr,
+		ExitCode:  spec.ExitCode,
+		Delay:     spec.Delay,
+	}, nil
+}
+
+// Match returns the first rule which applies to the given command executed in the given container, or false if there is none
+func (e ExecRules) Match(container string, command []string) (ExecRule, bool) {
+	joined := strings.Join(command, " ")
+	for _, rule := range e {
+		if (rule.Container == "" || rule.Container == container) && rule.Command.MatchString(joined) {
+			return rule, true
+		}
+	}
+
+	return ExecRule{}, false
+}
@@ -0,0 +1,51 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExecRule(t *testing.T) {
+	t.Parallel()
+
+	rule, err := ParseExecRule(ExecRuleSpec{Command: "cat /etc/.*", Stdout: "ok", ExitCode: 2, Delay: ConstantLatency(time.Second)})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", rule.Stdout)
+	assert.Equal(t, int32(2), rule.ExitCode)
+	assert.Equal(t, ConstantLatency(time.Second), rule.Delay)
+
+	_, err = ParseExecRule(ExecRuleSpec{Command: "(cat"})
+	assert.Error(t, err)
+
+	_, err = ParseExecRule(ExecRuleSpec{Command: "cat", ExitCode: 256})
+	assert.Error(t, err)
+}
+
+func TestExecRulesMatch(t *testing.T) {
+	t.Parallel()
+
+	var rules ExecRules
+	for _, spec := range []ExecRuleSpec{
+		{Command: "ls", Container: "sidecar", Stdout: "sidecar"},
+		{Command: "ls( .*)?", Stdout: "any"},
+	} {
+		rule, err := ParseExecRule(spec)
+		assert.NoError(t, err)
+		rules = append(rules, rule)
+	}
+
+	// The first matching rule applies
+	rule, ok := rules.Match("sidecar", []string{"ls"})
+	assert.True(t, ok)
+	assert.Equal(t, "sidecar", rule.Stdout)
+
+	rule, ok = rules.Match("app", []string{"ls", "-l"})
+	assert.True(t, ok)
+	assert.Equal(t, "any", rule.Stdout)
+
+	// The whole command should match
+	_, ok = rules.Match("app", []string{"sh", "-c", "ls"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,48 @@
+package scenario
+
+import (
+	"math"
+	"time"
+)
+
+// NodeImages determines how a node pulls the images of the pods running on it
+type NodeImages struct {
+	// The bandwidth with which images are pulled in bytes per second, 0 means pulls only take their latency
+	Bandwidth uint64
+
+	// The size in bytes of images of pods which don't specify the size of their images
+	DefaultSize uint64
+
+	// The images which are present on the node without being pulled
+	Cached []string
+}
+
+// PodImagePull determines how the images of a pod are pulled
+type PodImagePull struct {
+	// The size in bytes of every image of the pod, 0 means the default size of the node is used
+	Size uint64
+
+	// Whether pulling the images fails, for example because the registry is unavailable
+	Fail bool
+}
+
+// IsCached returns whether the given image is present on the node without being pulled
+func (n *NodeImages) IsCached(image string) bool {
+	for _, cached := range n.Cached {
+		if cached == image {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransferTime returns how long it takes to transfer an image of the given size with the bandwidth of the node
+func (n *NodeImages) TransferTime(size uint64) time.Duration {
+	if n.Bandwidth == 0 {
+		return 0
+	}
+
+	// Prevent overflows for very large images or very low bandwidths
+	return time.Duration(math.Min(float64(size)/float64(n.Bandwidth)*float64(time.Second), math.MaxInt64/2))
+}
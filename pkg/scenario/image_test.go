@@ -0,0 +1,27 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeImagesTransferTime(t *testing.T) {
+	t.Parallel()
+
+	images := &NodeImages{Bandwidth: 10 * 1024 * 1024}
+	assert.Equal(t, 5*time.Second, images.TransferTime(50*1024*1024))
+	assert.Equal(t, time.Duration(0), images.TransferTime(0))
+
+	// Without a bandwidth images are transferred instantly
+	assert.Equal(t, time.Duration(0), (&NodeImages{}).TransferTime(50*1024*1024))
+}
+
+func TestNodeImagesIsCached(t *testing.T) {
+	t.Parallel()
+
+	images := &NodeImages{Cached: []string{"nginx:1.19", "redis"}}
+	assert.True(t, images.IsCached("redis"))
+	assert.False(t, images.IsCached("nginx"))
+}
@@ -0,0 +1,343 @@
+package scenario
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RandomSource provides the random numbers used to sample a latency distribution
+type RandomSource interface {
+	// Float64 returns a uniformly distributed number in [0, 1)
+	Float64() float64
+
+	// NormFloat64 returns a normally distributed number with mean 0 and standard deviation 1
+	NormFloat64() float64
+
+	// ExpFloat64 returns an exponentially distributed number with mean 1
+	ExpFloat64() float64
+}
+
+// LatencyDistribution is a distribution from which the latency added to a single request is sampled
+type LatencyDistribution interface {
+	// Sample returns a latency drawn from the distribution, which is never negative
+	Sample(RandomSource) time.Duration
+}
+
+// ConstantLatency always adds the same latency
+type ConstantLatency time.Duration
+
+// Sample returns the constant latency
+func (c ConstantLatency) Sample(RandomSource) time.Duration {
+	return time.Duration(c)
+}
+
+// UniformLatency adds a latency uniformly distributed between Min and Max
+type UniformLatency struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// NewUniformLatency creates a new UniformLatency, which errors if the bounds are negative or in the wrong order
+func NewUniformLatency(min, max time.Duration) (UniformLatency, error) {
+	if min < 0 || max < min {
+		return UniformLatency{}, errors.Errorf("invalid uniform latency bounds %v and %v", min, max)
+	}
+
+	return UniformLatency{Min: min, Max: max}, nil
+}
+
+// Sample returns a latency between Min and Max
+func (u UniformLatency) Sample(random RandomSource) time.Duration {
+	return u.Min + time.Duration(random.Float64()*float64(u.Max-u.Min))
+}
+
+// NormalLatency adds a normally distributed latency, negative samples are cut off at 0
+type NormalLatency struct {
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+// NewNormalLatency creates a new NormalLatency, which errors if the mean or standard deviation is negative
+func NewNormalLatency(mean, stdDev time.Duration) (NormalLatency, error) {
+	if mean < 0 || stdDev < 0 {
+		return NormalLatency{}, errors.Errorf("invalid normal latency with mean %v and standard deviation %v", mean, stdDev)
+	}
+
+	return NormalLatency{Mean: mean, StdDev: stdDev}, nil
+}
+
+// Sample returns a normally distributed latency
+func (n NormalLatency) Sample(random RandomSource) time.Duration {
+	return nonNegative(float64(n.Mean) + random.NormFloat64()*float64(n.StdDev))
+}
+
+// ExponentialLatency adds an exponentially distributed latency with the given mean
+type ExponentialLatency struct {
+	Mean time.Duration
+}
+
+// NewExponentialLatency creates a new ExponentialLatency, which errors if the mean is negative
+func NewExponentialLatency(mean time.Duration) (ExponentialLatency, error) {
+	if mean < 0 {
+		return ExponentialLatency{}, errors.Errorf("invalid exponential latency with mean %v", mean)
+	}
+
+	return ExponentialLatency{Mean: mean}, nil
+}
+
+// Sample returns an exponentially distributed latency
+func (e ExponentialLatency) Sample(random RandomSource) time.Duration {
+	return nonNegative(random.ExpFloat64() * float64(e.Mean))
+}
+
+// LogNormalLatency adds a log-normally distributed latency
+// Mu and Sigma are the parameters of the underlying normal distribution of the logarithm of the latency in nanoseconds
+type LogNormalLatency struct {
+	Mu    float64
+	Sigma float64
+}
+
+// NewLogNormalLatency creates a new LogNormalLatency with the given mean and standard deviation of the latency itself
+func NewLogNormalLatency(mean, stdDev time.Duration) (LogNormalLatency, error) {
+	if mean <= 0 || stdDev < 0 {
+		return LogNormalLatency{}, errors.Errorf("invalid log-normal latency with mean %v and standard deviation %v", mean, stdDev)
+	}
+
+	m := float64(mean)
+	s := float64(stdDev)
+	variance := math.Log(1 + (s*s)/(m*m))
+
+	return LogNormalLatency{
+		Mu:    math.Log(m) - variance/2,
+		Sigma: math.Sqrt(variance),
+	}, nil
+}
+
+// Sample returns a log-normally distributed latency
+func (l LogNormalLatency) Sample(random RandomSource) time.Duration {
+	return nonNegative(math.Exp(l.Mu + random.NormFloat64()*l.Sigma))
+}
+
+// LatencyPercentile is a single point of an empirical latency distribution
+type LatencyPercentile struct {
+	// The percentile, between 0 and 100
+	Percentile float64
+
+	// The latency at this percentile
+	Latency time.Duration
+}
+
+// EmpiricalLatency adds a latency following a table of percentiles, interpolating linearly between them
+// Samples below the first percentile get its latency, samples above the last percentile get the latency of the last one
+type EmpiricalLatency []LatencyPercentile
+
+// NewEmpiricalLatency creates a new EmpiricalLatency from the given percentiles, which do not have to be sorted
+// It errors if a percentile is out of bounds or occurs twice, or if the latency decreases with a higher percentile
+func NewEmpiricalLatency(percentiles []LatencyPercentile) (EmpiricalLatency, error) {
+	if len(percentiles) == 0 {
+		return nil, errors.New("empirical latency needs at least one percentile")
+	}
+
+	sorted := make(EmpiricalLatency, len(percentiles))
+	copy(sorted, percentiles)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Percentile < sorted[j].Percentile
+	})
+
+	for i, p := range sorted {
+		if p.Percentile < 0 || p.Percentile > 100 {
+			return nil, errors.Errorf("percentile %v should be between 0 and 100", p.Percentile)
+		}
+
+		if p.Latency < 0 {
+			return nil, errors.Errorf("latency %v of percentile %v should not be negative", p.Latency, p.Percentile)
+		}
+
+		if i > 0 && p.Percentile == sorted[i-1].Percentile {
+			return nil, errors.Errorf("percentile %v occurs more than once", p.Percentile)
+		}
+
+		if i > 0 && p.Latency < sorted[i-1].Latency {
+			return nil, errors.Errorf("latency %v of percentile %v is lower than the latency of a lower percentile", p.Latency, p.Percentile)
+		}
+	}
+
+	return sorted, nil
+}
+
+// Sample returns a latency following the percentiles
+func (e EmpiricalLatency) Sample(random RandomSource) time.Duration {
+	percentile := random.Float64() * 100
+
+	index := sort.Search(len(e), func(i int) bool {
+		return e[i].Percentile >= percentile
+	})
+
+	if index == 0 {
+		return e[0].Latency
+	} else if index == len(e) {
+		return e[len(e)-1].Latency
+	}
+
+	lower, upper := e[index-1], e[index]
+	fraction := (percentile - lower.Percentile) / (upper.Percentile - lower.Percentile)
+	return lower.Latency + time.Duration(fraction*float64(upper.Latency-lower.Latency))
+}
+
+// MaxLatency returns the highest latency which can be sampled from the distribution, or false if it is unbounded
+func MaxLatency(distribution LatencyDistribution) (time.Duration, bool) {
+	switch d := distribution.(type) {
+	case ConstantLatency:
+		return time.Duration(d), true
+	case UniformLatency:
+		return d.Max, true
+	case EmpiricalLatency:
+		return d[len(d)-1].Latency, true
+	default:
+		return 0, false
+	}
+}
+
+// DistributionKind specifies the kind of a latency distribution
+type DistributionKind int
+
+const (
+	// DistributionUnset is not a valid distribution
+	DistributionUnset DistributionKind = iota
+	// DistributionConstant always has the same latency
+	DistributionConstant
+	// DistributionUniform is uniformly distributed between a minimum and a maximum
+	DistributionUniform
+	// DistributionNormal is normally distributed
+	DistributionNormal
+	// DistributionExponential is exponentially distributed
+	DistributionExponential
+	// DistributionLogNormal is log-normally distributed
+	DistributionLogNormal
+	// DistributionEmpirical is interpolated between percentiles
+	DistributionEmpirical
+)
+
+// LatencySpec describes a latency distribution as it is configured in a CRD
+// All latencies are in time.ParseDuration format, such as "10ms" or "42s"
+type LatencySpec struct {
+	// The kind of distribution, which determines which of the other fields are used
+	Distribution DistributionKind
+
+	// The latency of a constant distribution
+	Value string
+
+	// The bounds of a uniform distribution
+	Min string
+	Max string
+
+	// The mean of a normal, exponential or log-normal distribution
+	Mean string
+
+	// The standard deviation of a normal or log-normal distribution
+	StdDev string
+
+	// The percentiles of an empirical distribution
+	Percentiles []LatencyPercentileSpec
+}
+
+// LatencyPercentileSpec is a single percentile of an empirical distribution as it is configured in a CRD
+type LatencyPercentileSpec struct {
+	// The percentile, between 0 and 100, such as "99.9"
+	Percentile string
+
+	// The latency at this percentile
+	Latency string
+}
+
+// ParseLatencyDistribution parses the given spec into a distribution which can be sampled
+func ParseLatencyDistribution(spec LatencySpec) (LatencyDistribution, error) {
+	switch spec.Distribution {
+	case DistributionConstant:
+		value, err := parseLatency("value", spec.Value)
+		if err != nil {
+			return nil, err
+		} else if value < 0 {
+			return nil, errors.Errorf("constant latency %v should not be negative", value)
+		}
+
+		return ConstantLatency(value), nil
+	case DistributionUniform:
+		min, err := parseLatency("min", spec.Min)
+		if err != nil {
+			return nil, err
+		}
+
+		max, err := parseLatency("max", spec.Max)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewUniformLatency(min, max)
+	case DistributionNormal, DistributionLogNormal:
+		mean, err := parseLatency("mean", spec.Mean)
+		if err != nil {
+			return nil, err
+		}
+
+		stdDev, err := parseLatency("std_dev", spec.StdDev)
+		if err != nil {
+			return nil, err
+		}
+
+		if spec.Distribution == DistributionNormal {
+			return NewNormalLatency(mean, stdDev)
+		}
+		return NewLogNormalLatency(mean, stdDev)
+	case DistributionExponential:
+		mean, err := parseLatency("mean", spec.Mean)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewExponentialLatency(mean)
+	case DistributionEmpirical:
+		percentiles := make([]LatencyPercentile, 0, len(spec.Percentiles))
+		for _, p := range spec.Percentiles {
+			percentile, err := strconv.ParseFloat(p.Percentile, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid percentile %v", p.Percentile)
+			}
+
+			value, err := parseLatency("latency", p.Latency)
+			if err != nil {
+				return nil, err
+			}
+
+			percentiles = append(percentiles, LatencyPercentile{
+				Percentile: percentile,
+				Latency:    value,
+			})
+		}
+
+		return NewEmpiricalLatency(percentiles)
+	default:
+		return nil, errors.Errorf("unknown latency distribution %v", spec.Distribution)
+	}
+}
+
+func parseLatency(field, input string) (time.Duration, error) {
+	latency, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid latency %v for %v", input, field)
+	}
+
+	return latency, nil
+}
+
+func nonNegative(nanoseconds float64) time.Duration {
+	if nanoseconds < 0 {
+		return 0
+	}
+
+	return time.Duration(nanoseconds)
+}
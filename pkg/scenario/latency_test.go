@@ -0,0 +1,143 @@
+package scenario
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedRandom struct {
+	uniform     float64
+	normal      float64
+	exponential float64
+}
+
+func (f fixedRandom) Float64() float64     { return f.uniform }
+func (f fixedRandom) NormFloat64() float64 { return f.normal }
+func (f fixedRandom) ExpFloat64() float64  { return f.exponential }
+
+func TestConstantLatency(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 10*time.Millisecond, ConstantLatency(10*time.Millisecond).Sample(fixedRandom{uniform: 0.5}))
+}
+
+func TestUniformLatency(t *testing.T) {
+	t.Parallel()
+
+	latency, err := NewUniformLatency(10*time.Millisecond, 20*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, latency.Sample(fixedRandom{uniform: 0}))
+	assert.Equal(t, 15*time.Millisecond, latency.Sample(fixedRandom{uniform: 0.5}))
+
+	_, err = NewUniformLatency(20*time.Millisecond, 10*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestNormalLatency(t *testing.T) {
+	t.Parallel()
+
+	latency, err := NewNormalLatency(10*time.Millisecond, 2*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 14*time.Millisecond, latency.Sample(fixedRandom{normal: 2}))
+
+	// Negative latencies are cut off
+	assert.Equal(t, time.Duration(0), latency.Sample(fixedRandom{normal: -6}))
+
+	_, err = NewNormalLatency(10*time.Millisecond, -2*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestExponentialLatency(t *testing.T) {
+	t.Parallel()
+
+	latency, err := NewExponentialLatency(10 * time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 25*time.Millisecond, latency.Sample(fixedRandom{exponential: 2.5}))
+
+	_, err = NewExponentialLatency(-10 * time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestLogNormalLatency(t *testing.T) {
+	t.Parallel()
+
+	// Without deviation, every sample is the mean
+	latency, err := NewLogNormalLatency(10*time.Millisecond, 0)
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(10*time.Millisecond), float64(latency.Sample(fixedRandom{normal: 1})), 1)
+
+	latency, err = NewLogNormalLatency(10*time.Millisecond, 5*time.Millisecond)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Log(1.25), latency.Sigma*latency.Sigma, 1e-9)
+	assert.Less(t, int64(latency.Sample(fixedRandom{normal: 0})), int64(10*time.Millisecond))
+
+	_, err = NewLogNormalLatency(0, 5*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestEmpiricalLatency(t *testing.T) {
+	t.Parallel()
+
+	latency, err := NewEmpiricalLatency([]LatencyPercentile{
+		{Percentile: 99, Latency: 100 * time.Millisecond},
+		{Percentile: 50, Latency: 10 * time.Millisecond},
+		{Percentile: 90, Latency: 50 * time.Millisecond},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 10*time.Millisecond, latency.Sample(fixedRandom{uniform: 0.2}))
+	assert.Equal(t, 10*time.Millisecond, latency.Sample(fixedRandom{uniform: 0.5}))
+	assert.Equal(t, 30*time.Millisecond, latency.Sample(fixedRandom{uniform: 0.7}))
+	assert.Equal(t, 100*time.Millisecond, latency.Sample(fixedRandom{uniform: 0.995}))
+}
+
+func TestEmpiricalLatencyInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEmpiricalLatency(nil)
+	assert.Error(t, err)
+
+	_, err = NewEmpiricalLatency([]LatencyPercentile{{Percentile: 101, Latency: time.Millisecond}})
+	assert.Error(t, err)
+
+	_, err = NewEmpiricalLatency([]LatencyPercentile{
+		{Percentile: 50, Latency: time.Millisecond},
+		{Percentile: 50, Latency: 2 * time.Millisecond},
+	})
+	assert.Error(t, err)
+
+	_, err = NewEmpiricalLatency([]LatencyPercentile{
+		{Percentile: 50, Latency: 2 * time.Millisecond},
+		{Percentile: 90, Latency: time.Millisecond},
+	})
+	assert.Error(t, err)
+}
+
+func TestMaxLatency(t *testing.T) {
+	t.Parallel()
+
+	uniform, _ := NewUniformLatency(time.Millisecond, 20*time.Millisecond)
+	empirical, _ := NewEmpiricalLatency([]LatencyPercentile{
+		{Percentile: 50, Latency: 10 * time.Millisecond},
+		{Percentile: 99, Latency: 100 * time.Millisecond},
+	})
+	normal, _ := NewNormalLatency(10*time.Millisecond, time.Millisecond)
+
+	max, bounded := MaxLatency(ConstantLatency(10 * time.Millisecond))
+	assert.True(t, bounded)
+	assert.Equal(t, 10*time.Millisecond, max)
+
+	max, bounded = MaxLatency(uniform)
+	assert.True(t, bounded)
+	assert.Equal(t, 20*time.Millisecond, max)
+
+	max, bounded = MaxLatency(empirical)
+	assert.True(t, bounded)
+	assert.Equal(t, 100*time.Millisecond, max)
+
+	_, bounded = MaxLatency(normal)
+	assert.False(t, bounded)
+}
@@ -0,0 +1,160 @@
+package scenario
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultFailureTemplate is the template of the lines written when a container fails, if none is given
+const defaultFailureTemplate = "Emulated container {{.Container}} has failed with exit code {{.ExitCode}}"
+
+// LogModel determines the synthetic log lines written by the containers of a pod
+// The zero value writes no synthetic lines
+type LogModel struct {
+	// The template from which every line is generated, nil if lines are replayed
+	Template *template.Template
+
+	// The lines which are replayed, starting over once all have been written
+	Lines []string
+
+	// The amount of lines written per second
+	Rate float64
+
+	// The amount of lines written at once when a container fails, and the template from which they are generated
+	FailureBurst    int
+	FailureTemplate *template.Template
+}
+
+// LogModelSpec describes a log model as it is configured in a CRD
+type LogModelSpec struct {
+	Template        string
+	Lines           []string
+	Rate            string
+	FailureBurst    int32
+	FailureTemplate string
+}
+
+// LogLine is the data available to the templates of log lines
+type LogLine struct {
+	Pod       string
+	Namespace string
+	Container string
+
+	// The index of the line since the container started, and when it is written
+	Line int
+	Time time.Time
+
+	// The exit code of the container, only set for the lines written when it fails
+	ExitCode int32
+}
+
+// ParseLogModel parses the given spec into a log model
+func ParseLogModel(spec LogModelSpec) (*LogModel, error) {
+	model := &LogModel{
+		Lines:        spec.Lines,
+		Rate:         1,
+		FailureBurst: int(spec.FailureBurst),
+	}
+
+	switch {
+	case spec.Template != "" && len(spec.Lines) > 0:
+		return nil, errors.New("a log model can't have both a template and lines to replay")
+	case spec.Template != "":
+		parsed, err := template.New("line").Parse(spec.Template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid template %v", spec.Template)
+		}
+		model.Template = parsed
+	case len(spec.Lines) == 0:
+		return nil, errors.New("a log model needs a template or lines to replay")
+	}
+
+	if spec.Rate != "" {
+		rate, err := strconv.ParseFloat(spec.Rate, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate %v", spec.Rate)
+		} else if rate <= 0 {
+			return nil, errors.Errorf("rate %v should be positive", rate)
+		}
+		model.Rate = rate
+	}
+
+	if spec.FailureBurst < 0 {
+		return nil, errors.Errorf("failure burst %v should not be negative", spec.FailureBurst)
+	}
+
+	failureTemplate := spec.FailureTemplate
+	if failureTemplate == "" {
+		failureTemplate = defaultFailureTemplate
+	}
+
+	parsed, err := template.New("failure").Parse(failureTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid failure template %v", failureTemplate)
+	}
+	model.FailureTemplate = parsed
+
+	return model, nil
+}
+
+// SplitLogLines splits replayed log data into its lines, ignoring a trailing newline
+func SplitLogLines(data string) []string {
+	return strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+}
+
+// IsSet returns whether the model writes synthetic lines
+func (l *LogModel) IsSet() bool {
+	return l.Template != nil || len(l.Lines) > 0
+}
+
+// LineAt returns when the line with the given index is written by a container which started at startedAt
+func (l *LogModel) LineAt(startedAt time.Time, line int) time.Time {
+	return startedAt.Add(time.Duration(float64(line) / l.Rate * float64(time.Second)))
+}
+
+// LinesBefore returns the amount of lines written by a container which started at startedAt, before the given time
+func (l *LogModel) LinesBefore(startedAt, before time.Time) int {
+	if !startedAt.Before(before) {
+		return 0
+	}
+
+	// Estimate the amount and correct for rounding errors
+	lines := int(before.Sub(startedAt).Seconds() * l.Rate)
+	for lines > 0 && !l.LineAt(startedAt, lines-1).Before(before) {
+		lines--
+	}
+
+	for l.LineAt(startedAt, lines).Before(before) {
+		lines++
+	}
+
+	return lines
+}
+
+// Line returns the text of the regular line with the given data
+func (l *LogModel) Line(data LogLine) (string, error) {
+	if l.Template == nil {
+		return l.Lines[data.Line%len(l.Lines)], nil
+	}
+
+	return execute(l.Template, data)
+}
+
+// FailureLine returns the text of a line written when a container fails
+func (l *LogModel) FailureLine(data LogLine) (string, error) {
+	return execute(l.FailureTemplate, data)
+}
+
+func execute(t *template.Template, data LogLine) (string, error) {
+	var line bytes.Buffer
+	if err := t.Execute(&line, data); err != nil {
+		return "", errors.Wrapf(err, "failed to execute template %v", t.Name())
+	}
+
+	return line.String(), nil
+}
@@ -0,0 +1,70 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogModel(t *testing.T) {
+	t.Parallel()
+
+	model, err := ParseLogModel(LogModelSpec{Template: "{{.Container}} line {{.Line}}", Rate: "2.5", FailureBurst: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, model.Rate)
+	assert.Equal(t, 3, model.FailureBurst)
+
+	line, err := model.Line(LogLine{Container: "c", Line: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, "c line 4", line)
+
+	line, err = model.FailureLine(LogLine{Container: "c", ExitCode: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "Emulated container c has failed with exit code 2", line)
+}
+
+func TestParseLogModelInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []LogModelSpec{
+		{},
+		{Template: "a", Lines: []string{"b"}},
+		{Template: "{{.Container"},
+		{Template: "a", Rate: "0"},
+		{Template: "a", Rate: "fast"},
+		{Template: "a", FailureBurst: -1},
+		{Template: "a", FailureTemplate: "{{"},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseLogModel(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
+
+func TestLogModelReplay(t *testing.T) {
+	t.Parallel()
+
+	model, err := ParseLogModel(LogModelSpec{Lines: SplitLogLines("a\nb\n")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, model.Lines)
+
+	// Replayed lines start over once all have been written
+	line, err := model.Line(LogLine{Line: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", line)
+}
+
+func TestLogModelLinesBefore(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	model := &LogModel{Rate: 10}
+
+	assert.Equal(t, 0, model.LinesBefore(start, start))
+	assert.Equal(t, 1, model.LinesBefore(start, start.Add(time.Nanosecond)))
+	assert.Equal(t, 10, model.LinesBefore(start, start.Add(time.Second)))
+	assert.Equal(t, 11, model.LinesBefore(start, start.Add(time.Second+time.Millisecond)))
+	assert.Equal(t, start.Add(time.Second), model.LineAt(start, 10))
+}
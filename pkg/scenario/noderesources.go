@@ -25,4 +25,38 @@ type NodeResources struct {
 
 	// Identifier for type of node
 	Label string
+
+	// The index of the node among the nodes with the same label, assigned by the control plane when joining
+	Index int64
+}
+
+// NodeCapacity changes the resources of a node while it runs, resources which are nil keep their value
+type NodeCapacity struct {
+	Memory           *int64
+	CPU              *int64
+	Storage          *int64
+	EphemeralStorage *int64
+	MaxPods          *int64
+}
+
+// Apply returns the given resources with the changes of the capacity applied
+func (c *NodeCapacity) Apply(resources NodeResources) NodeResources {
+	changes := []struct {
+		value  *int64
+		target *int64
+	}{
+		{c.Memory, &resources.Memory},
+		{c.CPU, &resources.CPU},
+		{c.Storage, &resources.Storage},
+		{c.EphemeralStorage, &resources.EphemeralStorage},
+		{c.MaxPods, &resources.MaxPods},
+	}
+
+	for _, change := range changes {
+		if change.value != nil {
+			*change.target = *change.value
+		}
+	}
+
+	return resources
 }
@@ -0,0 +1,20 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCapacityApply(t *testing.T) {
+	t.Parallel()
+
+	resources := NodeResources{Memory: 4096, CPU: 1000, MaxPods: 10, Label: "a/b"}
+
+	memory := int64(2048)
+	pods := int64(0)
+	capacity := &NodeCapacity{Memory: &memory, MaxPods: &pods}
+
+	assert.Equal(t, NodeResources{Memory: 2048, CPU: 1000, MaxPods: 0, Label: "a/b"}, capacity.Apply(resources))
+	assert.Equal(t, resources, (&NodeCapacity{}).Apply(resources))
+}
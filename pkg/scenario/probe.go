@@ -0,0 +1,23 @@
+package scenario
+
+// ProbeResult specifies the emulated result of a probe
+type ProbeResult int
+
+const (
+	// ProbeResultUnset means the probe succeeds, used as a default
+	ProbeResultUnset ProbeResult = iota
+	// ProbeResultSucceed means the probe succeeds
+	ProbeResultSucceed
+	// ProbeResultFail means the probe fails
+	ProbeResultFail
+)
+
+// Probes are the emulated results of the probes in the pod spec of the containers of a pod
+// Containers without a probe of some kind are not affected by its result
+type Probes struct {
+	// The result of readiness probes, containers of which the readiness probe fails are not ready
+	Readiness ProbeResult
+
+	// The result of liveness probes, containers of which the liveness probe fails are killed
+	Liveness ProbeResult
+}
@@ -0,0 +1,45 @@
+package scenario
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+)
+
+// KeyedRandom is a source of random numbers derived from a key, so the same key always results in the same numbers
+// It is not thread safe
+type KeyedRandom struct {
+	state uint64
+}
+
+// NewKeyedRandom creates a new source of random numbers derived from the given key
+func NewKeyedRandom(key string) *KeyedRandom {
+	hash := sha256.Sum256([]byte(key))
+	return &KeyedRandom{state: binary.BigEndian.Uint64(hash[:8])}
+}
+
+// next returns the next number of the splitmix64 sequence
+func (k *KeyedRandom) next() uint64 {
+	k.state += 0x9e3779b97f4a7c15
+	z := k.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Float64 returns a uniformly distributed number in [0, 1)
+func (k *KeyedRandom) Float64() float64 {
+	return float64(k.next()>>11) / (1 << 53)
+}
+
+// NormFloat64 returns a normally distributed number with mean 0 and standard deviation 1, using the Box-Muller transform
+func (k *KeyedRandom) NormFloat64() float64 {
+	u := 1 - k.Float64()
+	v := k.Float64()
+	return math.Sqrt(-2*math.Log(u)) * math.Cos(2*math.Pi*v)
+}
+
+// ExpFloat64 returns an exponentially distributed number with mean 1
+func (k *KeyedRandom) ExpFloat64() float64 {
+	return -math.Log(1 - k.Float64())
+}
@@ -0,0 +1,27 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedRandom(t *testing.T) {
+	t.Parallel()
+
+	a, sameA, b := NewKeyedRandom("a"), NewKeyedRandom("a"), NewKeyedRandom("b")
+
+	differs := false
+	for i := 0; i < 100; i++ {
+		value := a.Float64()
+		assert.Equal(t, value, sameA.Float64())
+		assert.True(t, value >= 0 && value < 1)
+
+		differs = differs || value != b.Float64()
+
+		assert.True(t, a.ExpFloat64() >= 0)
+		sameA.ExpFloat64()
+	}
+
+	assert.True(t, differs, "different keys should result in different numbers")
+}
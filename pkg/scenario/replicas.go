@@ -0,0 +1,120 @@
+package scenario
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ReplicaSelection selects a subset of the replicas of a node configuration by their index
+// Replicas are numbered from 0 by the control plane when they join, in the order in which they join
+type ReplicaSelection struct {
+	// A block of replicas, given by an amount or a percentage of the replicas, after skipping offset replicas
+	count      int64
+	percentage int64
+	offset     int64
+
+	// Explicit indices, as inclusive ranges
+	ranges []indexRange
+}
+
+type indexRange struct {
+	from, to int64
+}
+
+// ReplicaSelectionSpec describes a replica selection as it is configured in a CRD
+// Exactly one of count, percentage and indices should be given
+type ReplicaSelectionSpec struct {
+	Count      int64
+	Percentage int64
+	Offset     int64
+	Indices    string
+}
+
+// ParseReplicaSelection parses the given spec into a replica selection
+func ParseReplicaSelection(spec ReplicaSelectionSpec) (*ReplicaSelection, error) {
+	given := 0
+	for _, ok := range []bool{spec.Count != 0, spec.Percentage != 0, spec.Indices != ""} {
+		if ok {
+			given++
+		}
+	}
+
+	if given != 1 {
+		return nil, errors.New("exactly one of count, percentage and indices should be given")
+	}
+
+	switch {
+	case spec.Count < 0:
+		return nil, errors.Errorf("count %v should be positive", spec.Count)
+	case spec.Percentage < 0 || spec.Percentage > 100:
+		return nil, errors.Errorf("percentage %v should be between 0 and 100", spec.Percentage)
+	case spec.Offset < 0:
+		return nil, errors.Errorf("offset %v should not be negative", spec.Offset)
+	case spec.Indices != "" && spec.Offset != 0:
+		return nil, errors.New("offset can't be combined with indices")
+	}
+
+	selection := &ReplicaSelection{
+		count:      spec.Count,
+		percentage: spec.Percentage,
+		offset:     spec.Offset,
+	}
+
+	if spec.Indices != "" {
+		var err error
+		if selection.ranges, err = parseIndices(spec.Indices); err != nil {
+			return nil, errors.Wrapf(err, "invalid indices %v", spec.Indices)
+		}
+	}
+
+	return selection, nil
+}
+
+// parseIndices parses a comma separated list of indices and inclusive ranges, such as "0-4,7"
+func parseIndices(input string) ([]indexRange, error) {
+	var ranges []indexRange
+
+	for _, part := range strings.Split(input, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+
+		from, err := strconv.ParseInt(bounds[0], 10, 64)
+		if err != nil || from < 0 {
+			return nil, errors.Errorf("invalid index %v", bounds[0])
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			to, err = strconv.ParseInt(bounds[1], 10, 64)
+			if err != nil || to < from {
+				return nil, errors.Errorf("invalid range %v", part)
+			}
+		}
+
+		ranges = append(ranges, indexRange{from: from, to: to})
+	}
+
+	return ranges, nil
+}
+
+// Selects returns true if the replica with the given index is selected, given the desired amount of replicas
+// Percentages are rounded to the nearest replica, so consecutive percentages select consecutive, disjoint blocks of replicas
+func (r *ReplicaSelection) Selects(index, replicas int64) bool {
+	if r.ranges != nil {
+		for _, ir := range r.ranges {
+			if index >= ir.from && index <= ir.to {
+				return true
+			}
+		}
+		return false
+	}
+
+	from, to := r.offset, r.offset+r.count
+	if r.percentage != 0 {
+		from = (r.offset*replicas + 50) / 100
+		to = ((r.offset+r.percentage)*replicas + 50) / 100
+	}
+
+	return index >= from && index < to
+}
@@ -0,0 +1,70 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func selected(selection *ReplicaSelection, replicas int64) []int64 {
+	var indices []int64
+	for i := int64(0); i < replicas; i++ {
+		if selection.Selects(i, replicas) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func TestReplicaSelectionCount(t *testing.T) {
+	t.Parallel()
+
+	selection, err := ParseReplicaSelection(ReplicaSelectionSpec{Count: 2, Offset: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, selected(selection, 10))
+}
+
+func TestReplicaSelectionPercentage(t *testing.T) {
+	t.Parallel()
+
+	first, err := ParseReplicaSelection(ReplicaSelectionSpec{Percentage: 10})
+	assert.NoError(t, err)
+	second, err := ParseReplicaSelection(ReplicaSelectionSpec{Percentage: 10, Offset: 10})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int64{0, 1}, selected(first, 20))
+	assert.Equal(t, []int64{2, 3}, selected(second, 20))
+
+	// Rounded to the nearest replica, without overlap
+	assert.Equal(t, []int64{0}, selected(first, 5))
+	assert.Nil(t, selected(second, 5))
+}
+
+func TestReplicaSelectionIndices(t *testing.T) {
+	t.Parallel()
+
+	selection, err := ParseReplicaSelection(ReplicaSelectionSpec{Indices: "0-2, 5,7-7"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 5, 7}, selected(selection, 10))
+}
+
+func TestReplicaSelectionInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []ReplicaSelectionSpec{
+		{},
+		{Count: 1, Percentage: 10},
+		{Count: -1},
+		{Percentage: 101},
+		{Count: 1, Offset: -1},
+		{Indices: "1", Offset: 1},
+		{Indices: "a"},
+		{Indices: "3-1"},
+		{Indices: "-1"},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseReplicaSelection(spec)
+		assert.Error(t, err, "spec %v", spec)
+	}
+}
@@ -1,5 +1,12 @@
 package scenario
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 // Response specifies the response type
 type Response int
 
@@ -13,3 +20,75 @@ const (
 	// ResponseError will error on any request
 	ResponseError
 )
+
+// WeightedResponse is a response which is chosen with the given probability
+type WeightedResponse struct {
+	Response Response
+
+	// The probability of this response, between 0 and 1
+	Probability float64
+}
+
+// ResponseDistribution is a weighted mix of responses, of which one is drawn for every request
+// The probability which is not assigned to any response is assigned to ResponseNormal
+type ResponseDistribution []WeightedResponse
+
+var responseNames = map[string]Response{
+	"NORMAL":  ResponseNormal,
+	"TIMEOUT": ResponseTimeout,
+	"ERROR":   ResponseError,
+}
+
+// IsResponseDistribution returns true if the given input describes a weighted mix of responses, such as "ERROR=5%"
+func IsResponseDistribution(input string) bool {
+	return strings.Contains(input, "=")
+}
+
+// ParseResponseDistribution parses a weighted mix of responses, such as "ERROR=5%,TIMEOUT=2%"
+// The remaining probability, 93% in this example, is assigned to ResponseNormal
+func ParseResponseDistribution(input string) (ResponseDistribution, error) {
+	var distribution ResponseDistribution
+	var total float64
+
+	for _, part := range strings.Split(input, ",") {
+		split := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(split) != 2 {
+			return nil, errors.Errorf("invalid weighted response %v, expected <response>=<percentage>%%", part)
+		}
+
+		response, ok := responseNames[split[0]]
+		if !ok {
+			return nil, errors.Errorf("invalid response %v in weighted response %v", split[0], part)
+		}
+
+		percentage, err := strconv.ParseFloat(strings.TrimSuffix(split[1], "%"), 64)
+		if err != nil || percentage < 0 {
+			return nil, errors.Errorf("invalid percentage %v in weighted response %v", split[1], part)
+		}
+
+		total += percentage
+		distribution = append(distribution, WeightedResponse{
+			Response:    response,
+			Probability: percentage / 100,
+		})
+	}
+
+	if total > 100 {
+		return nil, errors.Errorf("percentages of weighted response %v add up to more than 100%%", input)
+	}
+
+	return distribution, nil
+}
+
+// Draw returns the response corresponding to the given random number, which should be between 0 and 1
+func (d ResponseDistribution) Draw(random float64) Response {
+	var cumulative float64
+	for _, weighted := range d {
+		cumulative += weighted.Probability
+		if random < cumulative {
+			return weighted.Response
+		}
+	}
+
+	return ResponseNormal
+}
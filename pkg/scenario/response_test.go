@@ -0,0 +1,42 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	distribution, err := ParseResponseDistribution("ERROR=5%, TIMEOUT=2.5%")
+	assert.NoError(t, err)
+	assert.Equal(t, ResponseDistribution{
+		{Response: ResponseError, Probability: 0.05},
+		{Response: ResponseTimeout, Probability: 0.025},
+	}, distribution)
+}
+
+func TestParseResponseDistributionInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"ERROR", "UNSET=5%", "ERROR=abc%", "ERROR=-5%", "ERROR=60%,TIMEOUT=41%"} {
+		_, err := ParseResponseDistribution(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestResponseDistributionDraw(t *testing.T) {
+	t.Parallel()
+
+	distribution := ResponseDistribution{
+		{Response: ResponseError, Probability: 0.05},
+		{Response: ResponseTimeout, Probability: 0.02},
+	}
+
+	assert.Equal(t, ResponseError, distribution.Draw(0))
+	assert.Equal(t, ResponseError, distribution.Draw(0.049))
+	assert.Equal(t, ResponseTimeout, distribution.Draw(0.05))
+	assert.Equal(t, ResponseNormal, distribution.Draw(0.07))
+	assert.Equal(t, ResponseNormal, distribution.Draw(0.99))
+}
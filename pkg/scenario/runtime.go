@@ -0,0 +1,92 @@
+package scenario
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RuntimeModel determines how long a pod runs before it completes, like the pods of a job do
+// The zero value runs indefinitely
+type RuntimeModel struct {
+	// The distribution from which the runtime is sampled, nil if only the annotation is used
+	Runtime LatencyDistribution
+
+	// The annotation of the pod which contains its runtime, it takes precedence over the distribution if the pod has it
+	Annotation string
+
+	// The probability that the pod fails instead of succeeds once its runtime has elapsed
+	FailureProbability float64
+
+	// The exit code of the containers of a pod which has failed
+	ExitCode int32
+}
+
+// RuntimeModelSpec describes a runtime model as it is configured in a CRD
+type RuntimeModelSpec struct {
+	Runtime            LatencyDistribution
+	Annotation         string
+	FailureProbability string
+	ExitCode           int32
+}
+
+// PodRuntime is the sampled runtime of a single pod
+type PodRuntime struct {
+	// How long the pod runs after its containers started
+	Runtime time.Duration
+
+	// Whether the pod fails instead of succeeds once its runtime has elapsed
+	Failed bool
+}
+
+// ParseRuntimeModel parses the given spec into a runtime model
+func ParseRuntimeModel(spec RuntimeModelSpec) (*RuntimeModel, error) {
+	model := &RuntimeModel{
+		Runtime:    spec.Runtime,
+		Annotation: spec.Annotation,
+		ExitCode:   spec.ExitCode,
+	}
+
+	if spec.Runtime == nil && spec.Annotation == "" {
+		return nil, errors.New("a runtime model needs a runtime or an annotation")
+	}
+
+	if spec.FailureProbability != "" {
+		probability, err := strconv.ParseFloat(spec.FailureProbability, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid failure probability %v", spec.FailureProbability)
+		} else if probability < 0 || probability > 1 {
+			return nil, errors.Errorf("failure probability %v should be between 0 and 1", probability)
+		}
+		model.FailureProbability = probability
+
+		if probability > 0 && spec.ExitCode == 0 {
+			return nil, errors.New("failed pods should have a non-zero exit code")
+		}
+	}
+
+	return model, nil
+}
+
+// Sample returns the runtime of the pod with the given annotations, or false if the pod runs indefinitely
+// This is the case when the model has no runtime distribution and the pod doesn't have the annotation
+func (r *RuntimeModel) Sample(annotations map[string]string, random RandomSource) (PodRuntime, bool, error) {
+	var runtime PodRuntime
+	if value, ok := annotations[r.Annotation]; ok && r.Annotation != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return PodRuntime{}, false, errors.Wrapf(err, "invalid runtime %v in annotation %v", value, r.Annotation)
+		} else if duration < 0 {
+			return PodRuntime{}, false, errors.Errorf("runtime %v in annotation %v should not be negative", value, r.Annotation)
+		}
+		runtime.Runtime = duration
+	} else if r.Runtime != nil {
+		runtime.Runtime = r.Runtime.Sample(random)
+	} else {
+		return PodRuntime{}, false, nil
+	}
+
+	runtime.Failed = r.FailureProbability > 0 && random.Float64() < r.FailureProbability
+	return runtime, true, nil
+}
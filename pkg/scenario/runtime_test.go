@@ -0,0 +1,62 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuntimeModel(t *testing.T) {
+	t.Parallel()
+
+	model, err := ParseRuntimeModel(RuntimeModelSpec{
+		Runtime:            ConstantLatency(time.Minute),
+		Annotation:         "runtime",
+		FailureProbability: "0.25",
+		ExitCode:           2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &RuntimeModel{Runtime: ConstantLatency(time.Minute), Annotation: "runtime", FailureProbability: 0.25, ExitCode: 2}, model)
+}
+
+func TestParseRuntimeModelInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []RuntimeModelSpec{
+		{ExitCode: 1},
+		{Annotation: "runtime", FailureProbability: "2", ExitCode: 1},
+		{Annotation: "runtime", FailureProbability: "often", ExitCode: 1},
+		{Annotation: "runtime", FailureProbability: "0.5"},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseRuntimeModel(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
+
+func TestSampleRuntime(t *testing.T) {
+	t.Parallel()
+
+	model := &RuntimeModel{Runtime: ConstantLatency(time.Minute), Annotation: "runtime", FailureProbability: 0.5, ExitCode: 1}
+
+	runtime, ok, err := model.Sample(nil, fixedRandom{uniform: 0.6})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, PodRuntime{Runtime: time.Minute}, runtime)
+
+	// The annotation takes precedence over the distribution
+	runtime, ok, err = model.Sample(map[string]string{"runtime": "2h"}, fixedRandom{uniform: 0.4})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, PodRuntime{Runtime: 2 * time.Hour, Failed: true}, runtime)
+
+	_, _, err = model.Sample(map[string]string{"runtime": "soon"}, fixedRandom{})
+	assert.Error(t, err)
+
+	// Without a distribution, pods without the annotation run indefinitely
+	_, ok, err = (&RuntimeModel{Annotation: "runtime"}).Sample(nil, fixedRandom{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,49 @@
+package scenario
+
+import "time"
+
+// StartupPhases are the distributions from which the durations of the phases a pod goes through before its containers run are sampled
+// A nil distribution means the phase takes no time, or that the default of the node is used
+type StartupPhases struct {
+	// The time between the pod being scheduled on the node and the node starting to pull its images
+	Scheduled LatencyDistribution
+
+	// The time it takes to pull the images of the pod
+	ImagePull LatencyDistribution
+
+	// The time it takes to create the containers of the pod once their images have been pulled
+	ContainerCreating LatencyDistribution
+}
+
+// WithDefaults returns the startup phases, of which the unset phases are taken from the given defaults
+func (s *StartupPhases) WithDefaults(defaults *StartupPhases) *StartupPhases {
+	merged := *s
+	if merged.Scheduled == nil {
+		merged.Scheduled = defaults.Scheduled
+	}
+
+	if merged.ImagePull == nil {
+		merged.ImagePull = defaults.ImagePull
+	}
+
+	if merged.ContainerCreating == nil {
+		merged.ContainerCreating = defaults.ContainerCreating
+	}
+
+	return &merged
+}
+
+// Sample returns the durations of the scheduled, image pull and container creating phases in this order
+func (s *StartupPhases) Sample(random RandomSource) []time.Duration {
+	durations := make([]time.Duration, 0, 3)
+	for _, phase := range []LatencyDistribution{s.Scheduled, s.ImagePull, s.ContainerCreating} {
+		var duration time.Duration
+		if phase != nil {
+			duration = phase.Sample(random)
+		}
+
+		durations = append(durations, duration)
+	}
+
+	return durations
+}
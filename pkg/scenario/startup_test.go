@@ -0,0 +1,38 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartupPhasesWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	phases := &StartupPhases{ImagePull: ConstantLatency(time.Minute)}
+	defaults := &StartupPhases{
+		Scheduled: ConstantLatency(time.Second),
+		ImagePull: ConstantLatency(time.Hour),
+	}
+
+	merged := phases.WithDefaults(defaults)
+	assert.Equal(t, &StartupPhases{
+		Scheduled: ConstantLatency(time.Second),
+		ImagePull: ConstantLatency(time.Minute),
+	}, merged)
+
+	// The phases themselves are left untouched
+	assert.Nil(t, phases.Scheduled)
+}
+
+func TestStartupPhasesSample(t *testing.T) {
+	t.Parallel()
+
+	phases := &StartupPhases{
+		Scheduled:         ConstantLatency(time.Second),
+		ContainerCreating: ConstantLatency(time.Minute),
+	}
+
+	assert.Equal(t, []time.Duration{time.Second, 0, time.Minute}, phases.Sample(nil))
+}
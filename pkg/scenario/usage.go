@@ -0,0 +1,140 @@
+package scenario
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/pkg/errors"
+)
+
+// UsageShape determines how the resource usage of a pod changes over time
+type UsageShape string
+
+const (
+	// UsageStep sets the usage immediately
+	UsageStep UsageShape = "STEP"
+
+	// UsageLinear moves the usage linearly from its previous value to the new one
+	UsageLinear UsageShape = "LINEAR"
+
+	// UsageSine makes the usage oscillate around the new value
+	UsageSine UsageShape = "SINE"
+
+	// UsageRandomWalk makes the usage wander randomly around the new value, within bounds
+	UsageRandomWalk UsageShape = "RANDOM_WALK"
+)
+
+// UsageCurve describes how the resource usage of a pod changes over time after a new usage has been set
+type UsageCurve struct {
+	Shape UsageShape
+
+	// The time it takes a linear curve to reach the new usage
+	Ramp time.Duration
+
+	// The time it takes a sine curve to complete a single oscillation
+	Period time.Duration
+
+	// The maximum deviation of a sine curve or random walk, as a fraction of the new usage
+	Amplitude float64
+
+	// The time between two steps of a random walk
+	Interval time.Duration
+}
+
+// ResourceCurve is the value of the pod resources flag if the usage changes gradually instead of immediately
+type ResourceCurve struct {
+	// The usage which is reached by a linear curve, or around which the other curves move
+	Usage *stats.PodStats
+
+	Curve UsageCurve
+}
+
+// UsageCurveSpec describes a usage curve as it is configured in a CRD
+// All durations are in time.ParseDuration format, such as "10ms" or "42s"
+type UsageCurveSpec struct {
+	Shape     string
+	Ramp      string
+	Period    string
+	Amplitude string
+	Interval  string
+}
+
+// ParseUsageCurve parses the given spec into a usage curve
+func ParseUsageCurve(spec UsageCurveSpec) (UsageCurve, error) {
+	curve := UsageCurve{Shape: UsageShape(spec.Shape)}
+
+	var err error
+	switch curve.Shape {
+	case UsageStep:
+		return curve, nil
+	case UsageLinear:
+		curve.Ramp, err = parsePositiveDuration("ramp", spec.Ramp)
+		return curve, err
+	case UsageSine:
+		if curve.Period, err = parsePositiveDuration("period", spec.Period); err != nil {
+			return UsageCurve{}, err
+		}
+	case UsageRandomWalk:
+		if curve.Interval, err = parsePositiveDuration("interval", spec.Interval); err != nil {
+			return UsageCurve{}, err
+		}
+	default:
+		return UsageCurve{}, errors.Errorf("unknown usage curve shape %v", spec.Shape)
+	}
+
+	curve.Amplitude, err = strconv.ParseFloat(spec.Amplitude, 64)
+	if err != nil {
+		return UsageCurve{}, errors.Wrapf(err, "invalid amplitude %v", spec.Amplitude)
+	} else if curve.Amplitude < 0 || curve.Amplitude > 1 {
+		return UsageCurve{}, errors.Errorf("amplitude %v should be between 0 and 1", curve.Amplitude)
+	}
+
+	return curve, nil
+}
+
+// Value returns a single resource usage the given time after the curve started
+// From is the usage when the curve started, target is the new usage
+// Walk is the current position of a random walk, see Walk
+func (c UsageCurve) Value(from, target float64, elapsed time.Duration, walk float64) float64 {
+	switch c.Shape {
+	case UsageLinear:
+		if elapsed >= c.Ramp {
+			return target
+		}
+		return from + (target-from)*float64(elapsed)/float64(c.Ramp)
+	case UsageSine:
+		return target * (1 + c.Amplitude*math.Sin(2*math.Pi*float64(elapsed)/float64(c.Period)))
+	case UsageRandomWalk:
+		return target * (1 + walk)
+	default:
+		return target
+	}
+}
+
+// Steps returns the amount of steps a random walk has taken the given time after the curve started
+func (c UsageCurve) Steps(elapsed time.Duration) int64 {
+	if c.Shape != UsageRandomWalk {
+		return 0
+	}
+	return int64(elapsed / c.Interval)
+}
+
+// Walk takes a single step of a random walk from the given position, which stays between -Amplitude and Amplitude
+// Each step has a normally distributed size with a standard deviation of a quarter of the amplitude
+func (c UsageCurve) Walk(position float64, random RandomSource) float64 {
+	position += random.NormFloat64() * c.Amplitude / 4
+	return math.Max(-c.Amplitude, math.Min(c.Amplitude, position))
+}
+
+func parsePositiveDuration(field, input string) (time.Duration, error) {
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid duration %v for %v", input, field)
+	} else if duration <= 0 {
+		return 0, errors.Errorf("%v %v should be positive", field, input)
+	}
+
+	return duration, nil
+}
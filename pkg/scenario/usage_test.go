@@ -0,0 +1,84 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUsageCurve(t *testing.T) {
+	t.Parallel()
+
+	curve, err := ParseUsageCurve(UsageCurveSpec{Shape: "STEP"})
+	assert.NoError(t, err)
+	assert.Equal(t, UsageCurve{Shape: UsageStep}, curve)
+
+	curve, err = ParseUsageCurve(UsageCurveSpec{Shape: "LINEAR", Ramp: "30s"})
+	assert.NoError(t, err)
+	assert.Equal(t, UsageCurve{Shape: UsageLinear, Ramp: 30 * time.Second}, curve)
+
+	curve, err = ParseUsageCurve(UsageCurveSpec{Shape: "RANDOM_WALK", Interval: "1s", Amplitude: "0.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, UsageCurve{Shape: UsageRandomWalk, Interval: time.Second, Amplitude: 0.1}, curve)
+}
+
+func TestParseUsageCurveInvalid(t *testing.T) {
+	t.Parallel()
+
+	specs := []UsageCurveSpec{
+		{Shape: "SQUARE"},
+		{Shape: "LINEAR"},
+		{Shape: "LINEAR", Ramp: "-1s"},
+		{Shape: "SINE", Period: "1m"},
+		{Shape: "SINE", Period: "1m", Amplitude: "2"},
+		{Shape: "RANDOM_WALK", Amplitude: "0.1"},
+	}
+
+	for _, spec := range specs {
+		_, err := ParseUsageCurve(spec)
+		assert.Error(t, err, "spec %v", spec)
+	}
+}
+
+func TestUsageCurveLinear(t *testing.T) {
+	t.Parallel()
+
+	curve := UsageCurve{Shape: UsageLinear, Ramp: 10 * time.Second}
+	assert.Equal(t, 100.0, curve.Value(100, 200, 0, 0))
+	assert.Equal(t, 150.0, curve.Value(100, 200, 5*time.Second, 0))
+	assert.Equal(t, 200.0, curve.Value(100, 200, time.Minute, 0))
+	assert.Equal(t, 50.0, curve.Value(100, 0, 5*time.Second, 0))
+}
+
+func TestUsageCurveSine(t *testing.T) {
+	t.Parallel()
+
+	curve := UsageCurve{Shape: UsageSine, Period: 4 * time.Second, Amplitude: 0.5}
+	assert.InDelta(t, 100.0, curve.Value(0, 100, 0, 0), 1e-9)
+	assert.InDelta(t, 150.0, curve.Value(0, 100, time.Second, 0), 1e-9)
+	assert.InDelta(t, 50.0, curve.Value(0, 100, 3*time.Second, 0), 1e-9)
+}
+
+func TestUsageCurveRandomWalk(t *testing.T) {
+	t.Parallel()
+
+	curve := UsageCurve{Shape: UsageRandomWalk, Interval: time.Second, Amplitude: 0.2}
+	assert.Equal(t, int64(3), curve.Steps(3500*time.Millisecond))
+
+	// Every step moves a quarter of the amplitude per standard deviation, but never beyond the amplitude
+	position := curve.Walk(0, fixedRandom{normal: 1})
+	assert.InDelta(t, 0.05, position, 1e-9)
+	assert.InDelta(t, 0.2, curve.Walk(0.19, fixedRandom{normal: 1}), 1e-9)
+	assert.InDelta(t, -0.2, curve.Walk(-0.1, fixedRandom{normal: -3}), 1e-9)
+
+	assert.InDelta(t, 110.0, curve.Value(0, 100, 0, 0.1), 1e-9)
+}
+
+func TestUsageCurveStep(t *testing.T) {
+	t.Parallel()
+
+	curve := UsageCurve{Shape: UsageStep}
+	assert.Equal(t, 200.0, curve.Value(100, 200, 0, 0))
+	assert.Equal(t, int64(0), curve.Steps(time.Hour))
+}
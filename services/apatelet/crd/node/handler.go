@@ -1,7 +1,9 @@
 package node
 
 import (
+	"fmt"
 	"log"
+	"reflect"
 	"time"
 
 	"github.com/atlarge-research/apate/internal/crd/node"
@@ -10,11 +12,13 @@ import (
 
 	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/kubernetes/kubeconfig"
+	"github.com/atlarge-research/apate/pkg/scenario"
 	"github.com/atlarge-research/apate/services/apatelet/store"
 )
 
 // CreateNodeInformer creates a new node informer
-func CreateNodeInformer(config *kubeconfig.KubeConfig, st *store.Store, label string, stopch <-chan struct{}, wakeScheduler func()) error {
+// Only the tasks which target the replica with the given index are scheduled
+func CreateNodeInformer(config *kubeconfig.KubeConfig, st *store.Store, label string, index int64, stopch <-chan struct{}, wakeScheduler func()) error {
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return errors.Wrap(err, "couldn't get kubeconfig")
@@ -30,19 +34,24 @@ func CreateNodeInformer(config *kubeconfig.KubeConfig, st *store.Store, label st
 		nodeCfg := obj.(*nodeconfigv1.NodeConfiguration)
 
 		if node.GetCrdLabel(nodeCfg) == label {
-			err := setNodeTasks(nodeCfg, st)
+			err := setNodeTasks(nodeCfg, st, index)
 			if err != nil {
 				log.Printf("error while adding node tasks: %v\n", err)
 			}
 		}
 
 		wakeScheduler()
-	}, func(_, obj interface{}) {
+	}, func(oldObj, obj interface{}) {
 		// Update function
 		nodeCfg := obj.(*nodeconfigv1.NodeConfiguration)
 
+		if node.IsStatusUpdate(oldObj.(*nodeconfigv1.NodeConfiguration), nodeCfg) {
+			// Status updates are done by the control plane and do not influence the tasks
+			return
+		}
+
 		if node.GetCrdLabel(nodeCfg) == label {
-			err := setNodeTasks(nodeCfg, st)
+			err := setNodeTasks(nodeCfg, st, index)
 			if err != nil {
 				log.Printf("error while adding node tasks: %v\n", err)
 			}
@@ -57,25 +66,88 @@ func CreateNodeInformer(config *kubeconfig.KubeConfig, st *store.Store, label st
 	return nil
 }
 
-func setNodeTasks(nodeCfg *nodeconfigv1.NodeConfiguration, st *store.Store) error {
-	// Validating timestamps before actually doing anything
+func setNodeTasks(nodeCfg *nodeconfigv1.NodeConfiguration, st *store.Store, index int64) error {
+	// Validating timestamps, recurrences, windows, targets and jitter before actually doing anything
 	var durations = make([]time.Duration, len(nodeCfg.Spec.Tasks))
+	var recurrences = make([]*store.Recurrence, len(nodeCfg.Spec.Tasks))
+	var windows = make([]time.Duration, len(nodeCfg.Spec.Tasks))
+	var targets = make([]*scenario.ReplicaSelection, len(nodeCfg.Spec.Tasks))
+	var jitters = make([]scenario.LatencyDistribution, len(nodeCfg.Spec.Tasks))
 	for i, task := range nodeCfg.Spec.Tasks {
 		duration, err := time.ParseDuration(task.Timestamp)
 		if err != nil {
 			return errors.Wrapf(err, "error while converting timestamp %v to a duration", task.Timestamp)
 		}
 		durations[i] = duration
+
+		if task.Repeat != nil {
+			recurrences[i], err = store.NewRecurrence(task.Repeat.Interval, task.Repeat.Cron, task.Repeat.Count, task.Repeat.Until)
+			if err != nil {
+				return errors.Wrapf(err, "invalid repeat in task at %v", task.Timestamp)
+			}
+		}
+
+		if task.Duration != "" {
+			windows[i], err = time.ParseDuration(task.Duration)
+			if err != nil {
+				return errors.Wrapf(err, "error while converting duration %v of task at %v", task.Duration, task.Timestamp)
+			} else if windows[i] <= 0 {
+				return errors.Errorf("duration %v of task at %v should be positive", task.Duration, task.Timestamp)
+			}
+		}
+
+		if task.Target != nil {
+			targets[i], err = scenario.ParseReplicaSelection(scenario.ReplicaSelectionSpec{
+				Count:      task.Target.Count,
+				Percentage: task.Target.Percentage,
+				Offset:     task.Target.Offset,
+				Indices:    task.Target.Indices,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "invalid target in task at %v", task.Timestamp)
+			}
+		}
+
+		if task.Jitter != nil {
+			jitters[i], err = TranslateLatency(task.Jitter)
+			if err != nil {
+				return errors.Wrapf(err, "invalid jitter in task at %v", task.Timestamp)
+			}
+		}
 	}
 
-	if nodeCfg.Spec.NodeConfigurationState != (nodeconfigv1.NodeConfigurationState{}) {
-		SetNodeFlags(st, &nodeCfg.Spec.NodeConfigurationState)
+	// Validating states before actually doing anything, as node tasks are only translated once executed
+	for _, task := range nodeCfg.Spec.Tasks {
+		task := task
+		if _, err := TranslateNodeFlags(&task.State); err != nil {
+			return errors.Wrapf(err, "invalid state in task at %v", task.Timestamp)
+		}
+	}
+
+	if !reflect.DeepEqual(nodeCfg.Spec.NodeConfigurationState, nodeconfigv1.NodeConfigurationState{}) {
+		if err := SetNodeFlags(st, &nodeCfg.Spec.NodeConfigurationState); err != nil {
+			return errors.Wrap(err, "failed to set node flags")
+		}
 	}
 
 	var tasks []*store.Task
 	for i, task := range nodeCfg.Spec.Tasks {
+		if targets[i] != nil && !targets[i].Selects(index, nodeCfg.Spec.Replicas) {
+			continue
+		}
+
+		// The jitter is sampled once for every replica, deterministically so it doesn't change when the configuration is updated
+		var jitter time.Duration
+		if jitters[i] != nil {
+			jitter = jitters[i].Sample(scenario.NewKeyedRandom(fmt.Sprintf("%v/%v/%v", node.GetCrdLabel(nodeCfg), i, index)))
+		}
+
 		state := task.State
-		tasks = append(tasks, store.NewNodeTask(durations[i], &state))
+		nodeTask := store.NewNodeTask(durations[i]+jitter, &state)
+		nodeTask.Recurrence = recurrences[i]
+		nodeTask.Duration = windows[i]
+		nodeTask.Jitter = jitter
+		tasks = append(tasks, nodeTask)
 	}
 
 	if err := (*st).SetNodeTasks(tasks); err != nil {
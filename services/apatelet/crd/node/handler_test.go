@@ -73,7 +73,7 @@ func TestEnqueueNodeTasks(t *testing.T) {
 		assert.EqualValues(t, et2, arr[1])
 	})
 
-	err := setNodeTasks(&ep, &s)
+	err := setNodeTasks(&ep, &s, 0)
 	assert.NoError(t, err)
 }
 
@@ -116,6 +116,211 @@ func TestEnqueueCRDDirect(t *testing.T) {
 		assert.Equal(t, 0, len(arr))
 	})
 
-	err := setNodeTasks(&ep, &s)
+	err := setNodeTasks(&ep, &s, 0)
 	assert.NoError(t, err)
 }
+
+func TestEnqueueNodeTasksRepeat(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := nodeconfigv1.NodeConfiguration{
+		Spec: nodeconfigv1.NodeConfigurationSpec{
+			Tasks: []nodeconfigv1.NodeConfigurationTask{
+				{
+					Timestamp: "10m",
+					State: nodeconfigv1.NodeConfigurationState{
+						HeartbeatFailed: true,
+					},
+					Repeat: &nodeconfigv1.TaskRepeat{
+						Interval: "10m",
+						Until:    "24h",
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetNodeTasks(gomock.Any()).Do(func(arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, &store.Recurrence{Interval: 10 * time.Minute, Until: 24 * time.Hour}, arr[0].Recurrence)
+	})
+
+	assert.NoError(t, setNodeTasks(&ep, &s, 0))
+}
+
+func TestEnqueueNodeTasksInvalidRepeat(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := nodeconfigv1.NodeConfiguration{
+		Spec: nodeconfigv1.NodeConfigurationSpec{
+			Tasks: []nodeconfigv1.NodeConfigurationTask{
+				{
+					Timestamp: "10m",
+					Repeat:    &nodeconfigv1.TaskRepeat{Cron: "every minute"},
+				},
+			},
+		},
+	}
+
+	assert.Error(t, setNodeTasks(&ep, &s, 0))
+}
+
+func TestEnqueueNodeTasksDuration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := nodeconfigv1.NodeConfiguration{
+		Spec: nodeconfigv1.NodeConfigurationSpec{
+			Tasks: []nodeconfigv1.NodeConfigurationTask{
+				{
+					Timestamp: "10m",
+					Duration:  "30s",
+					State: nodeconfigv1.NodeConfigurationState{
+						HeartbeatFailed: true,
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetNodeTasks(gomock.Any()).Do(func(arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, 30*time.Second, arr[0].Duration)
+	})
+
+	assert.NoError(t, setNodeTasks(&ep, &s, 0))
+
+	// Durations should be positive
+	ep.Spec.Tasks[0].Duration = "-30s"
+	assert.Error(t, setNodeTasks(&ep, &s, 0))
+}
+
+func TestEnqueueNodeTasksTarget(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := nodeconfigv1.NodeConfiguration{
+		Spec: nodeconfigv1.NodeConfigurationSpec{
+			Replicas: 20,
+			Tasks: []nodeconfigv1.NodeConfigurationTask{
+				{
+					Timestamp: "5m",
+					Target:    &nodeconfigv1.TaskTarget{Percentage: 10},
+					State:     nodeconfigv1.NodeConfigurationState{NodeFailed: true},
+				},
+				{
+					Timestamp: "10m",
+					Target:    &nodeconfigv1.TaskTarget{Percentage: 10, Offset: 10},
+					State:     nodeconfigv1.NodeConfigurationState{NodeFailed: true},
+				},
+				{
+					Timestamp: "15m",
+					State:     nodeconfigv1.NodeConfigurationState{HeartbeatFailed: true},
+				},
+			},
+		},
+	}
+
+	expected := map[int64][]time.Duration{
+		0:  {5 * time.Minute, 15 * time.Minute},
+		3:  {10 * time.Minute, 15 * time.Minute},
+		10: {15 * time.Minute},
+	}
+
+	for index, timestamps := range expected {
+		timestamps := timestamps
+		ms.EXPECT().SetNodeTasks(gomock.Any()).Do(func(arr []*store.Task) {
+			var actual []time.Duration
+			for _, task := range arr {
+				actual = append(actual, task.RelativeTimestamp)
+			}
+			assert.Equal(t, timestamps, actual)
+		})
+
+		assert.NoError(t, setNodeTasks(&ep, &s, index))
+	}
+
+	// Targets should select replicas in exactly one way
+	ep.Spec.Tasks[0].Target.Count = 1
+	assert.Error(t, setNodeTasks(&ep, &s, 0))
+}
+
+func TestEnqueueNodeTasksJitter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := nodeconfigv1.NodeConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: nodeconfigv1.NodeConfigurationSpec{
+			Replicas: 2,
+			Tasks: []nodeconfigv1.NodeConfigurationTask{
+				{
+					Timestamp: "10m",
+					Jitter: &nodeconfigv1.Latency{
+						Distribution: nodeconfigv1.LatencyUniform,
+						Min:          "0s",
+						Max:          "1m",
+					},
+					State: nodeconfigv1.NodeConfigurationState{
+						HeartbeatFailed: true,
+					},
+				},
+			},
+		},
+	}
+
+	var timestamps []time.Duration
+	ms.EXPECT().SetNodeTasks(gomock.Any()).Do(func(arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.True(t, arr[0].Jitter >= 0 && arr[0].Jitter < time.Minute)
+		assert.Equal(t, 10*time.Minute+arr[0].Jitter, arr[0].RelativeTimestamp)
+		timestamps = append(timestamps, arr[0].RelativeTimestamp)
+	}).Times(3)
+
+	assert.NoError(t, setNodeTasks(&ep, &s, 0))
+	assert.NoError(t, setNodeTasks(&ep, &s, 0))
+	assert.NoError(t, setNodeTasks(&ep, &s, 1))
+
+	// The jitter of a replica doesn't change, but differs between replicas
+	assert.Equal(t, timestamps[0], timestamps[1])
+	assert.NotEqual(t, timestamps[0], timestamps[2])
+
+	// Invalid jitter is rejected
+	ep.Spec.Tasks[0].Jitter.Max = ""
+	assert.Error(t, setNodeTasks(&ep, &s, 0))
+}
@@ -4,6 +4,9 @@ package node
 import (
 	"time"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
 	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/scenario"
 	"github.com/atlarge-research/apate/pkg/scenario/events"
@@ -11,11 +14,37 @@ import (
 )
 
 // SetNodeFlags sets the correct flags for the apatelet
-func SetNodeFlags(st *store.Store, state *nodeconfigv1.NodeConfigurationState) {
+func SetNodeFlags(st *store.Store, state *nodeconfigv1.NodeConfigurationState) error {
+	flags, err := TranslateNodeFlags(state)
+	if err != nil {
+		return errors.Wrap(err, "failed to translate node state into flags")
+	}
+
+	(*st).SetNodeFlags(flags)
+
+	return nil
+}
+
+// SetNodeWindowFlags sets the flags of the given state in a window, so they are restored once the window is closed
+func SetNodeWindowFlags(st *store.Store, window store.Window, state *nodeconfigv1.NodeConfigurationState) error {
+	flags, err := TranslateNodeFlags(state)
+	if err != nil {
+		return errors.Wrap(err, "failed to translate node state into flags")
+	}
+
+	(*st).SetNodeWindowFlags(window, flags)
+
+	return nil
+}
+
+// TranslateNodeFlags translates a node state into a map of flags
+func TranslateNodeFlags(state *nodeconfigv1.NodeConfigurationState) (store.Flags, error) {
 	flags := make(store.Flags)
 
 	// Set custom flags
-	setCustomFlags(flags, state.CustomState)
+	if err := setCustomFlags(flags, state.CustomState); err != nil {
+		return nil, errors.Wrap(err, "failed to translate custom state")
+	}
 
 	// Check if the node should no longer respond to heartbeat
 	if state.HeartbeatFailed {
@@ -23,10 +52,64 @@ func SetNodeFlags(st *store.Store, state *nodeconfigv1.NodeConfigurationState) {
 	}
 
 	// Set latency
-	latency, err := time.ParseDuration(state.NetworkLatency)
-	if err == nil && latency >= 0 {
+	if state.Latency != nil {
+		distribution, err := TranslateLatency(state.Latency)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate latency")
+		}
+
+		flags[events.NodeAddedLatency] = distribution
+	} else if latency, err := time.ParseDuration(state.NetworkLatency); err == nil && latency >= 0 {
 		// Ignore errors explicitly, only valid ints are seen as updates
-		flags[events.NodeAddedLatency] = latency
+		flags[events.NodeAddedLatency] = scenario.ConstantLatency(latency)
+	}
+
+	if state.PodStartup != nil {
+		startup, err := translateStartup(state.PodStartup)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate pod startup")
+		}
+
+		flags[events.NodePodStartup] = startup
+	}
+
+	if state.Images != nil {
+		images, err := translateImages(state.Images)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate images")
+		}
+
+		flags[events.NodeImages] = images
+	}
+
+	if state.Conditions != nil {
+		conditions, err := translateConditions(state.Conditions)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate conditions")
+		}
+
+		flags[events.NodeConditions] = conditions
+	}
+
+	if state.Capacity != nil {
+		capacity, err := translateCapacity(state.Capacity)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate capacity")
+		}
+
+		flags[events.NodeCapacity] = capacity
+	}
+
+	if state.Labels != nil {
+		flags[events.NodeLabels] = copyMap(state.Labels)
+	}
+
+	if state.Annotations != nil {
+		flags[events.NodeAnnotations] = copyMap(state.Annotations)
+	}
+
+	if state.Taints != nil {
+		flags[events.NodeTaints] = translateTaints(state.Taints)
 	}
 
 	// Check if the node should fail
@@ -40,42 +123,77 @@ func SetNodeFlags(st *store.Store, state *nodeconfigv1.NodeConfigurationState) {
 		flags[events.NodePingResponse] = scenario.ResponseTimeout
 	}
 
-	(*st).SetNodeFlags(flags)
+	return flags, nil
 }
 
-func setCustomFlags(flags store.Flags, state *nodeconfigv1.NodeConfigurationCustomState) {
+func setCustomFlags(flags store.Flags, state *nodeconfigv1.NodeConfigurationCustomState) error {
 	// Check if there were no custom flags
 	if state == nil {
-		return
+		return nil
 	}
 
-	if !isResponseUnset(state.CreatePodResponse) {
-		flags[events.NodeCreatePodResponse] = translateResponse(state.CreatePodResponse)
+	responses := []struct {
+		flag     events.NodeEventFlag
+		response nodeconfigv1.NodeResponse
+	}{
+		{events.NodeCreatePodResponse, state.CreatePodResponse},
+		{events.NodeUpdatePodResponse, state.UpdatePodResponse},
+		{events.NodeDeletePodResponse, state.DeletePodResponse},
+		{events.NodeGetPodResponse, state.GetPodResponse},
+		{events.NodeGetPodStatusResponse, state.GetPodStatusResponse},
+		{events.NodeGetPodsResponse, state.GetPodsResponse},
+		{events.NodePingResponse, state.NodePingResponse},
 	}
 
-	if !isResponseUnset(state.UpdatePodResponse) {
-		flags[events.NodeUpdatePodResponse] = translateResponse(state.UpdatePodResponse)
+	for _, r := range responses {
+		if err := setResponseFlag(flags, r.flag, r.response); err != nil {
+			return errors.Wrapf(err, "failed to translate response for flag %v", r.flag)
+		}
 	}
 
-	if !isResponseUnset(state.DeletePodResponse) {
-		flags[events.NodeDeletePodResponse] = translateResponse(state.DeletePodResponse)
+	latencies := []struct {
+		flag    events.NodeEventFlag
+		latency *nodeconfigv1.Latency
+	}{
+		{events.NodeCreatePodLatency, state.CreatePodLatency},
+		{events.NodeUpdatePodLatency, state.UpdatePodLatency},
+		{events.NodeDeletePodLatency, state.DeletePodLatency},
+		{events.NodeGetPodLatency, state.GetPodLatency},
+		{events.NodeGetPodStatusLatency, state.GetPodStatusLatency},
+		{events.NodeGetPodsLatency, state.GetPodsLatency},
+		{events.NodePingLatency, state.NodePingLatency},
 	}
 
-	if !isResponseUnset(state.GetPodResponse) {
-		flags[events.NodeGetPodResponse] = translateResponse(state.GetPodResponse)
-	}
+	for _, l := range latencies {
+		if l.latency == nil {
+			continue
+		}
 
-	if !isResponseUnset(state.GetPodStatusResponse) {
-		flags[events.NodeGetPodStatusResponse] = translateResponse(state.GetPodStatusResponse)
-	}
+		distribution, err := TranslateLatency(l.latency)
+		if err != nil {
+			return errors.Wrapf(err, "failed to translate latency for flag %v", l.flag)
+		}
 
-	if !isResponseUnset(state.GetPodsResponse) {
-		flags[events.NodeGetPodsResponse] = translateResponse(state.GetPodsResponse)
+		flags[l.flag] = distribution
 	}
 
-	if !isResponseUnset(state.NodePingResponse) {
-		flags[events.NodePingResponse] = translateResponse(state.NodePingResponse)
+	return nil
+}
+
+// setResponseFlag sets the flag to the given response, or to a distribution if the response is a weighted mix
+func setResponseFlag(flags store.Flags, flag events.NodeEventFlag, response nodeconfigv1.NodeResponse) error {
+	if scenario.IsResponseDistribution(string(response)) {
+		distribution, err := scenario.ParseResponseDistribution(string(response))
+		if err != nil {
+			return errors.Wrap(err, "failed to parse weighted response")
+		}
+
+		flags[flag] = distribution
+	} else if !isResponseUnset(response) {
+		flags[flag] = translateResponse(response)
 	}
+
+	return nil
 }
 
 func isResponseUnset(response nodeconfigv1.NodeResponse) bool {
@@ -96,3 +214,167 @@ func translateResponse(input nodeconfigv1.NodeResponse) scenario.Response {
 		return scenario.ResponseUnset
 	}
 }
+
+// TranslateLatency translates a latency into a distribution which can be sampled
+// Pod configurations use the same latency type, so they are translated by this function as well
+func TranslateLatency(latency *nodeconfigv1.Latency) (scenario.LatencyDistribution, error) {
+	spec := scenario.LatencySpec{
+		Distribution: translateDistribution(latency.Distribution),
+		Value:        latency.Value,
+		Min:          latency.Min,
+		Max:          latency.Max,
+		Mean:         latency.Mean,
+		StdDev:       latency.StdDev,
+	}
+
+	for _, p := range latency.Percentiles {
+		spec.Percentiles = append(spec.Percentiles, scenario.LatencyPercentileSpec{
+			Percentile: p.Percentile,
+			Latency:    p.Latency,
+		})
+	}
+
+	distribution, err := scenario.ParseLatencyDistribution(spec)
+	return distribution, errors.Wrap(err, "failed to parse latency distribution")
+}
+
+func translateDistribution(distribution nodeconfigv1.LatencyDistribution) scenario.DistributionKind {
+	switch distribution {
+	case nodeconfigv1.LatencyConstant:
+		return scenario.DistributionConstant
+	case nodeconfigv1.LatencyUniform:
+		return scenario.DistributionUniform
+	case nodeconfigv1.LatencyNormal:
+		return scenario.DistributionNormal
+	case nodeconfigv1.LatencyExponential:
+		return scenario.DistributionExponential
+	case nodeconfigv1.LatencyLogNormal:
+		return scenario.DistributionLogNormal
+	case nodeconfigv1.LatencyEmpirical:
+		return scenario.DistributionEmpirical
+	default:
+		return scenario.DistributionUnset
+	}
+}
+
+func translateImages(images *nodeconfigv1.NodeImages) (*scenario.NodeImages, error) {
+	translated := &scenario.NodeImages{Cached: images.Cached}
+
+	if images.Bandwidth != "" {
+		bandwidth, err := scenario.GetInBytes(images.Bandwidth, "bandwidth")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate bandwidth")
+		}
+		translated.Bandwidth = uint64(bandwidth)
+	}
+
+	if images.DefaultSize != "" {
+		size, err := scenario.GetInBytes(images.DefaultSize, "default size")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate default size")
+		}
+		translated.DefaultSize = uint64(size)
+	}
+
+	return translated, nil
+}
+
+func copyMap(input map[string]string) map[string]string {
+	copied := make(map[string]string, len(input))
+	for k, v := range input {
+		copied[k] = v
+	}
+
+	return copied
+}
+
+func translateTaints(input []nodeconfigv1.Taint) []corev1.Taint {
+	taints := make([]corev1.Taint, 0, len(input))
+	for _, taint := range input {
+		taints = append(taints, corev1.Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: corev1.TaintEffect(taint.Effect),
+		})
+	}
+
+	return taints
+}
+
+func translateCapacity(capacity *nodeconfigv1.NodeCapacity) (*scenario.NodeCapacity, error) {
+	translated := &scenario.NodeCapacity{
+		CPU:     capacity.CPU,
+		MaxPods: capacity.MaxPods,
+	}
+
+	bytes := []struct {
+		name   string
+		value  string
+		target **int64
+	}{
+		{"memory", capacity.Memory, &translated.Memory},
+		{"storage", capacity.Storage, &translated.Storage},
+		{"ephemeral storage", capacity.EphemeralStorage, &translated.EphemeralStorage},
+	}
+
+	for _, b := range bytes {
+		if b.value == "" {
+			continue
+		}
+
+		value, err := scenario.GetInBytes(b.value, b.name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate %v", b.name)
+		}
+		*b.target = &value
+	}
+
+	return translated, nil
+}
+
+func translateConditions(conditions *nodeconfigv1.NodeConditions) (*scenario.NodeConditions, error) {
+	spec := scenario.NodeConditionsSpec{
+		MemoryPressure: conditions.MemoryPressure,
+		DiskPressure:   conditions.DiskPressure,
+		OutOfDisk:      conditions.OutOfDisk,
+		PIDPressure:    conditions.PIDPressure,
+		MaxPIDs:        conditions.MaxPIDs,
+	}
+
+	for _, forced := range conditions.Forced {
+		spec.Forced = append(spec.Forced, scenario.ForcedCondition{
+			Type:    forced.Type,
+			Status:  forced.Status,
+			Reason:  forced.Reason,
+			Message: forced.Message,
+		})
+	}
+
+	return scenario.ParseNodeConditions(spec)
+}
+
+// translateStartup translates the durations of the startup phases, unset phases are left nil
+func translateStartup(startup *nodeconfigv1.PodStartup) (*scenario.StartupPhases, error) {
+	phases := &scenario.StartupPhases{}
+	for _, phase := range []struct {
+		name         string
+		latency      *nodeconfigv1.Latency
+		distribution *scenario.LatencyDistribution
+	}{
+		{"scheduled", startup.Scheduled, &phases.Scheduled},
+		{"image pull", startup.ImagePull, &phases.ImagePull},
+		{"container creating", startup.ContainerCreating, &phases.ContainerCreating},
+	} {
+		if phase.latency == nil {
+			continue
+		}
+
+		distribution, err := TranslateLatency(phase.latency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate %v phase", phase.name)
+		}
+		*phase.distribution = distribution
+	}
+
+	return phases, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/scenario/events"
@@ -36,7 +37,7 @@ func TestSetNodeFlagsUnsetDirect(t *testing.T) {
 
 	ms.EXPECT().SetNodeFlags(store.Flags{})
 
-	SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
 		NetworkLatency: "unset", // default in types.go
 		CustomState: &nodeconfigv1.NodeConfigurationCustomState{
 			CreatePodResponse:    nodeconfigv1.ResponseUnset,
@@ -47,7 +48,7 @@ func TestSetNodeFlagsUnsetDirect(t *testing.T) {
 			GetPodStatusResponse: nodeconfigv1.ResponseUnset,
 			NodePingResponse:     nodeconfigv1.ResponseUnset,
 		},
-	})
+	}))
 }
 
 func TestSetNodeFlagsDirect(t *testing.T) {
@@ -70,7 +71,7 @@ func TestSetNodeFlagsDirect(t *testing.T) {
 		events.NodePingResponse:         translateResponse(nodeconfigv1.ResponseNormal),
 	})
 
-	SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
 		NetworkLatency: "unset", // default in types.go
 		CustomState: &nodeconfigv1.NodeConfigurationCustomState{
 			CreatePodResponse:    nodeconfigv1.ResponseNormal,
@@ -81,7 +82,7 @@ func TestSetNodeFlagsDirect(t *testing.T) {
 			GetPodStatusResponse: nodeconfigv1.ResponseNormal,
 			NodePingResponse:     nodeconfigv1.ResponseNormal,
 		},
-	})
+	}))
 }
 
 func TestSetNodeFlagsHeartbeat(t *testing.T) {
@@ -98,10 +99,10 @@ func TestSetNodeFlagsHeartbeat(t *testing.T) {
 		events.NodePingResponse: translateResponse(nodeconfigv1.ResponseTimeout),
 	})
 
-	SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
 		NetworkLatency:  "unset", // default in types.go
 		HeartbeatFailed: true,
-	})
+	}))
 }
 
 func TestSetNodeFlagsLatency(t *testing.T) {
@@ -116,13 +117,13 @@ func TestSetNodeFlagsLatency(t *testing.T) {
 
 	ms.EXPECT().SetNodeFlags(store.Flags{
 		events.NodePingResponse: translateResponse(nodeconfigv1.ResponseTimeout),
-		events.NodeAddedLatency: 100 * time.Millisecond,
+		events.NodeAddedLatency: scenario.ConstantLatency(100 * time.Millisecond),
 	})
 
-	SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
 		HeartbeatFailed: true,
 		NetworkLatency:  "100ms",
-	})
+	}))
 }
 
 func TestSetNodeFlagsNodeFailure(t *testing.T) {
@@ -136,7 +137,7 @@ func TestSetNodeFlagsNodeFailure(t *testing.T) {
 	var s store.Store = ms
 
 	ms.EXPECT().SetNodeFlags(store.Flags{
-		events.NodeAddedLatency:         100 * time.Millisecond,
+		events.NodeAddedLatency:         scenario.ConstantLatency(100 * time.Millisecond),
 		events.NodeCreatePodResponse:    translateResponse(nodeconfigv1.ResponseTimeout),
 		events.NodeUpdatePodResponse:    translateResponse(nodeconfigv1.ResponseTimeout),
 		events.NodeDeletePodResponse:    translateResponse(nodeconfigv1.ResponseTimeout),
@@ -146,9 +147,279 @@ func TestSetNodeFlagsNodeFailure(t *testing.T) {
 		events.NodePingResponse:         translateResponse(nodeconfigv1.ResponseTimeout),
 	})
 
-	SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
 		HeartbeatFailed: false,
 		NetworkLatency:  "100ms",
 		NodeFailed:      true,
+	}))
+}
+
+func TestSetNodeFlagsResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ms.EXPECT().SetNodeFlags(store.Flags{
+		events.NodeCreatePodResponse: scenario.ResponseDistribution{
+			{Response: scenario.ResponseError, Probability: 0.05},
+			{Response: scenario.ResponseTimeout, Probability: 0.02},
+		},
+		events.NodeGetPodResponse: scenario.ResponseError,
+	})
+
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+		NetworkLatency: "unset", // default in types.go
+		CustomState: &nodeconfigv1.NodeConfigurationCustomState{
+			CreatePodResponse: "ERROR=5%,TIMEOUT=2%",
+			GetPodResponse:    nodeconfigv1.ResponseError,
+		},
+	}))
+}
+
+func TestSetNodeFlagsInvalidResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	assert.Error(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+		NetworkLatency: "unset", // default in types.go
+		CustomState: &nodeconfigv1.NodeConfigurationCustomState{
+			CreatePodResponse: "ERROR=60%,TIMEOUT=50%",
+		},
+	}))
+}
+
+func TestSetNodeFlagsLatencyDistribution(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ms.EXPECT().SetNodeFlags(store.Flags{
+		events.NodeAddedLatency: scenario.UniformLatency{Min: 10 * time.Millisecond, Max: 20 * time.Millisecond},
+	})
+
+	// The distribution takes precedence over the network latency
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+		NetworkLatency: "100ms",
+		Latency: &nodeconfigv1.Latency{
+			Distribution: nodeconfigv1.LatencyUniform,
+			Min:          "10ms",
+			Max:          "20ms",
+		},
+	}))
+}
+
+func TestSetNodeFlagsPodStartup(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		PodStartup: &nodeconfigv1.PodStartup{
+			ImagePull: &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "5s"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.NodePodStartup: &scenario.StartupPhases{ImagePull: scenario.ConstantLatency(5 * time.Second)},
+	}, flags)
+
+	_, err = TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		PodStartup: &nodeconfigv1.PodStartup{
+			Scheduled: &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "-5s"},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetNodeFlagsImages(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Images: &nodeconfigv1.NodeImages{
+			Bandwidth:   "10M",
+			DefaultSize: "1G",
+			Cached:      []string{"nginx:1.19"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.NodeImages: &scenario.NodeImages{
+			Bandwidth:   10 * 1024 * 1024,
+			DefaultSize: 1024 * 1024 * 1024,
+			Cached:      []string{"nginx:1.19"},
+		},
+	}, flags)
+
+	_, err = TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Images: &nodeconfigv1.NodeImages{Bandwidth: "fast"},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetNodeFlagsConditions(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Conditions: &nodeconfigv1.NodeConditions{
+			MemoryPressure: "0.5",
+			MaxPIDs:        1000,
+			Forced: []nodeconfigv1.ForcedNodeCondition{
+				{Type: "KernelDeadlock", Status: "True", Reason: "DockerHung"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	expected := scenario.DefaultNodeConditions()
+	expected.MemoryPressure = 0.5
+	expected.MaxPIDs = 1000
+	expected.Forced = []scenario.ForcedCondition{{Type: "KernelDeadlock", Status: "True", Reason: "DockerHung"}}
+	assert.Equal(t, store.Flags{events.NodeConditions: expected}, flags)
+
+	_, err = TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Conditions: &nodeconfigv1.NodeConditions{DiskPressure: "2"},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetNodeFlagsCapacity(t *testing.T) {
+	t.Parallel()
+
+	pods := int64(20)
+	flags, err := TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Capacity: &nodeconfigv1.NodeCapacity{
+			Memory:  "2G",
+			MaxPods: &pods,
+		},
+	})
+	assert.NoError(t, err)
+
+	memory := int64(2 * 1024 * 1024 * 1024)
+	assert.Equal(t, store.Flags{
+		events.NodeCapacity: &scenario.NodeCapacity{
+			Memory:  &memory,
+			MaxPods: &pods,
+		},
+	}, flags)
+
+	_, err = TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Capacity: &nodeconfigv1.NodeCapacity{Storage: "lots"},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetNodeFlagsMetadata(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{
+		Labels:      map[string]string{"pool": "gpu"},
+		Annotations: map[string]string{"team": "infra"},
+		Taints: []nodeconfigv1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: "NoExecute"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.NodeLabels:      map[string]string{"pool": "gpu"},
+		events.NodeAnnotations: map[string]string{"team": "infra"},
+		events.NodeTaints: []corev1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+		},
+	}, flags)
+
+	// Empty taints remove the taints set by earlier states
+	flags, err = TranslateNodeFlags(&nodeconfigv1.NodeConfigurationState{Taints: []nodeconfigv1.Taint{}})
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{events.NodeTaints: []corev1.Taint{}}, flags)
+}
+
+func TestTranslateLatency(t *testing.T) {
+	t.Parallel()
+
+	latency, err := TranslateLatency(&nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "5ms"})
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ConstantLatency(5*time.Millisecond), latency)
+
+	latency, err = TranslateLatency(&nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyNormal, Mean: "5ms", StdDev: "1ms"})
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.NormalLatency{Mean: 5 * time.Millisecond, StdDev: time.Millisecond}, latency)
+
+	latency, err = TranslateLatency(&nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyExponential, Mean: "5ms"})
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ExponentialLatency{Mean: 5 * time.Millisecond}, latency)
+
+	latency, err = TranslateLatency(&nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyLogNormal, Mean: "5ms", StdDev: "1ms"})
+	assert.NoError(t, err)
+	assert.IsType(t, scenario.LogNormalLatency{}, latency)
+
+	latency, err = TranslateLatency(&nodeconfigv1.Latency{
+		Distribution: nodeconfigv1.LatencyEmpirical,
+		Percentiles: []nodeconfigv1.LatencyPercentile{
+			{Percentile: "99.9", Latency: "1s"},
+			{Percentile: "50", Latency: "10ms"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.EmpiricalLatency{
+		{Percentile: 50, Latency: 10 * time.Millisecond},
+		{Percentile: 99.9, Latency: time.Second},
+	}, latency)
+}
+
+func TestTranslateLatencyInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []*nodeconfigv1.Latency{
+		{Distribution: nodeconfigv1.LatencyConstant, Value: "fast"},
+		{Distribution: nodeconfigv1.LatencyConstant, Value: "-5ms"},
+		{Distribution: nodeconfigv1.LatencyUniform, Min: "20ms", Max: "10ms"},
+		{Distribution: nodeconfigv1.LatencyNormal, Mean: "5ms"},
+		{Distribution: nodeconfigv1.LatencyEmpirical},
+		{Distribution: "PARETO"},
+	}
+
+	for _, latency := range invalid {
+		_, err := TranslateLatency(latency)
+		assert.Error(t, err, latency)
+	}
+}
+
+func TestSetNodeFlagsOperationLatency(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ms.EXPECT().SetNodeFlags(store.Flags{
+		events.NodeCreatePodLatency: scenario.ExponentialLatency{Mean: 20 * time.Millisecond},
+		events.NodePingLatency:      scenario.ConstantLatency(time.Second),
 	})
+
+	assert.NoError(t, SetNodeFlags(&s, &nodeconfigv1.NodeConfigurationState{
+		NetworkLatency: "unset", // default in types.go
+		CustomState: &nodeconfigv1.NodeConfigurationCustomState{
+			CreatePodLatency: &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyExponential, Mean: "20ms"},
+			NodePingLatency:  &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "1s"},
+		},
+	}))
 }
@@ -2,14 +2,20 @@ package pod
 
 import (
 	"log"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/atlarge-research/apate/internal/crd/pod"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/kubernetes/kubeconfig"
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/services/apatelet/crd/node"
 	"github.com/atlarge-research/apate/services/apatelet/store"
 )
 
@@ -25,23 +31,34 @@ func CreatePodInformer(config *kubeconfig.KubeConfig, st *store.Store, stopch <-
 		return errors.Wrap(err, "failed to get podclient from rest config for pod informer")
 	}
 
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to get clientset from rest config for pod informer")
+	}
+
+	getConfigMap := func(namespace, name string) (map[string]string, error) {
+		configMap, err := clientSet.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get config map %v/%v", namespace, name)
+		}
+		return configMap.Data, nil
+	}
+
 	podClient.WatchResources(func(obj interface{}) {
 		// Add function
 		podCfg := obj.(*podconfigv1.PodConfiguration)
-		err := setPodTasks(podCfg, st)
-		if err != nil {
-			log.Printf("error while adding pod tasks: %v\n", err)
-		}
+		handlePodConfiguration(podCfg, st, getConfigMap)
 
 		wakeScheduler()
-	}, func(_, obj interface{}) {
+	}, func(oldObj, obj interface{}) {
 		// Update function
 		podCfg := obj.(*podconfigv1.PodConfiguration)
 
-		err := setPodTasks(podCfg, st) // just replace all tasks with the <namespace>/<name>
-		if err != nil {
-			log.Printf("error while adding pod tasks: %v\n", err)
+		if pod.IsStatusUpdate(oldObj.(*podconfigv1.PodConfiguration), podCfg) {
+			// Status updates are done by the control plane and do not influence the tasks
+			return
 		}
+		handlePodConfiguration(podCfg, st, getConfigMap) // just replace all tasks with the <namespace>/<name>
 
 		wakeScheduler()
 	}, func(obj interface{}) {
@@ -49,6 +66,8 @@ func CreatePodInformer(config *kubeconfig.KubeConfig, st *store.Store, stopch <-
 		podCfg := obj.(*podconfigv1.PodConfiguration)
 
 		crdLabel := getCrdLabel(podCfg)
+		(*st).SetPodSelector(crdLabel, nil)
+		(*st).SetPodTrace(crdLabel, nil)
 		err := (*st).RemovePodTasks(crdLabel)
 		if err != nil {
 			log.Printf("error while removing pod tasks: %v\n", err)
@@ -58,22 +77,106 @@ func CreatePodInformer(config *kubeconfig.KubeConfig, st *store.Store, stopch <-
 	return nil
 }
 
-func setPodTasks(podCfg *podconfigv1.PodConfiguration, st *store.Store) error {
-	// Validating timestamps before actually doing anything
+// handlePodConfiguration sets the tasks of the given pod configuration and records the result in the store,
+// so it can be reported back to the control plane
+func handlePodConfiguration(podCfg *podconfigv1.PodConfiguration, st *store.Store, getConfigMap configMapGetter) {
+	err := setPodTasks(podCfg, st, getConfigMap)
+	if err != nil {
+		log.Printf("error while adding pod tasks: %v\n", err)
+	}
+
+	(*st).SetPodConfigurationError(getCrdLabel(podCfg), err)
+}
+
+// configMapGetter returns the data of the ConfigMap with the given namespace and name
+type configMapGetter func(string, string) (map[string]string, error)
+
+func setPodTasks(podCfg *podconfigv1.PodConfiguration, st *store.Store, getConfigMap configMapGetter) error {
+	// Validating timestamps, recurrences, windows, targets, jitter and states before actually doing anything
+	var states = make([]*podconfigv1.PodConfigurationState, len(podCfg.Spec.Tasks))
+	var flags = make([]store.Flags, len(podCfg.Spec.Tasks))
 	var durations = make([]time.Duration, len(podCfg.Spec.Tasks))
+	var recurrences = make([]*store.Recurrence, len(podCfg.Spec.Tasks))
+	var windows = make([]time.Duration, len(podCfg.Spec.Tasks))
+	var subsets = make([]*store.PodSubset, len(podCfg.Spec.Tasks))
+	var jitters = make([]scenario.LatencyDistribution, len(podCfg.Spec.Tasks))
 	for i, task := range podCfg.Spec.Tasks {
 		duration, err := time.ParseDuration(task.Timestamp)
 		if err != nil {
 			return errors.Wrapf(err, "error while converting timestamp %v to a duration", task.Timestamp)
 		}
 		durations[i] = duration
+
+		if task.Repeat != nil {
+			recurrences[i], err = store.NewRecurrence(task.Repeat.Interval, task.Repeat.Cron, task.Repeat.Count, task.Repeat.Until)
+			if err != nil {
+				return errors.Wrapf(err, "invalid repeat in task at %v", task.Timestamp)
+			}
+		}
+
+		if task.Duration != "" {
+			windows[i], err = time.ParseDuration(task.Duration)
+			if err != nil {
+				return errors.Wrapf(err, "error while converting duration %v of task at %v", task.Duration, task.Timestamp)
+			} else if windows[i] <= 0 {
+				return errors.Errorf("duration %v of task at %v should be positive", task.Duration, task.Timestamp)
+			}
+		}
+
+		if task.Target != nil {
+			subsets[i], err = store.NewPodSubset(task.Target.Seed, task.Target.Percentage, task.Target.Offset)
+			if err != nil {
+				return errors.Wrapf(err, "invalid target in task at %v", task.Timestamp)
+			}
+		}
+
+		if task.Jitter != nil {
+			jitters[i], err = node.TranslateLatency(task.Jitter)
+			if err != nil {
+				return errors.Wrapf(err, "invalid jitter in task at %v", task.Timestamp)
+			}
+
+			// The state of a task which is not relative to the pod is restored on all pods at once,
+			// so pods with a jitter longer than the duration would never see it
+			if longest, bounded := scenario.MaxLatency(jitters[i]); bounded && windows[i] > 0 && !task.RelativeToPod && longest >= windows[i] {
+				return errors.Errorf("jitter of task at %v can be up to %v, which should be shorter than its duration %v", task.Timestamp, longest, windows[i])
+			}
+		}
+
+		task := task
+		states[i], err = loadLogs(podCfg.Namespace, &task.State, getConfigMap)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load logs of task at %v", task.Timestamp)
+		}
+
+		// Only the flags of tasks relative to the pod are kept, other tasks are translated again once executed
+		flags[i], err = TranslatePodFlags(states[i])
+		if err != nil {
+			return errors.Wrapf(err, "invalid state in task at %v", task.Timestamp)
+		}
+	}
+
+	state, err := loadLogs(podCfg.Namespace, &podCfg.Spec.PodConfigurationState, getConfigMap)
+	if err != nil {
+		return errors.Wrap(err, "failed to load logs")
+	}
+
+	trace, err := loadTrace(podCfg, getConfigMap)
+	if err != nil {
+		return errors.Wrap(err, "failed to load trace")
 	}
 
 	crdLabel := getCrdLabel(podCfg)
 
-	empty := podconfigv1.PodConfigurationState{}
-	if podCfg.Spec.PodConfigurationState != empty {
-		if err := SetPodFlags(st, crdLabel, &podCfg.Spec.PodConfigurationState); err != nil {
+	selector, err := translatePodSelector(podCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to translate pod selector")
+	}
+	(*st).SetPodSelector(crdLabel, selector)
+	(*st).SetPodTrace(crdLabel, trace)
+
+	if !reflect.DeepEqual(*state, podconfigv1.PodConfigurationState{}) {
+		if err := SetPodFlags(st, crdLabel, nil, nil, "", state); err != nil {
 			return errors.Wrap(err, "failed to set pod flags during enqueueing of crd")
 		}
 	}
@@ -82,20 +185,33 @@ func setPodTasks(podCfg *podconfigv1.PodConfiguration, st *store.Store) error {
 	var timeFlags []*store.TimeFlags
 
 	for i, task := range podCfg.Spec.Tasks {
-		state := task.State
-
 		if task.RelativeToPod {
-			flags, err := TranslatePodFlags(&state)
-			if err != nil {
-				return errors.Wrap(err, "failed to translate pod state into flags")
+			// Tasks relative to the pod are not scheduled, so their repetitions are expanded up front
+			timestamps := []time.Duration{durations[i]}
+			if recurrences[i] != nil {
+				timestamps, err = recurrences[i].Timestamps(durations[i])
+				if err != nil {
+					return errors.Wrapf(err, "invalid repeat in task at %v relative to the pod", task.Timestamp)
+				}
 			}
 
-			timeFlags = append(timeFlags, &store.TimeFlags{
-				TimeSincePodStart: durations[i],
-				Flags:             flags,
-			})
+			for _, timestamp := range timestamps {
+				timeFlags = append(timeFlags, &store.TimeFlags{
+					TimeSincePodStart: timestamp,
+					Duration:          windows[i],
+					Flags:             flags[i],
+					Index:             i,
+					Subset:            subsets[i],
+					Jitter:            jitters[i],
+				})
+			}
 		} else {
-			tasks = append(tasks, store.NewPodTask(durations[i], crdLabel, &state))
+			podTask := store.NewPodTask(durations[i], crdLabel, i, states[i])
+			podTask.Recurrence = recurrences[i]
+			podTask.Duration = windows[i]
+			podTask.PodTask.Subset = subsets[i]
+			podTask.PodTask.Jitter = jitters[i]
+			tasks = append(tasks, podTask)
 		}
 	}
 
@@ -103,6 +219,97 @@ func setPodTasks(podCfg *podconfigv1.PodConfiguration, st *store.Store) error {
 	return errors.Wrap((*st).SetPodTasks(crdLabel, tasks), "failed to set pod tasks")
 }
 
+// translatePodSelector creates the selector which determines which pods belong to the given pod configuration,
+// or nil if the pod configuration only applies to pods with the apate label
+func translatePodSelector(podCfg *podconfigv1.PodConfiguration) (*store.PodSelector, error) {
+	if podCfg.Spec.Selector == nil && podCfg.Spec.Owner == nil {
+		return nil, nil
+	}
+
+	selector := &store.PodSelector{
+		Namespace: podCfg.Namespace,
+		Priority:  podCfg.Spec.Priority,
+	}
+
+	if podCfg.Spec.Selector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(podCfg.Spec.Selector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid label selector")
+		}
+		selector.Selector = labelSelector
+	}
+
+	if podCfg.Spec.Owner != nil {
+		selector.OwnerKind = podCfg.Spec.Owner.Kind
+		selector.OwnerName = podCfg.Spec.Owner.Name
+	}
+
+	return selector, nil
+}
+
+// loadTrace parses the trace of the given pod configuration, or returns nil if it has none
+func loadTrace(podCfg *podconfigv1.PodConfiguration, getConfigMap configMapGetter) (*store.Trace, error) {
+	trace := podCfg.Spec.Trace
+	if trace == nil {
+		return nil, nil
+	}
+
+	data := trace.Data
+	switch {
+	case trace.Data != "" && trace.ConfigMap != nil:
+		return nil, errors.New("a trace can't have both data and a config map")
+	case trace.ConfigMap != nil:
+		var err error
+		if data, err = readConfigMapKey(podCfg.Namespace, trace.ConfigMap, getConfigMap); err != nil {
+			return nil, errors.Wrap(err, "failed to get trace from config map")
+		}
+	case trace.Data == "":
+		return nil, errors.New("a trace needs data or a config map")
+	}
+
+	parsed, err := store.NewTrace(strings.NewReader(data))
+	return parsed, errors.Wrap(err, "failed to parse trace")
+}
+
+// loadLogs returns a copy of the given state, of which the log lines to replay have been read from their config map if needed
+func loadLogs(namespace string, state *podconfigv1.PodConfigurationState, getConfigMap configMapGetter) (*podconfigv1.PodConfigurationState, error) {
+	loaded := *state
+	if state.Logs == nil || state.Logs.Replay == nil || state.Logs.Replay.ConfigMap == nil || state.Logs.Replay.Data != "" {
+		return &loaded, nil
+	}
+
+	data, err := readConfigMapKey(namespace, state.Logs.Replay.ConfigMap, getConfigMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get logs from config map")
+	}
+
+	// The state belongs to the informer, so it is copied before it is changed
+	logs := *state.Logs
+	logs.Replay = &podconfigv1.LogReplay{Data: data}
+	loaded.Logs = &logs
+
+	return &loaded, nil
+}
+
+// readConfigMapKey returns the data stored under the selected key of a ConfigMap in the given namespace
+func readConfigMapKey(namespace string, selector *podconfigv1.ConfigMapKeySelector, getConfigMap configMapGetter) (string, error) {
+	if getConfigMap == nil {
+		return "", errors.New("config maps are not available")
+	}
+
+	configMap, err := getConfigMap(namespace, selector.Name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get config map %v", selector.Name)
+	}
+
+	data, ok := configMap[selector.Key]
+	if !ok {
+		return "", errors.Errorf("config map %v has no key %v", selector.Name, selector.Key)
+	}
+
+	return data, nil
+}
+
 func getCrdLabel(podCfg *podconfigv1.PodConfiguration) string {
 	crdLabel := podCfg.Namespace + "/" + podCfg.Name
 	return crdLabel
@@ -14,6 +14,7 @@ import (
 
 	"github.com/finitum/node-cli/stats"
 
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/scenario/events"
 	"github.com/atlarge-research/apate/services/apatelet/store"
@@ -47,13 +48,13 @@ func TestEnqueueCRD(t *testing.T) {
 
 	et1 := store.NewPodTask(
 		1*time.Millisecond,
-		"TestNamespace/TestName", &podconfigv1.PodConfigurationState{
+		"TestNamespace/TestName", 0, &podconfigv1.PodConfigurationState{
 			PodStatus: podconfigv1.PodStatusFailed,
 		})
 
 	et2 := store.NewPodTask(
 		42*time.Millisecond,
-		"TestNamespace/TestName", &podconfigv1.PodConfigurationState{
+		"TestNamespace/TestName", 1, &podconfigv1.PodConfigurationState{
 			PodStatus: podconfigv1.PodStatusPending,
 		})
 
@@ -87,6 +88,9 @@ func TestEnqueueCRD(t *testing.T) {
 		},
 	}
 
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
 	ms.EXPECT().SetPodTasks(
 		"TestNamespace/TestName",
 		gomock.Any(),
@@ -106,10 +110,11 @@ func TestEnqueueCRD(t *testing.T) {
 			Flags: store.Flags{
 				events.PodStatus: scenario.PodStatusRunning,
 			},
+			Index: 2,
 		}, arr[0])
 	})
 
-	err := setPodTasks(&ep, &s)
+	err := setPodTasks(&ep, &s, nil)
 	assert.NoError(t, err)
 }
 
@@ -168,6 +173,9 @@ func TestEnqueueCRDDirect(t *testing.T) {
 		assert.Equal(t, translatePodStatus(podconfigv1.PodStatusRunning), flags[events.PodStatus])
 	})
 
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
 	ms.EXPECT().SetPodTasks(
 		"TestNamespace/TestName",
 		gomock.Any(),
@@ -183,6 +191,490 @@ func TestEnqueueCRDDirect(t *testing.T) {
 		assert.Empty(t, arr)
 	})
 
-	err := setPodTasks(&ep, &s)
+	err := setPodTasks(&ep, &s, nil)
+	assert.NoError(t, err)
+}
+
+func TestTranslatePodSelector(t *testing.T) {
+	t.Parallel()
+
+	ep := podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: podconfigv1.PodConfigurationSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+			Owner: &podconfigv1.PodConfigurationOwner{
+				Kind: "Deployment",
+				Name: "web",
+			},
+			Priority: 42,
+		},
+	}
+
+	selector, err := translatePodSelector(&ep)
+	assert.NoError(t, err)
+	assert.Equal(t, "TestNamespace", selector.Namespace)
+	assert.Equal(t, "app=web", selector.Selector.String())
+	assert.Equal(t, "Deployment", selector.OwnerKind)
+	assert.Equal(t, "web", selector.OwnerName)
+	assert.Equal(t, 42, selector.Priority)
+}
+
+func TestTranslatePodSelectorInvalid(t *testing.T) {
+	t.Parallel()
+
+	ep := podconfigv1.PodConfiguration{
+		Spec: podconfigv1.PodConfigurationSpec{
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: "Invalid"},
+				},
+			},
+		},
+	}
+
+	_, err := translatePodSelector(&ep)
+	assert.Error(t, err)
+}
+
+func TestEnqueueCRDRepeat(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1m",
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+					Repeat: &podconfigv1.TaskRepeat{
+						Cron: "0 * * * *",
+					},
+				},
+				{
+					Timestamp:     "1s",
+					RelativeToPod: true,
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusRunning,
+					},
+					Repeat: &podconfigv1.TaskRepeat{
+						Interval: "1s",
+						Count:    3,
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
+	ms.EXPECT().SetPodTasks(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.NotNil(t, arr[0].Recurrence.Schedule)
+	})
+
+	ms.EXPECT().SetPodTimeFlags(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.TimeFlags) {
+		assert.Equal(t, 3, len(arr))
+		for i, flags := range arr {
+			assert.Equal(t, time.Duration(i+1)*time.Second, flags.TimeSincePodStart)
+			assert.Equal(t, 1, flags.Index)
+		}
+	})
+
+	assert.NoError(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestEnqueueCRDRepeatRelativeUnbounded(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := podconfigv1.PodConfiguration{
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp:     "1s",
+					RelativeToPod: true,
+					Repeat: &podconfigv1.TaskRepeat{
+						Interval: "1s",
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetPodSelector(gomock.Any(), gomock.Any())
+	ms.EXPECT().SetPodTrace(gomock.Any(), gomock.Any())
+
+	assert.Error(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestEnqueueCRDInvalidState(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := podconfigv1.PodConfiguration{
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1s",
+					State: podconfigv1.PodConfigurationState{
+						Logs: &podconfigv1.PodLogs{
+							Replay: &podconfigv1.LogReplay{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The state of a task which is not relative to the pod is validated before anything is stored
+	assert.Error(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestEnqueueCRDMissingLogs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	getConfigMap := func(namespace, name string) (map[string]string, error) {
+		return map[string]string{}, nil
+	}
+
+	ep := podconfigv1.PodConfiguration{
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1s",
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusRunning,
+					},
+				},
+				{
+					Timestamp: "2s",
+					State: podconfigv1.PodConfigurationState{
+						Logs: &podconfigv1.PodLogs{
+							Replay: &podconfigv1.LogReplay{
+								ConfigMap: &podconfigv1.ConfigMapKeySelector{Name: "logs", Key: "app.log"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The logs of every task are loaded before anything is stored
+	assert.Error(t, setPodTasks(&ep, &s, getConfigMap))
+}
+
+func TestEnqueueCRDDuration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1m",
+					Duration:  "10s",
+					State: podconfigv1.PodConfigurationState{
+						CreatePodResponse: podconfigv1.ResponseError,
+					},
+				},
+				{
+					Timestamp:     "1s",
+					RelativeToPod: true,
+					Duration:      "2s",
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
+	ms.EXPECT().SetPodTasks(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, 10*time.Second, arr[0].Duration)
+	})
+
+	ms.EXPECT().SetPodTimeFlags(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.TimeFlags) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, 2*time.Second, arr[0].Duration)
+	})
+
+	assert.NoError(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestEnqueueCRDTarget(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	ep := podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1m",
+					Target:    &podconfigv1.TaskTarget{Percentage: 10},
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+				},
+				{
+					Timestamp:     "1s",
+					RelativeToPod: true,
+					Target:        &podconfigv1.TaskTarget{Percentage: 10, Offset: 10, Seed: "crash"},
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
+	ms.EXPECT().SetPodTasks(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		expected, _ := store.NewPodSubset("", 10, 0)
+		assert.Equal(t, expected, arr[0].PodTask.Subset)
+	})
+
+	ms.EXPECT().SetPodTimeFlags(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.TimeFlags) {
+		assert.Equal(t, 1, len(arr))
+		expected, _ := store.NewPodSubset("crash", 10, 10)
+		assert.Equal(t, expected, arr[0].Subset)
+	})
+
+	assert.NoError(t, setPodTasks(&ep, &s, nil))
+
+	// Targets can't select more than all pods
+	ep.Spec.Tasks[1].Target.Offset = 95
+	assert.Error(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestEnqueueCRDJitter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	jitter := &nodeconfigv1.Latency{
+		Distribution: nodeconfigv1.LatencyUniform,
+		Min:          "0s",
+		Max:          "10s",
+	}
+
+	ep := podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{
+					Timestamp: "1m",
+					Jitter:    jitter,
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+				},
+				{
+					Timestamp:     "1s",
+					RelativeToPod: true,
+					Jitter:        jitter,
+					State: podconfigv1.PodConfigurationState{
+						PodStatus: podconfigv1.PodStatusFailed,
+					},
+				},
+			},
+		},
+	}
+
+	ms.EXPECT().SetPodSelector("TestNamespace/TestName", (*store.PodSelector)(nil))
+	ms.EXPECT().SetPodTrace("TestNamespace/TestName", (*store.Trace)(nil))
+
+	expected := scenario.UniformLatency{Min: 0, Max: 10 * time.Second}
+
+	ms.EXPECT().SetPodTasks(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.Task) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, expected, arr[0].PodTask.Jitter)
+	})
+
+	ms.EXPECT().SetPodTimeFlags(
+		"TestNamespace/TestName",
+		gomock.Any(),
+	).Do(func(_ string, arr []*store.TimeFlags) {
+		assert.Equal(t, 1, len(arr))
+		assert.Equal(t, expected, arr[0].Jitter)
+	})
+
+	assert.NoError(t, setPodTasks(&ep, &s, nil))
+
+	// Invalid jitter is rejected before anything is set
+	ep.Spec.Tasks[1].Jitter = &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyUniform, Min: "1s"}
+	assert.Error(t, setPodTasks(&ep, &s, nil))
+
+	// Jitter which can exceed the duration of the task is rejected as well, as some pods would never see the state
+	ep.Spec.Tasks[1].Jitter = jitter
+	ep.Spec.Tasks[0].Duration = "10s"
+	assert.Error(t, setPodTasks(&ep, &s, nil))
+}
+
+func TestLoadTrace(t *testing.T) {
+	t.Parallel()
+
+	podCfg := &podconfigv1.PodConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "TestName",
+			Namespace: "TestNamespace",
+		},
+	}
+
+	// No trace
+	trace, err := loadTrace(podCfg, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, trace)
+
+	// Inline data
+	podCfg.Spec.Trace = &podconfigv1.ResourceTrace{Data: "timestamp,cpu\n0,1\n"}
+	trace, err = loadTrace(podCfg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trace.Pods())
+
+	// Config map
+	getConfigMap := func(namespace, name string) (map[string]string, error) {
+		assert.Equal(t, "TestNamespace", namespace)
+		assert.Equal(t, "traces", name)
+		return map[string]string{"trace.csv": "timestamp,pod,cpu\n0,a,1\n0,b,1\n"}, nil
+	}
+
+	podCfg.Spec.Trace = &podconfigv1.ResourceTrace{ConfigMap: &podconfigv1.ConfigMapKeySelector{Name: "traces", Key: "trace.csv"}}
+	trace, err = loadTrace(podCfg, getConfigMap)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, trace.Pods())
+
+	// Missing key
+	podCfg.Spec.Trace.ConfigMap.Key = "other.csv"
+	_, err = loadTrace(podCfg, getConfigMap)
+	assert.Error(t, err)
+
+	// Both data and a config map
+	podCfg.Spec.Trace.Data = "timestamp,cpu\n0,1\n"
+	_, err = loadTrace(podCfg, getConfigMap)
+	assert.Error(t, err)
+}
+
+func TestLoadLogs(t *testing.T) {
+	t.Parallel()
+
+	getConfigMap := func(namespace, name string) (map[string]string, error) {
+		assert.Equal(t, "TestNamespace", namespace)
+		assert.Equal(t, "logs", name)
+		return map[string]string{"app.log": "a\nb\n"}, nil
+	}
+
+	replay := &podconfigv1.LogReplay{ConfigMap: &podconfigv1.ConfigMapKeySelector{Name: "logs", Key: "app.log"}}
+	state := &podconfigv1.PodConfigurationState{Logs: &podconfigv1.PodLogs{Replay: replay}}
+
+	loaded, err := loadLogs("TestNamespace", state, getConfigMap)
+	assert.NoError(t, err)
+	assert.Equal(t, &podconfigv1.LogReplay{Data: "a\nb\n"}, loaded.Logs.Replay)
+
+	// The original state is left as it is
+	assert.Equal(t, replay, state.Logs.Replay)
+
+	// Missing key
+	replay.ConfigMap.Key = "other.log"
+	_, err = loadLogs("TestNamespace", state, getConfigMap)
+	assert.Error(t, err)
+
+	// States without replayed logs are left as they are
+	state = &podconfigv1.PodConfigurationState{PodStatus: podconfigv1.PodStatusRunning}
+	loaded, err = loadLogs("TestNamespace", state, nil)
 	assert.NoError(t, err)
+	assert.Equal(t, state, loaded)
 }
@@ -8,44 +8,98 @@ import (
 
 	"github.com/pkg/errors"
 
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
 	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/crd/node"
 	"github.com/atlarge-research/apate/services/apatelet/store"
 )
 
 // SetPodFlags sets all flags for a pod.
-func SetPodFlags(st *store.Store, label string, pt *podconfigv1.PodConfigurationState) error {
+// If a subset is given, the flags are only set for the pods in the subset
+// If a jitter is given, every pod sees the flags after a delay sampled from it, which is derived from the jitter key
+func SetPodFlags(st *store.Store, label string, subset *store.PodSubset, jitter scenario.LatencyDistribution, jitterKey string, pt *podconfigv1.PodConfigurationState) error {
 	flags, err := TranslatePodFlags(pt)
 	if err != nil {
 		return errors.Wrap(err, "failed to translate pod state into flags")
 	}
 
-	(*st).SetPodFlags(label, flags)
+	(*st).SetPodFlags(label, partialFlags(flags, subset, jitter, jitterKey))
 
 	return nil
 }
 
+// SetPodWindowFlags sets all flags for a pod in a window, so they are restored once the window is closed
+// If a subset is given, the flags are only set for the pods in the subset
+// If a jitter is given, every pod sees the flags after a delay sampled from it, but they are restored for all pods at once
+func SetPodWindowFlags(st *store.Store, window store.Window, label string, subset *store.PodSubset, jitter scenario.LatencyDistribution, jitterKey string, pt *podconfigv1.PodConfigurationState) error {
+	flags, err := TranslatePodFlags(pt)
+	if err != nil {
+		return errors.Wrap(err, "failed to translate pod state into flags")
+	}
+
+	(*st).SetPodWindowFlags(window, label, partialFlags(flags, subset, jitter, jitterKey))
+
+	return nil
+}
+
+// partialFlags limits the given flags to the pods in the subset and delays them by the jitter, if any
+func partialFlags(flags store.Flags, subset *store.PodSubset, jitter scenario.LatencyDistribution, jitterKey string) store.Flags {
+	if subset == nil && jitter == nil {
+		return flags
+	}
+
+	result := make(store.Flags, len(flags))
+	for flag, value := range flags {
+		result[flag] = &store.PartialValue{Subset: subset, Jitter: jitter, JitterKey: jitterKey, Value: value}
+	}
+
+	return result
+}
+
 // TranslatePodFlags translates a pod configuration into a map of flags.
 func TranslatePodFlags(pt *podconfigv1.PodConfigurationState) (store.Flags, error) {
 	flags := make(store.Flags)
-	if !isResponseUnset(pt.CreatePodResponse) {
-		flags[events.PodCreatePodResponse] = translateResponse(pt.CreatePodResponse)
-	}
 
-	if !isResponseUnset(pt.UpdatePodResponse) {
-		flags[events.PodUpdatePodResponse] = translateResponse(pt.UpdatePodResponse)
+	responses := []struct {
+		flag     events.PodEventFlag
+		response podconfigv1.PodResponse
+	}{
+		{events.PodCreatePodResponse, pt.CreatePodResponse},
+		{events.PodUpdatePodResponse, pt.UpdatePodResponse},
+		{events.PodDeletePodResponse, pt.DeletePodResponse},
+		{events.PodGetPodResponse, pt.GetPodResponse},
+		{events.PodGetPodStatusResponse, pt.GetPodStatusResponse},
 	}
 
-	if !isResponseUnset(pt.DeletePodResponse) {
-		flags[events.PodDeletePodResponse] = translateResponse(pt.DeletePodResponse)
+	for _, r := range responses {
+		if err := setResponseFlag(flags, r.flag, r.response); err != nil {
+			return nil, errors.Wrapf(err, "failed to translate response for flag %v", r.flag)
+		}
 	}
 
-	if !isResponseUnset(pt.GetPodResponse) {
-		flags[events.PodGetPodResponse] = translateResponse(pt.GetPodResponse)
+	latencies := []struct {
+		flag    events.PodEventFlag
+		latency *nodeconfigv1.Latency
+	}{
+		{events.PodCreatePodLatency, pt.CreatePodLatency},
+		{events.PodUpdatePodLatency, pt.UpdatePodLatency},
+		{events.PodDeletePodLatency, pt.DeletePodLatency},
+		{events.PodGetPodLatency, pt.GetPodLatency},
+		{events.PodGetPodStatusLatency, pt.GetPodStatusLatency},
 	}
 
-	if !isResponseUnset(pt.GetPodStatusResponse) {
-		flags[events.PodGetPodStatusResponse] = translateResponse(pt.GetPodStatusResponse)
+	for _, l := range latencies {
+		if l.latency == nil {
+			continue
+		}
+
+		distribution, err := node.TranslateLatency(l.latency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate latency for flag %v", l.flag)
+		}
+
+		flags[l.flag] = distribution
 	}
 
 	if pt.PodResources != nil {
@@ -54,15 +108,125 @@ func TranslatePodFlags(pt *podconfigv1.PodConfigurationState) (store.Flags, erro
 			return nil, errors.Wrap(err, "failed to translate pod resources")
 		}
 		flags[events.PodResources] = resources
+
+		if curve := pt.PodResources.Curve; curve != nil {
+			usageCurve, err := scenario.ParseUsageCurve(scenario.UsageCurveSpec{
+				Shape:     curve.Shape,
+				Ramp:      curve.Ramp,
+				Period:    curve.Period,
+				Amplitude: curve.Amplitude,
+				Interval:  curve.Interval,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to translate pod resources curve")
+			}
+
+			flags[events.PodResources] = &scenario.ResourceCurve{
+				Usage: resources,
+				Curve: usageCurve,
+			}
+		}
 	}
 
 	if !isPodStatusUnset(pt.PodStatus) {
 		flags[events.PodStatus] = translatePodStatus(pt.PodStatus)
 	}
 
+	if pt.Crash != nil {
+		crash, err := scenario.ParseCrashModel(scenario.CrashModelSpec{
+			Probability: pt.Crash.Probability,
+			Interval:    pt.Crash.Interval,
+			After:       pt.Crash.After,
+			ExitCode:    pt.Crash.ExitCode,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate crash")
+		}
+		flags[events.PodCrash] = crash
+	}
+
+	if pt.Containers != nil {
+		containers, err := translateContainerStates(pt.Containers)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate container states")
+		}
+		flags[events.PodContainers] = containers
+	}
+
+	if pt.Probes != nil {
+		flags[events.PodProbes] = scenario.Probes{
+			Readiness: translateProbeResult(pt.Probes.Readiness),
+			Liveness:  translateProbeResult(pt.Probes.Liveness),
+		}
+	}
+
+	if pt.Startup != nil {
+		startup, err := translateStartup(pt.Startup)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate startup")
+		}
+		flags[events.PodStartup] = startup
+	}
+
+	if pt.ImagePull != nil {
+		imagePull := &scenario.PodImagePull{Fail: pt.ImagePull.Fail}
+		if pt.ImagePull.Size != "" {
+			size, err := scenario.GetInBytes(pt.ImagePull.Size, "image size")
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to translate image size")
+			}
+			imagePull.Size = uint64(size)
+		}
+		flags[events.PodImagePull] = imagePull
+	}
+
+	if pt.Runtime != nil {
+		runtime, err := translateRuntime(pt.Runtime)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate runtime")
+		}
+		flags[events.PodRuntime] = runtime
+	}
+
+	if pt.Logs != nil {
+		logs, err := translateLogs(pt.Logs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate logs")
+		}
+		flags[events.PodLogs] = logs
+	}
+
+	if pt.Exec != nil {
+		rules, err := translateExecRules(pt.Exec)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate exec rules")
+		}
+		flags[events.PodExec] = rules
+	}
+
+	if pt.Processes != nil {
+		flags[events.PodProcesses] = *pt.Processes
+	}
+
 	return flags, nil
 }
 
+// setResponseFlag sets the flag to the given response, or to a distribution if the response is a weighted mix
+func setResponseFlag(flags store.Flags, flag events.PodEventFlag, response podconfigv1.PodResponse) error {
+	if scenario.IsResponseDistribution(string(response)) {
+		distribution, err := scenario.ParseResponseDistribution(string(response))
+		if err != nil {
+			return errors.Wrap(err, "failed to parse weighted response")
+		}
+
+		flags[flag] = distribution
+	} else if !isResponseUnset(response) {
+		flags[flag] = translateResponse(response)
+	}
+
+	return nil
+}
+
 func isResponseUnset(response podconfigv1.PodResponse) bool {
 	return response != podconfigv1.ResponseError && response != podconfigv1.ResponseNormal && response != podconfigv1.ResponseTimeout
 }
@@ -105,6 +269,161 @@ func translatePodStatus(input podconfigv1.PodStatus) scenario.PodStatus {
 	}
 }
 
+// translateContainerStates translates the states of individual containers, which should have unique names
+func translateContainerStates(input []podconfigv1.ContainerState) (scenario.ContainerStates, error) {
+	containers := make(scenario.ContainerStates, len(input))
+	for _, c := range input {
+		if _, ok := containers[c.Name]; ok {
+			return nil, errors.Errorf("container %v occurs more than once", c.Name)
+		}
+
+		state := &scenario.ContainerState{
+			Status:   translateContainerStatus(c.Status),
+			Ready:    c.Ready == nil || *c.Ready,
+			ExitCode: c.ExitCode,
+			Reason:   c.Reason,
+		}
+
+		if c.Resources != nil {
+			if c.Resources.Curve != nil {
+				return nil, errors.Errorf("container %v can't have a resource curve", c.Name)
+			}
+
+			resources, err := translatePodResources(c.Resources)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to translate resources of container %v", c.Name)
+			}
+			state.Resources = resources
+		}
+
+		containers[c.Name] = state
+	}
+
+	return containers, nil
+}
+
+func translateContainerStatus(input podconfigv1.ContainerStatus) scenario.ContainerStatus {
+	switch input {
+	case podconfigv1.ContainerStatusRunning:
+		return scenario.ContainerStatusRunning
+	case podconfigv1.ContainerStatusWaiting:
+		return scenario.ContainerStatusWaiting
+	case podconfigv1.ContainerStatusTerminated:
+		return scenario.ContainerStatusTerminated
+	default:
+		return scenario.ContainerStatusUnset
+	}
+}
+
+// translateStartup translates the durations of the startup phases, unset phases are left nil
+func translateStartup(startup *podconfigv1.PodStartup) (*scenario.StartupPhases, error) {
+	phases := &scenario.StartupPhases{}
+	for _, phase := range []struct {
+		name         string
+		latency      *nodeconfigv1.Latency
+		distribution *scenario.LatencyDistribution
+	}{
+		{"scheduled", startup.Scheduled, &phases.Scheduled},
+		{"image pull", startup.ImagePull, &phases.ImagePull},
+		{"container creating", startup.ContainerCreating, &phases.ContainerCreating},
+	} {
+		if phase.latency == nil {
+			continue
+		}
+
+		distribution, err := node.TranslateLatency(phase.latency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate %v phase", phase.name)
+		}
+		*phase.distribution = distribution
+	}
+
+	return phases, nil
+}
+
+func translateRuntime(runtime *podconfigv1.PodRuntime) (*scenario.RuntimeModel, error) {
+	spec := scenario.RuntimeModelSpec{
+		Annotation:         runtime.Annotation,
+		FailureProbability: runtime.FailureProbability,
+		ExitCode:           runtime.ExitCode,
+	}
+
+	if runtime.Latency != nil {
+		distribution, err := node.TranslateLatency(runtime.Latency)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to translate runtime latency")
+		}
+		spec.Runtime = distribution
+	}
+
+	return scenario.ParseRuntimeModel(spec)
+}
+
+// translateLogs translates the synthetic logs, of which the lines to replay should have been read from their config map already
+func translateLogs(logs *podconfigv1.PodLogs) (*scenario.LogModel, error) {
+	spec := scenario.LogModelSpec{
+		Template:        logs.Template,
+		Rate:            logs.Rate,
+		FailureBurst:    logs.FailureBurst,
+		FailureTemplate: logs.FailureTemplate,
+	}
+
+	if replay := logs.Replay; replay != nil {
+		switch {
+		case replay.Data != "" && replay.ConfigMap != nil:
+			return nil, errors.New("replayed logs can't have both data and a config map")
+		case replay.ConfigMap != nil:
+			return nil, errors.New("replayed logs from a config map are only available in pod configurations")
+		case replay.Data == "":
+			return nil, errors.New("replayed logs need data or a config map")
+		}
+
+		spec.Lines = scenario.SplitLogLines(replay.Data)
+	}
+
+	return scenario.ParseLogModel(spec)
+}
+
+func translateExecRules(input []podconfigv1.ExecRule) (scenario.ExecRules, error) {
+	rules := make(scenario.ExecRules, 0, len(input))
+	for _, rule := range input {
+		spec := scenario.ExecRuleSpec{
+			Command:   rule.Command,
+			Container: rule.Container,
+			Stdout:    rule.Stdout,
+			Stderr:    rule.Stderr,
+			ExitCode:  rule.ExitCode,
+		}
+
+		if rule.Delay != nil {
+			delay, err := node.TranslateLatency(rule.Delay)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to translate delay of command %v", rule.Command)
+			}
+			spec.Delay = delay
+		}
+
+		parsed, err := scenario.ParseExecRule(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate command %v", rule.Command)
+		}
+		rules = append(rules, parsed)
+	}
+
+	return rules, nil
+}
+
+func translateProbeResult(input podconfigv1.ProbeResult) scenario.ProbeResult {
+	switch input {
+	case podconfigv1.ProbeResultSucceed:
+		return scenario.ProbeResultSucceed
+	case podconfigv1.ProbeResultFail:
+		return scenario.ProbeResultFail
+	default:
+		return scenario.ProbeResultUnset
+	}
+}
+
 func translatePodResources(input *podconfigv1.PodResources) (*stats.PodStats, error) {
 	memory, err := scenario.GetInBytes(input.Memory, "memory")
 	if err != nil {
@@ -2,14 +2,17 @@ package pod
 
 import (
 	"testing"
+	"time"
 
 	"github.com/atlarge-research/apate/pkg/scenario/events"
 
 	"github.com/atlarge-research/apate/pkg/scenario"
 
+	"github.com/finitum/node-cli/stats"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
 	"github.com/atlarge-research/apate/services/apatelet/store"
 	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
@@ -22,7 +25,7 @@ func TestTranslateResponse(t *testing.T) {
 	assert.Equal(t, scenario.ResponseError, translateResponse(podconfigv1.ResponseError))
 	assert.Equal(t, scenario.ResponseTimeout, translateResponse(podconfigv1.ResponseTimeout))
 	assert.Equal(t, scenario.ResponseUnset, translateResponse(podconfigv1.ResponseUnset))
-	assert.Equal(t, scenario.ResponseUnset, translateResponse(podconfigv1.PodResponse(20)))
+	assert.Equal(t, scenario.ResponseUnset, translateResponse(podconfigv1.PodResponse("20")))
 }
 
 func TestTranslatePodStatus(t *testing.T) {
@@ -34,7 +37,7 @@ func TestTranslatePodStatus(t *testing.T) {
 	assert.Equal(t, scenario.PodStatusFailed, translatePodStatus(podconfigv1.PodStatusFailed))
 	assert.Equal(t, scenario.PodStatusUnknown, translatePodStatus(podconfigv1.PodStatusUnknown))
 	assert.Equal(t, scenario.PodStatusUnset, translatePodStatus(podconfigv1.PodStatusUnset))
-	assert.Equal(t, scenario.PodStatusUnset, translatePodStatus(podconfigv1.PodStatus(20)))
+	assert.Equal(t, scenario.PodStatusUnset, translatePodStatus(podconfigv1.PodStatus("20")))
 }
 
 func TestTranslatePodResources(t *testing.T) {
@@ -103,7 +106,7 @@ func TestSetPodFlagsUnset(t *testing.T) {
 
 	ms.EXPECT().SetPodFlags("test", store.Flags{})
 
-	err := SetPodFlags(&s, "test", &podconfigv1.PodConfigurationState{
+	err := SetPodFlags(&s, "test", nil, nil, "", &podconfigv1.PodConfigurationState{
 		CreatePodResponse:    podconfigv1.ResponseUnset,
 		UpdatePodResponse:    podconfigv1.ResponseUnset,
 		DeletePodResponse:    podconfigv1.ResponseUnset,
@@ -136,7 +139,7 @@ func TestSetPodFlags(t *testing.T) {
 		assert.Equal(t, translatePodStatus(podconfigv1.PodStatusRunning), flags[events.PodStatus])
 	})
 
-	err := SetPodFlags(&s, "test", &podconfigv1.PodConfigurationState{
+	err := SetPodFlags(&s, "test", nil, nil, "", &podconfigv1.PodConfigurationState{
 		CreatePodResponse:    podconfigv1.ResponseNormal,
 		UpdatePodResponse:    podconfigv1.ResponseNormal,
 		DeletePodResponse:    podconfigv1.ResponseNormal,
@@ -154,6 +157,30 @@ func TestSetPodFlags(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSetPodFlagsSubset(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+
+	subset, err := store.NewPodSubset("", 10, 0)
+	assert.NoError(t, err)
+
+	ms.EXPECT().SetPodFlags("test", store.Flags{
+		events.PodStatus: &store.PartialValue{Subset: subset, Value: translatePodStatus(podconfigv1.PodStatusFailed)},
+	})
+
+	err = SetPodFlags(&s, "test", subset, nil, "", &podconfigv1.PodConfigurationState{
+		PodStatus: podconfigv1.PodStatusFailed,
+	})
+
+	assert.NoError(t, err)
+}
+
 func TestSetPodFlagsErr(t *testing.T) {
 	t.Parallel()
 
@@ -166,7 +193,7 @@ func TestSetPodFlagsErr(t *testing.T) {
 
 	ms.EXPECT().SetPodFlags(gomock.Any(), gomock.Any()).MinTimes(0)
 
-	err := SetPodFlags(&s, "test", &podconfigv1.PodConfigurationState{
+	err := SetPodFlags(&s, "test", nil, nil, "", &podconfigv1.PodConfigurationState{
 		CreatePodResponse:    podconfigv1.ResponseNormal,
 		UpdatePodResponse:    podconfigv1.ResponseNormal,
 		DeletePodResponse:    podconfigv1.ResponseNormal,
@@ -183,3 +210,319 @@ func TestSetPodFlagsErr(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestTranslatePodFlagsResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		DeletePodResponse: "TIMEOUT=12.5%",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodDeletePodResponse: scenario.ResponseDistribution{
+			{Response: scenario.ResponseTimeout, Probability: 0.125},
+		},
+	}, flags)
+}
+
+func TestTranslatePodFlagsInvalidResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		DeletePodResponse: "UNSET=10%",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsLatency(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		CreatePodLatency: &nodeconfigv1.Latency{
+			Distribution: nodeconfigv1.LatencyNormal,
+			Mean:         "1s",
+			StdDev:       "100ms",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodCreatePodLatency: scenario.NormalLatency{Mean: time.Second, StdDev: 100 * time.Millisecond},
+	}, flags)
+}
+
+func TestTranslatePodFlagsInvalidLatency(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		GetPodStatusLatency: &nodeconfigv1.Latency{
+			Distribution: nodeconfigv1.LatencyUniform,
+			Min:          "1s",
+		},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsCrash(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Crash: &podconfigv1.PodCrash{
+			Probability: "0.1",
+			Interval:    "1m",
+			ExitCode:    2,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodCrash: &scenario.CrashModel{Probability: 0.1, Interval: time.Minute, ExitCode: 2},
+	}, flags)
+
+	_, err = TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Crash: &podconfigv1.PodCrash{ExitCode: 1},
+	})
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsContainers(t *testing.T) {
+	t.Parallel()
+
+	notReady := false
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Containers: []podconfigv1.ContainerState{
+			{
+				Name:   "app",
+				Status: podconfigv1.ContainerStatusRunning,
+				Ready:  &notReady,
+			},
+			{
+				Name:     "sidecar",
+				Status:   podconfigv1.ContainerStatusTerminated,
+				ExitCode: 3,
+				Reason:   "Error",
+				Resources: &podconfigv1.PodResources{
+					Memory:           "1K",
+					Storage:          "0B",
+					EphemeralStorage: "0B",
+				},
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodContainers: scenario.ContainerStates{
+			"app": &scenario.ContainerState{Status: scenario.ContainerStatusRunning},
+			"sidecar": &scenario.ContainerState{
+				Status:    scenario.ContainerStatusTerminated,
+				Ready:     true,
+				ExitCode:  3,
+				Reason:    "Error",
+				Resources: &stats.PodStats{UsageBytesMemory: 1024},
+			},
+		},
+	}, flags)
+}
+
+func TestTranslatePodFlagsDuplicateContainers(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Containers: []podconfigv1.ContainerState{
+			{Name: "app"},
+			{Name: "app"},
+		},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsProbes(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Probes: &podconfigv1.PodProbes{
+			Readiness: podconfigv1.ProbeResultFail,
+			Liveness:  podconfigv1.ProbeResultSucceed,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodProbes: scenario.Probes{Readiness: scenario.ProbeResultFail, Liveness: scenario.ProbeResultSucceed},
+	}, flags)
+}
+
+func TestTranslatePodFlagsStartup(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Startup: &podconfigv1.PodStartup{
+			Scheduled:         &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "1s"},
+			ContainerCreating: &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyUniform, Min: "1s", Max: "3s"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodStartup: &scenario.StartupPhases{
+			Scheduled:         scenario.ConstantLatency(time.Second),
+			ContainerCreating: scenario.UniformLatency{Min: time.Second, Max: 3 * time.Second},
+		},
+	}, flags)
+}
+
+func TestTranslatePodFlagsImagePull(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		ImagePull: &podconfigv1.PodImagePull{
+			Size: "2M",
+			Fail: true,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodImagePull: &scenario.PodImagePull{Size: 2 * 1024 * 1024, Fail: true},
+	}, flags)
+
+	_, err = TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		ImagePull: &podconfigv1.PodImagePull{Size: "large"},
+	})
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsRuntime(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Runtime: &podconfigv1.PodRuntime{
+			Latency:            &nodeconfigv1.Latency{Distribution: nodeconfigv1.LatencyConstant, Value: "5m"},
+			Annotation:         "example.com/runtime",
+			FailureProbability: "0.1",
+			ExitCode:           1,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.Flags{
+		events.PodRuntime: &scenario.RuntimeModel{
+			Runtime:            scenario.ConstantLatency(5 * time.Minute),
+			Annotation:         "example.com/runtime",
+			FailureProbability: 0.1,
+			ExitCode:           1,
+		},
+	}, flags)
+
+	_, err = TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Runtime: &podconfigv1.PodRuntime{ExitCode: 1},
+	})
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsLogs(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Logs: &podconfigv1.PodLogs{
+			Replay:       &podconfigv1.LogReplay{Data: "a\nb\n"},
+			Rate:         "10",
+			FailureBurst: 5,
+		},
+	})
+	assert.NoError(t, err)
+
+	logs, ok := flags[events.PodLogs].(*scenario.LogModel)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, logs.Lines)
+	assert.Equal(t, 10.0, logs.Rate)
+	assert.Equal(t, 5, logs.FailureBurst)
+
+	// Config maps should have been read by the handler
+	_, err = TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Logs: &podconfigv1.PodLogs{
+			Replay: &podconfigv1.LogReplay{ConfigMap: &podconfigv1.ConfigMapKeySelector{Name: "logs", Key: "app.log"}},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsExec(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Exec: []podconfigv1.ExecRule{
+			{Command: "cat /etc/.*", Stdout: "ok"},
+			{Command: "false", Container: "app", ExitCode: 2},
+		},
+	})
+	assert.NoError(t, err)
+
+	rules, ok := flags[events.PodExec].(scenario.ExecRules)
+	assert.True(t, ok)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "ok", rules[0].Stdout)
+	assert.Equal(t, "app", rules[1].Container)
+	assert.Equal(t, int32(2), rules[1].ExitCode)
+
+	_, err = TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		Exec: []podconfigv1.ExecRule{{Command: "(cat"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestTranslatePodFlagsProcesses(t *testing.T) {
+	t.Parallel()
+
+	processes := int64(12)
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{Processes: &processes})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), flags[events.PodProcesses])
+}
+
+func TestTranslatePodFlagsResourceCurve(t *testing.T) {
+	t.Parallel()
+
+	flags, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		PodResources: &podconfigv1.PodResources{
+			Memory:           "1K",
+			CPU:              50,
+			Storage:          "0B",
+			EphemeralStorage: "0B",
+			Curve: &podconfigv1.UsageCurve{
+				Shape:     "SINE",
+				Period:    "1m",
+				Amplitude: "0.5",
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	curve, ok := flags[events.PodResources].(*scenario.ResourceCurve)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1024), curve.Usage.UsageBytesMemory)
+	assert.Equal(t, uint64(50), curve.Usage.UsageNanoCores)
+	assert.Equal(t, scenario.UsageCurve{Shape: scenario.UsageSine, Period: time.Minute, Amplitude: 0.5}, curve.Curve)
+}
+
+func TestTranslatePodFlagsInvalidResourceCurve(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslatePodFlags(&podconfigv1.PodConfigurationState{
+		PodResources: &podconfigv1.PodResources{
+			Memory:           "1K",
+			Storage:          "0B",
+			EphemeralStorage: "0B",
+			Curve: &podconfigv1.UsageCurve{
+				Shape: "LINEAR",
+			},
+		},
+	})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"sync"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+// capacityTracker keeps track of the changes to the resources of the node made by tasks
+type capacityTracker struct {
+	lock     sync.RWMutex
+	capacity *scenario.NodeCapacity
+
+	// Signals that the capacity has changed, so the node status can be updated right away
+	changed chan struct{}
+}
+
+func newCapacityTracker() *capacityTracker {
+	return &capacityTracker{
+		capacity: &scenario.NodeCapacity{},
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// set changes the capacity of the node, a pending signal of an earlier change covers this one as well
+func (c *capacityTracker) set(capacity *scenario.NodeCapacity) {
+	c.lock.Lock()
+	c.capacity = capacity
+	c.lock.Unlock()
+
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// changes returns the channel which signals changes of the capacity
+func (c *capacityTracker) changes() <-chan struct{} {
+	return c.changed
+}
+
+// resources returns the current resources of the node, which are those it joined with unless tasks changed them
+func (p *Provider) resources() scenario.NodeResources {
+	p.nodeCapacity.lock.RLock()
+	defer p.nodeCapacity.lock.RUnlock()
+
+	return p.nodeCapacity.capacity.Apply(*p.Resources)
+}
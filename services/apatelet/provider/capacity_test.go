@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/finitum/node-cli/provider"
+	"github.com/finitum/node-cli/stats"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/atlarge-research/apate/pkg/env"
+	"github.com/atlarge-research/apate/pkg/kubernetes/node"
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/provider/podmanager"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+)
+
+func TestNodeCapacityChange(t *testing.T) {
+	t.Parallel()
+
+	st := store.NewStore()
+	resources := scenario.NodeResources{
+		UUID:             uuid.New(),
+		Memory:           4096,
+		CPU:              1000,
+		Storage:          2048,
+		EphemeralStorage: 2048,
+		MaxPods:          10,
+	}
+
+	ni, err := node.NewInfo("a", "b", "c", "d", "e/f")
+	assert.NoError(t, err)
+
+	e, err := env.ApateletEnv()
+	assert.NoError(t, err)
+
+	p, ok := NewProvider(podmanager.New(), NewStats(), &resources, &provider.InitConfig{}, &ni, &st, true, e, nil).(*Provider)
+	assert.True(t, ok)
+
+	memory := int64(2048)
+	pods := int64(20)
+	st.SetNodeFlags(store.Flags{events.NodeCapacity: &scenario.NodeCapacity{Memory: &memory, MaxPods: &pods}})
+
+	// The node status is updated right away
+	select {
+	case <-p.nodeCapacity.changes():
+	case <-time.After(time.Second):
+		assert.Fail(t, "capacity change was not signalled")
+	}
+
+	assert.EqualValues(t, corev1.ResourceList{
+		corev1.ResourceCPU:              *resource.NewQuantity(1000, ""),
+		corev1.ResourceMemory:           *resource.NewQuantity(2048, ""),
+		corev1.ResourceStorage:          *resource.NewQuantity(2048, ""),
+		corev1.ResourceEphemeralStorage: *resource.NewQuantity(2048, ""),
+		corev1.ResourcePods:             *resource.NewQuantity(20, ""),
+	}, p.capacity())
+
+	// Allocatable resources follow the capacity
+	allocatable := p.allocatable()
+	assert.EqualValues(t, 2048, allocatable.Memory().Value())
+	assert.EqualValues(t, 20, allocatable.Pods().Value())
+
+	// Resources used beyond the lowered capacity are not available, rather than wrapping around
+	available, usage := p.memoryStats([]stats.PodStats{{UsageBytesMemory: 3072}})
+	assert.EqualValues(t, 0, available)
+	assert.EqualValues(t, 3072, usage)
+
+	storage := int64(1024)
+	st.SetNodeFlags(store.Flags{events.NodeCapacity: &scenario.NodeCapacity{Storage: &storage, EphemeralStorage: &storage}})
+
+	free, capacity, used := p.ephemeralStats([]stats.PodStats{{UsedBytesEphemeral: 1536}})
+	assert.EqualValues(t, 0, free)
+	assert.EqualValues(t, 1024, capacity)
+	assert.EqualValues(t, 1536, used)
+
+	free, capacity, used = p.storageStats([]stats.PodStats{{UsedBytesStorage: 1536}})
+	assert.EqualValues(t, 0, free)
+	assert.EqualValues(t, 1024, capacity)
+	assert.EqualValues(t, 1536, used)
+
+	// The resources the node joined with are kept, so the capacity can be restored
+	assert.EqualValues(t, 4096, resources.Memory)
+	st.SetNodeFlags(store.Flags{events.NodeCapacity: &scenario.NodeCapacity{}})
+	assert.EqualValues(t, 4096, p.resources().Memory)
+}
@@ -21,6 +21,16 @@ type NodeCondition struct {
 
 	condition  corev1.NodeConditionType
 	transition metav1.Time
+
+	// The condition reported regardless of the value, if it is forced
+	forced *Forced
+}
+
+// Forced is a condition which is reported regardless of the value of the condition
+type Forced struct {
+	Status  corev1.ConditionStatus
+	Reason  string
+	Message string
 }
 
 // New returns a new condition struct
@@ -35,6 +45,10 @@ func New(initial bool, condition corev1.NodeConditionType) NodeCondition {
 }
 
 func (c *NodeCondition) getStatus() corev1.ConditionStatus {
+	if c.forced != nil {
+		return c.forced.Status
+	}
+
 	if c.val {
 		return corev1.ConditionTrue
 	}
@@ -43,6 +57,10 @@ func (c *NodeCondition) getStatus() corev1.ConditionStatus {
 }
 
 func (c *NodeCondition) getMessage() string {
+	if c.forced != nil {
+		return c.forced.Message
+	}
+
 	if c.val != c.negate {
 		return readyMessage
 	}
@@ -51,6 +69,10 @@ func (c *NodeCondition) getMessage() string {
 }
 
 func (c *NodeCondition) getReason() string {
+	if c.forced != nil {
+		return c.forced.Reason
+	}
+
 	if c.val != c.negate {
 		return resourceReason
 	}
@@ -58,22 +80,39 @@ func (c *NodeCondition) getReason() string {
 	return resourcePressureReason
 }
 
+// Type returns the type of the condition
+func (c *NodeCondition) Type() corev1.NodeConditionType {
+	return c.condition
+}
+
 // Update returns the correct node condition based on the given condition
 func (c *NodeCondition) Update(condition bool) corev1.NodeCondition {
+	return c.Set(condition, nil)
+}
+
+// Force returns the node condition with the given status, reason and message, regardless of the given conditions
+// It stays forced until it is updated again
+func (c *NodeCondition) Force(status corev1.ConditionStatus, reason, message string) corev1.NodeCondition {
+	return c.Set(c.val, &Forced{
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// Set returns the node condition based on the given condition, or the forced condition if it is not nil
+// The transition time only changes if the reported status does
+func (c *NodeCondition) Set(condition bool, forced *Forced) corev1.NodeCondition {
+	previous := c.getStatus()
+	c.val = condition
+	c.forced = forced
+
 	// If the state is different, update state accordingly
-	if c.val != condition {
-		c.val = condition
+	if c.getStatus() != previous {
 		c.transition = metav1.Now()
 	}
 
-	return corev1.NodeCondition{
-		Type:               c.condition,
-		Status:             c.getStatus(),
-		LastHeartbeatTime:  metav1.Now(),
-		LastTransitionTime: c.transition,
-		Reason:             c.getReason(),
-		Message:            c.getMessage(),
-	}
+	return c.Get()
 }
 
 // Get returns the NodeCondition
@@ -46,3 +46,21 @@ func TestConditionMemoryPressure(t *testing.T) {
 	assert.Equal(t, readyMessage, cond.getMessage())
 	assert.Equal(t, resourceReason, cond.getReason())
 }
+
+func TestConditionForce(t *testing.T) {
+	t.Parallel()
+
+	cond := New(false, corev1.NodeDiskPressure)
+	initial := cond.Get().LastTransitionTime
+
+	forced := cond.Force(corev1.ConditionUnknown, "Testing", "forced by a test")
+	assert.Equal(t, corev1.ConditionUnknown, forced.Status)
+	assert.Equal(t, "Testing", forced.Reason)
+	assert.Equal(t, "forced by a test", forced.Message)
+	assert.False(t, forced.LastTransitionTime.Before(&initial))
+
+	// Updating the condition stops forcing it
+	updated := cond.Update(false)
+	assert.Equal(t, corev1.ConditionFalse, updated.Status)
+	assert.Equal(t, resourceReason, updated.Reason)
+}
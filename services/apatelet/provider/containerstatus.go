@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// containerPhase summarises the state of a single container, which determines the phase of its pod
+type containerPhase int
+
+const (
+	// containerWaiting means the container is waiting to be started for the first time
+	containerWaiting containerPhase = iota
+	// containerRunning means the container is running
+	containerRunning
+	// containerBackOff means the container has terminated and is waiting to be restarted
+	containerBackOff
+	// containerTerminated means the container has terminated and won't be restarted
+	containerTerminated
+)
+
+// emulatedContainer is the emulated state of a single container of a pod
+type emulatedContainer struct {
+	phase  containerPhase
+	status corev1.ContainerStatus
+
+	// Why the container terminated, nil if it hasn't
+	terminated *termination
+}
+
+// getContainerStates returns the emulated states of the containers of the given pod, nil if it has no containers
+func (p *Provider) getContainerStates(pod *corev1.Pod) (scenario.ContainerStates, error) {
+	if len(pod.Spec.Containers) == 0 {
+		return nil, nil
+	}
+
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodContainers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod containers flag")
+	}
+
+	containers, ok := flag.(scenario.ContainerStates)
+	if !ok {
+		return nil, errors.Errorf("invalid pod containers flag %v", flag)
+	}
+
+	return containers, nil
+}
+
+// getContainerUsage returns the total resources used by the running containers with their own resources
+func getContainerUsage(pod *corev1.Pod, containers scenario.ContainerStates) stats.PodStats {
+	var usage stats.PodStats
+	for _, c := range pod.Spec.Containers {
+		state, ok := containers[c.Name]
+		if !ok || state.Resources == nil || state.Status == scenario.ContainerStatusWaiting || state.Status == scenario.ContainerStatusTerminated {
+			continue
+		}
+
+		usage.UsageNanoCores += state.Resources.UsageNanoCores
+		usage.UsageBytesMemory += state.Resources.UsageBytesMemory
+		usage.UsedBytesEphemeral += state.Resources.UsedBytesEphemeral
+		usage.UsedBytesStorage += state.Resources.UsedBytesStorage
+	}
+
+	return usage
+}
+
+// emulatePod determines the status of a running pod from the emulated state of each of its containers, which started at the given time
+// Exit is why the containers should terminate according to the pod status, limitExit is why they are killed
+// when the pod exceeds its limits, both are nil if the containers should be running
+func (p *Provider) emulatePod(pod *corev1.Pod, startedAt time.Time, exit, limitExit *termination, containers scenario.ContainerStates,
+	crash *scenario.CrashModel, probes scenario.Probes) *corev1.PodStatus {
+	specs := pod.Spec.Containers
+	if len(specs) == 0 {
+		// A pod without containers is emulated as a single unnamed container, which isn't reported
+		specs = []corev1.Container{{}}
+	}
+
+	now := time.Now()
+	emulated := make([]emulatedContainer, len(specs))
+	for i, c := range specs {
+		emulated[i] = p.emulateContainer(pod, c, containers[c.Name], startedAt, exit, limitExit, crash, probes, now)
+	}
+
+	status := podFromContainers(emulated, now)
+	if len(pod.Spec.Containers) > 0 {
+		status.ContainerStatuses = make([]corev1.ContainerStatus, len(emulated))
+		for i, e := range emulated {
+			status.ContainerStatuses[i] = e.status
+		}
+	}
+
+	return status
+}
+
+// emulateContainer determines the status of a single container, the state overrides the pod status for it if set
+func (p *Provider) emulateContainer(pod *corev1.Pod, c corev1.Container, state *scenario.ContainerState,
+	startedAt time.Time, exit, limitExit *termination, crash *scenario.CrashModel, probes scenario.Probes, now time.Time) emulatedContainer {
+	status := corev1.ContainerStatus{
+		Name:  c.Name,
+		Image: c.Image,
+	}
+
+	ready := true
+	if state != nil {
+		ready = state.Ready
+
+		switch state.Status {
+		case scenario.ContainerStatusUnset:
+			// follow the status of the pod
+		case scenario.ContainerStatusRunning:
+			exit = nil
+		case scenario.ContainerStatusWaiting:
+			status.State.Waiting = &corev1.ContainerStateWaiting{
+				Reason:  defaultReason(state.Reason, "ContainerCreating"),
+				Message: fmt.Sprintf("Emulated container %v is waiting", c.Name),
+			}
+			return emulatedContainer{phase: containerWaiting, status: status}
+		case scenario.ContainerStatusTerminated:
+			reason := "Completed"
+			if state.ExitCode != 0 {
+				reason = "Error"
+			}
+
+			exit = &termination{
+				exitCode: state.ExitCode,
+				reason:   defaultReason(state.Reason, reason),
+				message:  fmt.Sprintf("Emulated container %v has terminated", c.Name),
+			}
+		}
+	}
+
+	if exit == nil {
+		switch {
+		case limitExit != nil:
+			exit = limitExit
+		case state != nil && state.Resources != nil && exceedsContainerLimit(c, state.Resources):
+			exit = &termination{
+				exitCode: 137,
+				reason:   "OOMKilled",
+				message:  fmt.Sprintf("Container %v used too many resources and was then killed", c.Name),
+			}
+		}
+	}
+
+	run := p.restarts.evaluate(pod, c, startedAt, exit, crash, probes, now, p.random)
+	status.RestartCount = run.restarts
+	if run.last != nil {
+		status.LastTerminationState.Terminated = terminatedState(run.last)
+	}
+
+	switch {
+	case run.terminated == nil:
+		status.State.Running = &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(run.startedAt)}
+		status.Ready = ready && run.ready
+		return emulatedContainer{phase: containerRunning, status: status}
+	case restartable(pod.Spec.RestartPolicy, run.terminated.exitCode):
+		status.State.Waiting = &corev1.ContainerStateWaiting{
+			Reason: "CrashLoopBackOff",
+			Message: fmt.Sprintf("back-off %v restarting failed container %v of pod %v",
+				run.backoff, c.Name, pod.Name),
+		}
+		status.LastTerminationState.Terminated = terminatedState(run.terminated)
+		return emulatedContainer{phase: containerBackOff, status: status, terminated: run.terminated}
+	default:
+		status.State.Terminated = terminatedState(run.terminated)
+		return emulatedContainer{phase: containerTerminated, status: status, terminated: run.terminated}
+	}
+}
+
+// podFromContainers derives the phase and conditions of a pod from the emulated state of its containers
+func podFromContainers(emulated []emulatedContainer, now time.Time) *corev1.PodStatus {
+	counts := make(map[containerPhase]int)
+	ready := true
+	var terminated *termination
+	for _, e := range emulated {
+		counts[e.phase]++
+		ready = ready && e.phase == containerRunning && e.status.Ready
+
+		// The pod reports the first failed termination, or else the first termination
+		if e.terminated != nil && (terminated == nil || terminated.exitCode == 0 && e.terminated.exitCode != 0) {
+			terminated = e.terminated
+		}
+	}
+
+	if counts[containerTerminated] == len(emulated) {
+		if terminated.exitCode == 0 {
+			return &corev1.PodStatus{
+				Phase:   corev1.PodSucceeded,
+				Message: terminated.message,
+			}
+		}
+
+		return &corev1.PodStatus{
+			Phase:      corev1.PodFailed,
+			Message:    terminated.message,
+			Conditions: []corev1.PodCondition{podReadyCondition(false, "Failed pod...", now)},
+		}
+	}
+
+	if counts[containerWaiting] == len(emulated) {
+		return &corev1.PodStatus{
+			Phase:   corev1.PodPending,
+			Message: "Containers are waiting to be started",
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionTrue,
+					LastProbeTime:      metav1.NewTime(now),
+					LastTransitionTime: metav1.NewTime(now),
+					Message:            "Pod is scheduled pod...",
+				},
+			},
+		}
+	}
+
+	if ready {
+		return &corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Message:    "Emulating pod successfully",
+			Conditions: []corev1.PodCondition{podReadyCondition(true, "Emulating pod...", now)},
+		}
+	}
+
+	message := "Not all containers are ready"
+	if terminated != nil {
+		message = terminated.message
+	}
+
+	condition := podReadyCondition(false, "Containers are not ready", now)
+	condition.Reason = "ContainersNotReady"
+	return &corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		Message:    message,
+		Conditions: []corev1.PodCondition{condition},
+	}
+}
+
+func podReadyCondition(ready bool, message string, now time.Time) corev1.PodCondition {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return corev1.PodCondition{
+		Type:               corev1.PodReady,
+		Status:             status,
+		LastProbeTime:      metav1.NewTime(now),
+		LastTransitionTime: metav1.NewTime(now),
+		Message:            message,
+	}
+}
+
+func terminatedState(t *termination) *corev1.ContainerStateTerminated {
+	return &corev1.ContainerStateTerminated{
+		ExitCode:   t.exitCode,
+		Reason:     t.reason,
+		Message:    t.message,
+		FinishedAt: metav1.NewTime(t.at),
+	}
+}
+
+func exceedsContainerLimit(c corev1.Container, usage *stats.PodStats) bool {
+	limits := c.Resources.Limits
+	exceeds := func(used uint64, limit int64) bool {
+		return limit > 0 && used > uint64(limit)
+	}
+
+	// CPU usage is measured in nanocores, while the limit is in cores
+	return exceeds(usage.UsageNanoCores, limits.Cpu().ScaledValue(resource.Nano)) ||
+		exceeds(usage.UsageBytesMemory, limits.Memory().Value()) ||
+		exceeds(usage.UsedBytesEphemeral, limits.StorageEphemeral().Value())
+}
+
+func defaultReason(reason, fallback string) string {
+	if reason == "" {
+		return fallback
+	}
+	return reason
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/utils/exec"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+func (p *Provider) getExecRules(pod *corev1.Pod) (scenario.ExecRules, error) {
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodExec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod exec flag")
+	}
+
+	rules, ok := flag.(scenario.ExecRules)
+	if !ok {
+		return nil, errors.Errorf("invalid pod exec flag %v", flag)
+	}
+
+	return rules, nil
+}
+
+// runScriptedCommand responds to the given command executed in the given container of the pod as scripted by its exec rules
+// Commands which match no rule succeed without output, like they did before they could be scripted
+func (p *Provider) runScriptedCommand(ctx context.Context, pod *corev1.Pod, container string, cmd []string, attach api.AttachIO) error {
+	rules, err := p.getExecRules(pod)
+	if err != nil {
+		return errors.Wrap(err, "failed to get exec rules")
+	}
+
+	rule, ok := rules.Match(container, cmd)
+	if !ok {
+		return nil
+	}
+
+	if rule.Delay != nil {
+		timer := time.NewTimer(rule.Delay.Sample(p.random))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Wrap(ctx.Err(), "context cancelled while running command")
+		case <-timer.C:
+		}
+	}
+
+	// Streams which were not requested are nil
+	if err := writeStream(attach.Stdout(), rule.Stdout); err != nil {
+		return errors.Wrap(err, "failed to write stdout")
+	}
+
+	if err := writeStream(attach.Stderr(), rule.Stderr); err != nil {
+		return errors.Wrap(err, "failed to write stderr")
+	}
+
+	if rule.ExitCode != 0 {
+		// The exit code is only reported to the client if this error is returned as is
+		return utilexec.CodeExitError{
+			Err:  errors.Errorf("command terminated with exit code %d", rule.ExitCode),
+			Code: int(rule.ExitCode),
+		}
+	}
+
+	return nil
+}
+
+func writeStream(w io.Writer, data string) error {
+	if w == nil || data == "" {
+		return nil
+	}
+
+	_, err := io.WriteString(w, data)
+	return err
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/utils/exec"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error {
+	return nil
+}
+
+type bufferAttachIO struct {
+	fakeAttachIO
+	stdout, stderr bufferCloser
+}
+
+func (b *bufferAttachIO) Stdout() io.WriteCloser {
+	return &b.stdout
+}
+
+func (b *bufferAttachIO) Stderr() io.WriteCloser {
+	return &b.stderr
+}
+
+func prepareExec(t *testing.T, specs ...scenario.ExecRuleSpec) (*Provider, *corev1.Pod, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	ms := mock_store.NewMockStore(ctrl)
+
+	var rules scenario.ExecRules
+	for _, spec := range specs {
+		rule, err := scenario.ParseExecRule(spec)
+		assert.NoError(t, err)
+		rules = append(rules, rule)
+	}
+
+	pod := &corev1.Pod{}
+	ms.EXPECT().GetPodFlag(pod, events.PodExec).Return(rules, nil)
+
+	var s store.Store = ms
+	return &Provider{Store: &s}, pod, ctrl
+}
+
+func TestRunScriptedCommand(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareExec(t, scenario.ExecRuleSpec{Command: "cat /etc/.*", Stdout: "out", Stderr: "err"})
+	defer ctrl.Finish()
+
+	attach := &bufferAttachIO{}
+	err := prov.runScriptedCommand(context.Background(), pod, "app", []string{"cat", "/etc/hosts"}, attach)
+	assert.NoError(t, err)
+	assert.Equal(t, "out", attach.stdout.String())
+	assert.Equal(t, "err", attach.stderr.String())
+}
+
+func TestRunScriptedCommandExitCode(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareExec(t, scenario.ExecRuleSpec{Command: "false", ExitCode: 3})
+	defer ctrl.Finish()
+
+	// Streams which were not requested are skipped
+	err := prov.runScriptedCommand(context.Background(), pod, "app", []string{"false"}, fakeAttachIO{})
+
+	exitErr, ok := err.(utilexec.ExitError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, exitErr.ExitStatus())
+}
+
+func TestRunScriptedCommandDelay(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareExec(t, scenario.ExecRuleSpec{Command: "sleep", Delay: scenario.ConstantLatency(time.Hour)})
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := prov.runScriptedCommand(ctx, pod, "app", []string{"sleep"}, fakeAttachIO{})
+	assert.Error(t, err)
+}
+
+func TestRunScriptedCommandNoMatch(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareExec(t, scenario.ExecRuleSpec{Command: "ls", Container: "sidecar", Stdout: "out"})
+	defer ctrl.Finish()
+
+	attach := &bufferAttachIO{}
+	err := prov.runScriptedCommand(context.Background(), pod, "app", []string{"ls"}, attach)
+	assert.NoError(t, err)
+	assert.Equal(t, "", attach.stdout.String())
+}
+
+var _ api.AttachIO = &bufferAttachIO{}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// imageCache keeps track of the images pulled by the node
+type imageCache struct {
+	lock   sync.Mutex
+	images map[string]cachedImage
+
+	// Only one image is pulled at a time, like the kubelet does by default
+	busyUntil time.Time
+}
+
+// cachedImage is an image which has been pulled, or is being pulled, by the node
+type cachedImage struct {
+	size        uint64
+	availableAt time.Time
+}
+
+func newImageCache() *imageCache {
+	return &imageCache{
+		images: make(map[string]cachedImage),
+	}
+}
+
+func (p *Provider) getNodeImages() (*scenario.NodeImages, error) {
+	flag, err := (*p.Store).GetNodeFlag(events.NodeImages)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node images flag")
+	}
+
+	images, ok := flag.(*scenario.NodeImages)
+	if !ok {
+		return nil, errors.Errorf("invalid node images flag %v", flag)
+	}
+
+	return images, nil
+}
+
+func (p *Provider) getPodImagePull(pod *corev1.Pod) (*scenario.PodImagePull, error) {
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodImagePull)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod image pull flag")
+	}
+
+	imagePull, ok := flag.(*scenario.PodImagePull)
+	if !ok {
+		return nil, errors.Errorf("invalid pod image pull flag %v", flag)
+	}
+
+	return imagePull, nil
+}
+
+// podImages returns the distinct images of the containers of the given pod
+func podImages(pod *corev1.Pod) []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, c := range pod.Spec.Containers {
+		if c.Image != "" && !seen[c.Image] {
+			seen[c.Image] = true
+			images = append(images, c.Image)
+		}
+	}
+
+	return images
+}
+
+// missing returns the first of the given images which is neither present on the node nor pulled or being pulled
+func (c *imageCache) missing(images []string, node *scenario.NodeImages) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, image := range images {
+		if _, ok := c.images[image]; !ok && !node.IsCached(image) {
+			return image, true
+		}
+	}
+
+	return "", false
+}
+
+// pull pulls the given images which are not present on the node yet, from the given time onwards
+// It returns when all images are available, which is the given time if they are all present already
+func (c *imageCache) pull(images []string, from time.Time, latency time.Duration, size uint64, node *scenario.NodeImages) time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	pulled := from
+	for _, image := range images {
+		if node.IsCached(image) {
+			continue
+		}
+
+		cached, ok := c.images[image]
+		if !ok {
+			start := from
+			if c.busyUntil.After(start) {
+				start = c.busyUntil
+			}
+
+			cached = cachedImage{
+				size:        size,
+				availableAt: start.Add(latency + node.TransferTime(size)),
+			}
+			c.images[image] = cached
+			c.busyUntil = cached.availableAt
+		}
+
+		// Images which are being pulled for another pod are shared
+		if cached.availableAt.After(pulled) {
+			pulled = cached.availableAt
+		}
+	}
+
+	return pulled
+}
+
+// nodeImages returns the images present on the node at the given time, as they are reported to kubernetes
+func (c *imageCache) nodeImages(node *scenario.NodeImages, now time.Time) []corev1.ContainerImage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	sizes := make(map[string]uint64)
+	for _, image := range node.Cached {
+		sizes[image] = node.DefaultSize
+	}
+
+	for image, cached := range c.images {
+		if !cached.availableAt.After(now) {
+			sizes[image] = cached.size
+		}
+	}
+
+	images := make([]corev1.ContainerImage, 0, len(sizes))
+	for image, size := range sizes {
+		images = append(images, corev1.ContainerImage{
+			Names:     []string{image},
+			SizeBytes: int64(size),
+		})
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Names[0] < images[j].Names[0]
+	})
+
+	return images
+}
+
+// nodeImages returns the images present on the node, so the scheduler can take image locality into account
+func (p *Provider) nodeImages() []corev1.ContainerImage {
+	node, err := p.getNodeImages()
+	if err != nil {
+		log.Printf("unable to get node images: %v\n", err)
+		return nil
+	}
+
+	return p.images.nodeImages(node, time.Now())
+}
+
+// nextImagePullRetry returns the first time after the given time at which the kubelet retries pulling an image which failed at failedAt
+func nextImagePullRetry(failedAt, after time.Time) time.Time {
+	retry, backoff := failedAt, initialBackoff
+	for !retry.After(after) {
+		retry = retry.Add(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	return retry
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+func TestImageCachePull(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	cache := newImageCache()
+	node := &scenario.NodeImages{Bandwidth: 100, Cached: []string{"cached"}}
+
+	// Cached images are available right away
+	assert.Equal(t, start, cache.pull([]string{"cached"}, start, time.Second, 1000, node))
+
+	// Pulling takes the latency and the transfer time
+	pulled := cache.pull([]string{"a"}, start, time.Second, 1000, node)
+	assert.Equal(t, start.Add(11*time.Second), pulled)
+
+	// Other images wait for the pull in progress
+	assert.Equal(t, start.Add(22*time.Second), cache.pull([]string{"b"}, start, time.Second, 1000, node))
+
+	// Images which are being pulled are shared
+	assert.Equal(t, pulled, cache.pull([]string{"a", "cached"}, start.Add(time.Second), time.Second, 1000, node))
+
+	_, missing := cache.missing([]string{"a", "b", "cached"}, node)
+	assert.False(t, missing)
+
+	image, missing := cache.missing([]string{"a", "c"}, node)
+	assert.True(t, missing)
+	assert.Equal(t, "c", image)
+}
+
+func TestImageCacheNodeImages(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	cache := newImageCache()
+	node := &scenario.NodeImages{Bandwidth: 100, DefaultSize: 50, Cached: []string{"cached"}}
+
+	cache.pull([]string{"a"}, start, 0, 1000, node)
+
+	// Images are only reported once they have been pulled
+	assert.Equal(t, []corev1.ContainerImage{
+		{Names: []string{"cached"}, SizeBytes: 50},
+	}, cache.nodeImages(node, start))
+
+	assert.Equal(t, []corev1.ContainerImage{
+		{Names: []string{"a"}, SizeBytes: 1000},
+		{Names: []string{"cached"}, SizeBytes: 50},
+	}, cache.nodeImages(node, start.Add(10*time.Second)))
+}
+
+func TestNextImagePullRetry(t *testing.T) {
+	t.Parallel()
+
+	failedAt := time.Now()
+
+	assert.Equal(t, failedAt.Add(initialBackoff), nextImagePullRetry(failedAt, failedAt))
+	assert.Equal(t, failedAt.Add(3*initialBackoff), nextImagePullRetry(failedAt, failedAt.Add(15*time.Second)))
+
+	// The backoff is capped
+	retry := nextImagePullRetry(failedAt, failedAt.Add(time.Hour))
+	assert.True(t, retry.Sub(failedAt) <= time.Hour+maxBackoff)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// runLatency waits for the latency of the node plus the latency of the given operation on node level
+func (p *Provider) runLatency(ctx context.Context, nodeEventFlag events.NodeEventFlag) error {
+	latency, err := p.sampleNodeLatency(nodeEventFlag)
+	if err != nil {
+		return errors.Wrap(err, "failed to sample node latency")
+	}
+
+	return waitLatency(ctx, latency)
+}
+
+// runPodLatency waits for the latency of the node plus the latency of the given operation on both node and pod level
+// If the pod is nil, only the latency on node level is used
+func (p *Provider) runPodLatency(ctx context.Context, pod *corev1.Pod, podEventFlag events.PodEventFlag) error {
+	nodeEventFlag, err := getCorrespondingNodeLatencyFlag(podEventFlag)
+	if err != nil {
+		return errors.Wrap(err, "error translating pod latency flag to node latency flag")
+	}
+
+	latency, err := p.sampleNodeLatency(nodeEventFlag)
+	if err != nil {
+		return errors.Wrap(err, "failed to sample node latency")
+	}
+
+	if pod != nil {
+		iflag, err := (*p.Store).GetPodFlag(pod, podEventFlag)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get pod flag %v", podEventFlag)
+		}
+
+		podLatency, err := p.sampleLatency(iflag)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sample pod flag %v", podEventFlag)
+		}
+
+		latency += podLatency
+	}
+
+	return waitLatency(ctx, latency)
+}
+
+// sampleNodeLatency samples the latency of the node and adds the latency of the given operation to it
+func (p *Provider) sampleNodeLatency(nodeEventFlag events.NodeEventFlag) (time.Duration, error) {
+	var total time.Duration
+	for _, flag := range []events.NodeEventFlag{events.NodeAddedLatency, nodeEventFlag} {
+		iflag, err := (*p.Store).GetNodeFlag(flag)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get node flag %v", flag)
+		}
+
+		latency, err := p.sampleLatency(iflag)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to sample node flag %v", flag)
+		}
+
+		total += latency
+	}
+
+	return total, nil
+}
+
+func (p *Provider) sampleLatency(iflag interface{}) (time.Duration, error) {
+	distribution, ok := iflag.(scenario.LatencyDistribution)
+	if !ok {
+		return 0, errors.Errorf("couldn't cast %v to latency distribution", iflag)
+	}
+
+	return distribution.Sample(p.random), nil
+}
+
+func getCorrespondingNodeLatencyFlag(podEventFlag events.PodEventFlag) (events.NodeEventFlag, error) {
+	switch podEventFlag {
+	case events.PodCreatePodLatency:
+		return events.NodeCreatePodLatency, nil
+	case events.PodUpdatePodLatency:
+		return events.NodeUpdatePodLatency, nil
+	case events.PodDeletePodLatency:
+		return events.NodeDeletePodLatency, nil
+	case events.PodGetPodLatency:
+		return events.NodeGetPodLatency, nil
+	case events.PodGetPodStatusLatency:
+		return events.NodeGetPodStatusLatency, nil
+	default:
+		return -1, errors.Errorf("pod event flag %v cannot be translated into node latency flag", podEventFlag)
+	}
+}
+
+func waitLatency(ctx context.Context, latency time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "context cancelled while running latency")
+	case <-time.After(latency):
+		// Do the actual latency
+		return nil
+	}
+}
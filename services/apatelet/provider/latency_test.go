@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
+)
+
+func TestSampleNodeLatency(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	p := Provider{Store: &s, random: newRandom(0)}
+
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(10*time.Millisecond), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeCreatePodLatency).Return(scenario.UniformLatency{Min: 5 * time.Millisecond, Max: 5 * time.Millisecond}, nil)
+
+	latency, err := p.sampleNodeLatency(events.NodeCreatePodLatency)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Millisecond, latency)
+}
+
+func TestSampleNodeLatencyInvalid(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	p := Provider{Store: &s}
+
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(10*time.Millisecond, nil)
+
+	_, err := p.sampleNodeLatency(events.NodeCreatePodLatency)
+	assert.Error(t, err)
+}
+
+func TestRunPodLatency(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	p := Provider{Store: &s}
+	pod := &corev1.Pod{}
+
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeDeletePodLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(pod, events.PodDeletePodLatency).Return(scenario.ConstantLatency(time.Hour), nil)
+
+	// Only the pod adds latency, so the context times out
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.Error(t, p.runPodLatency(ctx, pod, events.PodDeletePodLatency))
+}
+
+func TestGetCorrespondingNodeLatencyFlag(t *testing.T) {
+	t.Parallel()
+
+	pairs := map[events.PodEventFlag]events.NodeEventFlag{
+		events.PodCreatePodLatency:    events.NodeCreatePodLatency,
+		events.PodUpdatePodLatency:    events.NodeUpdatePodLatency,
+		events.PodDeletePodLatency:    events.NodeDeletePodLatency,
+		events.PodGetPodLatency:       events.NodeGetPodLatency,
+		events.PodGetPodStatusLatency: events.NodeGetPodStatusLatency,
+	}
+
+	for podFlag, nodeFlag := range pairs {
+		flag, err := getCorrespondingNodeLatencyFlag(podFlag)
+		assert.NoError(t, err)
+		assert.Equal(t, nodeFlag, flag)
+	}
+
+	_, err := getCorrespondingNodeLatencyFlag(events.PodCreatePodResponse)
+	assert.Error(t, err)
+}
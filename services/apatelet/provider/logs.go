@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// emulatedLog is written by containers which don't have synthetic logs
+const emulatedLog = "This container is emulated by Apate\n"
+
+// logOptions determines which lines of the log of a container are written, and how
+type logOptions struct {
+	// Only the last lines are written if positive
+	tail int
+
+	// Only lines written at or after this time are written if it is set
+	since time.Time
+
+	// The output is truncated after this amount of bytes if positive
+	limitBytes int
+
+	// Whether every line is prefixed with the time it was written at
+	timestamps bool
+}
+
+func newLogOptions(opts api.ContainerLogOpts, now time.Time) logOptions {
+	options := logOptions{
+		tail:       opts.Tail,
+		limitBytes: opts.LimitBytes,
+		timestamps: opts.Timestamps,
+	}
+
+	if opts.Since > 0 {
+		options.since = now.Add(-opts.Since)
+	}
+
+	return options
+}
+
+// containerLog generates the synthetic log of the current run of a container
+type containerLog struct {
+	model *scenario.LogModel
+	data  scenario.LogLine
+	run   containerRun
+}
+
+// getContainerLog returns the synthetic log of the given container of the given pod, or false if it has none
+func (p *Provider) getContainerLog(pod *corev1.Pod, container string) (*containerLog, bool, error) {
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodLogs)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get pod logs flag")
+	}
+
+	model, ok := flag.(*scenario.LogModel)
+	if !ok {
+		return nil, false, errors.Errorf("invalid pod logs flag %v", flag)
+	}
+
+	if !model.IsSet() {
+		return nil, false, nil
+	}
+
+	log := &containerLog{
+		model: model,
+		data: scenario.LogLine{
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Container: container,
+		},
+	}
+
+	// Containers which haven't been started yet have an empty log
+	log.run, _ = p.restarts.current(pod.UID, container)
+	return log, true, nil
+}
+
+// containerLogs returns the log of the given container of the given pod
+func (p *Provider) containerLogs(pod *corev1.Pod, container string, options logOptions) (io.ReadCloser, error) {
+	log, ok, err := p.getContainerLog(pod, container)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get container log")
+	}
+
+	if !ok {
+		return ioutil.NopCloser(bytes.NewReader([]byte(emulatedLog))), nil
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		// Writing stops with an error once the reader has been closed, which is not reported anywhere
+		err := log.write(newLimitedWriter(writer, options.limitBytes), options, time.Now())
+		if errors.Is(err, errLimitReached) {
+			err = nil
+		}
+		_ = writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+// write writes the lines of the log which have been written at the given time
+func (l *containerLog) write(w io.Writer, options logOptions, now time.Time) error {
+	if l.run.startedAt.IsZero() {
+		return nil
+	}
+
+	end := now
+	if l.run.terminated != nil {
+		end = l.run.terminated.at
+	}
+
+	first := l.model.LinesBefore(l.run.startedAt, options.since)
+	last := l.model.LinesBefore(l.run.startedAt, end)
+
+	burst := 0
+	if t := l.run.terminated; t != nil && t.exitCode != 0 && !t.at.Before(options.since) && !t.at.After(now) {
+		burst = l.model.FailureBurst
+	}
+
+	// Skip the lines which don't fit in the tail, regular lines come before the burst
+	if skip := last - first + burst - options.tail; options.tail > 0 && skip > 0 {
+		first += skip
+		if first > last {
+			burst -= first - last
+			first = last
+		}
+	}
+
+	for line := first; line < last; line++ {
+		if err := l.writeLine(w, line, options); err != nil {
+			return err
+		}
+	}
+
+	for line := 0; line < burst; line++ {
+		data := l.data
+		data.Line = last + line
+		data.Time = l.run.terminated.at
+		data.ExitCode = l.run.terminated.exitCode
+
+		text, err := l.model.FailureLine(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate failure line")
+		}
+
+		if err := writeLogLine(w, text, data.Time, options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeLine writes the regular line with the given index
+func (l *containerLog) writeLine(w io.Writer, line int, options logOptions) error {
+	data := l.data
+	data.Line = line
+	data.Time = l.model.LineAt(l.run.startedAt, line)
+
+	text, err := l.model.Line(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate line")
+	}
+
+	return writeLogLine(w, text, data.Time, options)
+}
+
+// writeLogLine writes a line in the format of the kubelet, which prefixes it with its time if requested
+func writeLogLine(w io.Writer, text string, at time.Time, options logOptions) error {
+	if options.timestamps {
+		text = at.UTC().Format(time.RFC3339Nano) + " " + text
+	}
+
+	_, err := io.WriteString(w, text+"\n")
+	return err
+}
+
+// limitedWriter truncates everything written to it after a limit, if it has one
+type limitedWriter struct {
+	writer io.Writer
+
+	// The amount of bytes which may still be written, or a negative amount if there is no limit
+	remaining int
+}
+
+// errLimitReached stops writing a log once its limit has been reached
+var errLimitReached = errors.New("log limit reached")
+
+func newLimitedWriter(w io.Writer, limit int) *limitedWriter {
+	if limit <= 0 {
+		limit = -1
+	}
+
+	return &limitedWriter{writer: w, remaining: limit}
+}
+
+func (l *limitedWriter) Write(data []byte) (int, error) {
+	if l.remaining < 0 {
+		return l.writer.Write(data)
+	}
+
+	if len(data) < l.remaining {
+		l.remaining -= len(data)
+		return l.writer.Write(data)
+	}
+
+	n, err := l.writer.Write(data[:l.remaining])
+	l.remaining = 0
+	if err != nil {
+		return n, err
+	}
+
+	return n, errLimitReached
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
+)
+
+func createLog(t *testing.T, spec scenario.LogModelSpec, run containerRun) *containerLog {
+	model, err := scenario.ParseLogModel(spec)
+	assert.NoError(t, err)
+
+	return &containerLog{
+		model: model,
+		data:  scenario.LogLine{Pod: "pod", Container: "c"},
+		run:   run,
+	}
+}
+
+func writeLog(t *testing.T, log *containerLog, options logOptions, now time.Time) string {
+	var buffer bytes.Buffer
+	assert.NoError(t, log.write(&buffer, options, now))
+	return buffer.String()
+}
+
+func TestContainerLogTemplate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	log := createLog(t, scenario.LogModelSpec{Template: "{{.Container}} {{.Line}}", Rate: "2"}, containerRun{startedAt: start})
+
+	// Two lines per second
+	assert.Equal(t, "c 0\nc 1\nc 2\n", writeLog(t, log, logOptions{}, start.Add(1500*time.Millisecond)))
+
+	// Tail and since
+	assert.Equal(t, "c 8\nc 9\n", writeLog(t, log, logOptions{tail: 2}, start.Add(5*time.Second)))
+	assert.Equal(t, "c 6\nc 7\nc 8\nc 9\n", writeLog(t, log, logOptions{since: start.Add(3 * time.Second)}, start.Add(5*time.Second)))
+
+	// Timestamps are formatted like the kubelet does
+	assert.Equal(t, "2020-01-01T00:00:00.5Z c 1\n", writeLog(t, log, logOptions{tail: 1, timestamps: true}, start.Add(time.Second)))
+
+	// Containers which haven't started have an empty log
+	log.run = containerRun{}
+	assert.Equal(t, "", writeLog(t, log, logOptions{}, start.Add(time.Second)))
+}
+
+func TestContainerLogFailureBurst(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	run := containerRun{
+		startedAt:  start,
+		terminated: &termination{at: start.Add(2 * time.Second), exitCode: 3},
+	}
+	log := createLog(t, scenario.LogModelSpec{Lines: []string{"a", "b", "c"}, FailureBurst: 2, FailureTemplate: "failed {{.ExitCode}}"}, run)
+
+	// Replayed lines stop when the container terminates, after which the burst is written
+	assert.Equal(t, "a\nb\nfailed 3\nfailed 3\n", writeLog(t, log, logOptions{}, start.Add(time.Hour)))
+	assert.Equal(t, "b\nfailed 3\nfailed 3\n", writeLog(t, log, logOptions{tail: 3}, start.Add(time.Hour)))
+	assert.Equal(t, "failed 3\n", writeLog(t, log, logOptions{tail: 1}, start.Add(time.Hour)))
+
+	// Containers which succeed don't write a burst
+	run.terminated = &termination{at: start.Add(2 * time.Second)}
+	log.run = run
+	assert.Equal(t, "a\nb\n", writeLog(t, log, logOptions{}, start.Add(time.Hour)))
+}
+
+func TestContainerLogsLimitBytes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	pod := &corev1.Pod{}
+	pod.UID = "uid"
+	pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+	model, err := scenario.ParseLogModel(scenario.LogModelSpec{Template: "line {{.Line}}", Rate: "1000"})
+	assert.NoError(t, err)
+	ms.EXPECT().GetPodFlag(pod, events.PodLogs).Return(model, nil)
+
+	prov := &Provider{Store: &s, restarts: newRestartTracker()}
+
+	// The container has been running for a minute when its status was last requested
+	start := time.Now().Add(-time.Minute)
+	prov.restarts.evaluate(pod, corev1.Container{Name: "c"}, start, nil, nil, scenario.Probes{}, start, fixedRandom{})
+
+	logs, err := prov.containerLogs(pod, "c", logOptions{limitBytes: 10})
+	assert.NoError(t, err)
+
+	all, err := ioutil.ReadAll(logs)
+	assert.NoError(t, err)
+	assert.Equal(t, "line 0\nlin", string(all))
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+const (
+	// initialMetadataBackoff is the time after which a failed update of the node metadata is retried for the first time
+	initialMetadataBackoff = time.Second
+
+	// maxMetadataBackoff is the maximum time after which a failed update of the node metadata is retried, the backoff doubles up to it
+	maxMetadataBackoff = time.Minute
+)
+
+// metadataTracker keeps track of the labels, annotations and taints added to the node by its configuration
+type metadataTracker struct {
+	lock sync.Mutex
+
+	// What was last added to the node, so it can be removed once it is no longer set
+	applied nodeMetadata
+
+	// The time after which the last failed update is retried, 0 if the last update succeeded
+	backoff time.Duration
+
+	// Signals that the metadata has changed, so the node can be updated right away
+	changed chan struct{}
+}
+
+func newMetadataTracker() *metadataTracker {
+	return &metadataTracker{
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// notify signals that the metadata has changed, a pending signal of an earlier change covers this one as well
+func (m *metadataTracker) notify() {
+	select {
+	case m.changed <- struct{}{}:
+	default:
+	}
+}
+
+// changes returns the channel which signals changes of the metadata
+func (m *metadataTracker) changes() <-chan struct{} {
+	return m.changed
+}
+
+// retryLater signals a change once the backoff has passed, so a failed update is retried
+// The node may not exist yet, or the error may be transient, so the backoff doubles with every consecutive failure
+func (m *metadataTracker) retryLater() time.Duration {
+	m.lock.Lock()
+	if m.backoff == 0 {
+		m.backoff = initialMetadataBackoff
+	} else {
+		m.backoff *= 2
+	}
+
+	if m.backoff > maxMetadataBackoff {
+		m.backoff = maxMetadataBackoff
+	}
+	backoff := m.backoff
+	m.lock.Unlock()
+
+	time.AfterFunc(backoff, m.notify)
+	return backoff
+}
+
+// nodeMetadata are the labels, annotations and taints added to the node by its configuration
+type nodeMetadata struct {
+	labels      map[string]string
+	annotations map[string]string
+	taints      []corev1.Taint
+}
+
+func (p *Provider) getNodeMetadata() (nodeMetadata, error) {
+	labels, err := p.getStringMapFlag(events.NodeLabels)
+	if err != nil {
+		return nodeMetadata{}, errors.Wrap(err, "failed to get node labels")
+	}
+
+	annotations, err := p.getStringMapFlag(events.NodeAnnotations)
+	if err != nil {
+		return nodeMetadata{}, errors.Wrap(err, "failed to get node annotations")
+	}
+
+	flag, err := (*p.Store).GetNodeFlag(events.NodeTaints)
+	if err != nil {
+		return nodeMetadata{}, errors.Wrap(err, "failed to get node taints flag")
+	}
+
+	taints, ok := flag.([]corev1.Taint)
+	if !ok {
+		return nodeMetadata{}, errors.Errorf("invalid node taints flag %v", flag)
+	}
+
+	return nodeMetadata{labels: labels, annotations: annotations, taints: taints}, nil
+}
+
+func (p *Provider) getStringMapFlag(id events.NodeEventFlag) (map[string]string, error) {
+	flag, err := (*p.Store).GetNodeFlag(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get node flag %v", id)
+	}
+
+	values, ok := flag.(map[string]string)
+	if !ok {
+		return nil, errors.Errorf("invalid node flag %v: %v", id, flag)
+	}
+
+	return values, nil
+}
+
+// applyNodeMetadata adds the configured labels, annotations and taints to the given node, after removing those which
+// were added before. Labels and taints which are set by Apate itself, or by others, are left untouched
+// It returns what has been added to the node
+func (p *Provider) applyNodeMetadata(node *corev1.Node, previous, metadata nodeMetadata) nodeMetadata {
+	own := p.objectMeta()
+	node.Labels = replaceEntries(node.Labels, previous.labels, metadata.labels, own.Labels)
+	node.Annotations = replaceEntries(node.Annotations, previous.annotations, metadata.annotations, own.Annotations)
+
+	// The taint set by Apate itself is never added or removed here
+	ownTaints := p.spec().Taints
+	var added []corev1.Taint
+	for _, taint := range metadata.taints {
+		if !containsTaint(ownTaints, taint) {
+			added = append(added, taint)
+		}
+	}
+
+	var taints []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if !containsTaint(previous.taints, taint) && !containsTaint(added, taint) {
+			taints = append(taints, taint)
+		}
+	}
+	node.Spec.Taints = append(taints, added...)
+
+	return nodeMetadata{labels: metadata.labels, annotations: metadata.annotations, taints: added}
+}
+
+// replaceEntries removes the previous entries from current and adds the new ones, except for the entries which are owned by Apate
+func replaceEntries(current, previous, next, owned map[string]string) map[string]string {
+	if current == nil {
+		current = make(map[string]string)
+	}
+
+	for k := range previous {
+		if _, ok := owned[k]; !ok {
+			delete(current, k)
+		}
+	}
+
+	for k, v := range next {
+		if _, ok := owned[k]; !ok {
+			current[k] = v
+		}
+	}
+
+	return current
+}
+
+// containsTaint returns whether the given taints contain a taint with the same key and effect, like kubernetes compares them
+func containsTaint(taints []corev1.Taint, taint corev1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateNodeMetadata updates the labels, annotations and taints of the node in kubernetes
+// The status of the node is updated through the node controller, which leaves the rest of the node as is
+func (p *Provider) updateNodeMetadata() error {
+	if p.Nodes == nil {
+		return nil
+	}
+
+	p.metadata.lock.Lock()
+	defer p.metadata.lock.Unlock()
+
+	metadata, err := p.getNodeMetadata()
+	if err != nil {
+		return errors.Wrap(err, "failed to get node metadata")
+	}
+
+	// Other components update the node as well, so the update is retried when it is outdated
+	var applied nodeMetadata
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := p.Nodes.Get(p.NodeInfo.Name, metav1.GetOptions{})
+		if err != nil {
+			return err // Don't wrap, conflicts should be detected by the retry
+		}
+
+		applied = p.applyNodeMetadata(node, p.metadata.applied, metadata)
+
+		_, err = p.Nodes.Update(node)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update node %v", p.NodeInfo.Name)
+	}
+
+	p.metadata.applied = applied
+	p.metadata.backoff = 0
+	return nil
+}
+
+// watchNodeMetadata registers listeners which update the node once its labels, annotations or taints change
+func (p *Provider) watchNodeMetadata() {
+	for _, flag := range []events.NodeEventFlag{events.NodeLabels, events.NodeAnnotations, events.NodeTaints} {
+		(*p.Store).AddNodeFlagListener(flag, func(interface{}) {
+			p.metadata.notify()
+		})
+	}
+}
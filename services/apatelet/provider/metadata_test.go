@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
+	"github.com/atlarge-research/apate/pkg/kubernetes/node"
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+)
+
+func TestUpdateNodeMetadata(t *testing.T) {
+	t.Parallel()
+
+	st := store.NewStore()
+	info, err := node.NewInfo("apatelet", "agent", "apatelet-x", "42", "my/apate")
+	assert.NoError(t, err)
+
+	// Labels and taints of others are left as is
+	nodeControllerTaint := corev1.Taint{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute}
+	clientSet := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "apatelet-x",
+			Labels: map[string]string{"kubectl": "label", "type": "apatelet"},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: nodeconfigv1.EmulatedLabel, Effect: corev1.TaintEffectNoSchedule}, nodeControllerTaint},
+		},
+	})
+
+	prov := Provider{
+		Store:     &st,
+		NodeInfo:  &info,
+		Resources: &scenario.NodeResources{UUID: uuid.New()},
+		Nodes:     clientSet.CoreV1().Nodes(),
+		metadata:  newMetadataTracker(),
+	}
+	prov.watchNodeMetadata()
+
+	dedicated := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute}
+	st.SetNodeFlags(store.Flags{
+		events.NodeLabels:      map[string]string{"pool": "gpu", "type": "overridden"},
+		events.NodeAnnotations: map[string]string{"team": "infra"},
+		events.NodeTaints:      []corev1.Taint{dedicated},
+	})
+
+	// Changes are signalled to the node status loop
+	select {
+	case <-prov.metadata.changes():
+	default:
+		assert.Fail(t, "metadata change was not signalled")
+	}
+
+	assert.NoError(t, prov.updateNodeMetadata())
+
+	updated, err := clientSet.CoreV1().Nodes().Get("apatelet-x", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"kubectl": "label", "type": "apatelet", "pool": "gpu"}, updated.Labels)
+	assert.Equal(t, map[string]string{"team": "infra"}, updated.Annotations)
+	assert.Equal(t, []corev1.Taint{{Key: nodeconfigv1.EmulatedLabel, Effect: corev1.TaintEffectNoSchedule}, nodeControllerTaint, dedicated}, updated.Spec.Taints)
+
+	// Removing the labels, annotations and taints only removes those which were added
+	st.SetNodeFlags(store.Flags{
+		events.NodeLabels:      map[string]string{},
+		events.NodeAnnotations: map[string]string{},
+		events.NodeTaints:      []corev1.Taint{},
+	})
+	assert.NoError(t, prov.updateNodeMetadata())
+
+	updated, err = clientSet.CoreV1().Nodes().Get("apatelet-x", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"kubectl": "label", "type": "apatelet"}, updated.Labels)
+	assert.Empty(t, updated.Annotations)
+	assert.Equal(t, []corev1.Taint{{Key: nodeconfigv1.EmulatedLabel, Effect: corev1.TaintEffectNoSchedule}, nodeControllerTaint}, updated.Spec.Taints)
+}
+
+func TestUpdateNodeMetadataRetry(t *testing.T) {
+	t.Parallel()
+
+	st := store.NewStore()
+	info, err := node.NewInfo("apatelet", "agent", "apatelet-x", "42", "my/apate")
+	assert.NoError(t, err)
+
+	// The node doesn't exist yet
+	clientSet := fake.NewSimpleClientset()
+
+	prov := Provider{
+		Store:     &st,
+		NodeInfo:  &info,
+		Resources: &scenario.NodeResources{UUID: uuid.New()},
+		Nodes:     clientSet.CoreV1().Nodes(),
+		metadata:  newMetadataTracker(),
+	}
+
+	assert.Error(t, prov.updateNodeMetadata())
+
+	// The update is retried after a backoff, which doubles with every failure
+	assert.Equal(t, initialMetadataBackoff, prov.metadata.retryLater())
+	select {
+	case <-prov.metadata.changes():
+	case <-time.After(2 * initialMetadataBackoff):
+		assert.Fail(t, "retry was not signalled")
+	}
+	assert.Equal(t, 2*initialMetadataBackoff, prov.metadata.retryLater())
+
+	// Once the update succeeds, the backoff starts over
+	_, err = clientSet.CoreV1().Nodes().Create(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "apatelet-x"}})
+	assert.NoError(t, err)
+	assert.NoError(t, prov.updateNodeMetadata())
+	assert.Equal(t, initialMetadataBackoff, prov.metadata.retryLater())
+}
@@ -24,9 +24,6 @@ import (
 )
 
 const (
-	memThresh      = 0.85
-	diskThresh     = 0.85
-	diskFullThresh = 0.96
 	updateInterval = 30 * time.Second
 )
 
@@ -35,10 +32,14 @@ type nodeConditions struct {
 	outOfDisk      condition.NodeCondition
 	memoryPressure condition.NodeCondition
 	diskPressure   condition.NodeCondition
+	pidPressure    condition.NodeCondition
 
-	// Unused conditions, may be implement in a later version
+	// The network is always available, unless this condition is forced
 	networkUnavailable condition.NodeCondition
-	pidPressure        condition.NodeCondition
+
+	// Conditions which are not standard, such as those set by the node problem detector
+	// They are only reported as long as they are forced, in the order in which they are forced
+	custom []condition.NodeCondition
 }
 
 func (p *Provider) getPingResponse() (scenario.Response, error) {
@@ -47,9 +48,9 @@ func (p *Provider) getPingResponse() (scenario.Response, error) {
 		return scenario.ResponseUnset, errors.Errorf("unable to retrieve ping flag %v", err)
 	}
 
-	flag, ok := rawFlag.(scenario.Response)
-	if !ok {
-		return scenario.ResponseUnset, errors.Errorf("invalid ping flag %v", rawFlag)
+	flag, err := drawResponse(p, rawFlag)
+	if err != nil {
+		return scenario.ResponseUnset, errors.Wrap(err, "invalid ping flag")
 	}
 
 	return flag, nil
@@ -57,7 +58,7 @@ func (p *Provider) getPingResponse() (scenario.Response, error) {
 
 // Ping will react to ping based on the given set flag
 func (p *Provider) Ping(ctx context.Context) error {
-	if err := p.runLatency(ctx); err != nil {
+	if err := p.runLatency(ctx, events.NodePingLatency); err != nil {
 		err = errors.Wrap(err, "failed to run latency (Ping)")
 		log.Println(err)
 		return err
@@ -92,6 +93,29 @@ func (p *Provider) NotifyNodeStatus(ctx context.Context, cb func(*corev1.Node))
 				return
 			case <-time.After(updateInterval):
 				p.updateConditions(cb)
+			case <-p.nodeCapacity.changes():
+				// Resources changed by tasks are reported right away
+				p.updateConditions(cb)
+			case <-p.metadata.changes():
+				if err := p.updateNodeMetadata(); err != nil {
+					backoff := p.metadata.retryLater()
+					log.Printf("failed to update node metadata, retrying in %v: %v", backoff, err)
+				}
+			}
+		}
+	}()
+
+	// Resource usage may change gradually, so the stats summary is kept up to date in between pod updates
+	go func() {
+		ticker := time.NewTicker(usageUpdateInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.updateStatsSummary()
 			}
 		}
 	}()
@@ -106,6 +130,15 @@ func (p *Provider) ConfigureNode(ctx context.Context, node *corev1.Node) {
 
 	node.Spec = p.spec()
 	node.ObjectMeta = p.objectMeta()
+
+	// If this fails, the node starts without the labels, annotations and taints of its configuration
+	if metadata, err := p.getNodeMetadata(); err != nil {
+		log.Printf("failed to get node metadata: %v", err)
+	} else {
+		p.metadata.lock.Lock()
+		p.metadata.applied = p.applyNodeMetadata(node, nodeMetadata{}, metadata)
+		p.metadata.lock.Unlock()
+	}
 	node.Status = p.nodeStatus()
 	p.Node = node.DeepCopy()
 }
@@ -127,35 +160,144 @@ func (p *Provider) updateConditions(cb func(*corev1.Node)) {
 		return
 	}
 
-	// Set bools
-	memPressure := float32(stats.Node.UsageBytesMemory) > float32(p.Resources.Memory)*memThresh
-	diskPressure := float32(stats.Node.UsedBytesEphemeral) > float32(p.Resources.Storage)*diskThresh
-	diskFull := float32(stats.Node.UsedBytesEphemeral) > float32(p.Resources.Storage)*diskFullThresh
-
-	// Set conditions and update node
-	p.Node.Status.Conditions = []corev1.NodeCondition{
-		p.Conditions.ready.Update(!diskFull),
-		p.Conditions.outOfDisk.Update(diskFull),
-		p.Conditions.memoryPressure.Update(memPressure),
-		p.Conditions.diskPressure.Update(diskPressure),
-		p.Conditions.networkUnavailable.Update(false),
-		p.Conditions.pidPressure.Update(false),
+	thresholds, err := p.getNodeConditions()
+	if err != nil {
+		log.Printf("failed to update node conditions: %v", err)
+		return
 	}
+
+	processes, err := p.processes()
+	if err != nil {
+		log.Printf("failed to update node conditions: %v", err)
+		return
+	}
+
+	// Set bools
+	resources := p.resources()
+	memPressure := float64(stats.Node.UsageBytesMemory) > float64(resources.Memory)*thresholds.MemoryPressure
+	diskPressure := float64(stats.Node.UsedBytesEphemeral) > float64(resources.EphemeralStorage)*thresholds.DiskPressure
+	diskFull := float64(stats.Node.UsedBytesEphemeral) > float64(resources.EphemeralStorage)*thresholds.OutOfDisk
+	pidPressure := float64(processes) > float64(thresholds.MaxPIDs)*thresholds.PIDPressure
+
+	// Set conditions, the forced ones take precedence
+	forced := forcedConditions(thresholds.Forced)
+	p.Conditions.ready.Set(!diskFull, forced[p.Conditions.ready.Type()])
+	p.Conditions.outOfDisk.Set(diskFull, forced[p.Conditions.outOfDisk.Type()])
+	p.Conditions.memoryPressure.Set(memPressure, forced[p.Conditions.memoryPressure.Type()])
+	p.Conditions.diskPressure.Set(diskPressure, forced[p.Conditions.diskPressure.Type()])
+	p.Conditions.networkUnavailable.Set(false, forced[p.Conditions.networkUnavailable.Type()])
+	p.Conditions.pidPressure.Set(pidPressure, forced[p.Conditions.pidPressure.Type()])
+	p.Conditions.forceCustom(thresholds.Forced)
+
+	// Update node
 	p.Node.Status = p.nodeStatus()
 
 	cb(p.Node.DeepCopy())
 }
 
+func (p *Provider) getNodeConditions() (*scenario.NodeConditions, error) {
+	flag, err := (*p.Store).GetNodeFlag(events.NodeConditions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node conditions flag")
+	}
+
+	conditions, ok := flag.(*scenario.NodeConditions)
+	if !ok {
+		return nil, errors.Errorf("invalid node conditions flag %v", flag)
+	}
+
+	return conditions, nil
+}
+
+// processes returns the amount of processes running in the pods on the node
+func (p *Provider) processes() (int64, error) {
+	total := int64(0)
+	for _, pod := range p.Pods.GetAllPods() {
+		flag, err := (*p.Store).GetPodFlag(pod, events.PodProcesses)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get pod processes flag")
+		}
+
+		processes, ok := flag.(int64)
+		if !ok {
+			return 0, errors.Errorf("invalid pod processes flag %v", flag)
+		}
+
+		total += processes
+	}
+
+	return total, nil
+}
+
+// standard returns the standard conditions of the node, in the order in which they are reported
+func (n *nodeConditions) standard() []*condition.NodeCondition {
+	return []*condition.NodeCondition{
+		&n.ready,
+		&n.outOfDisk,
+		&n.memoryPressure,
+		&n.diskPressure,
+		&n.networkUnavailable,
+		&n.pidPressure,
+	}
+}
+
+// forcedConditions returns the forced conditions by their type, later conditions take precedence
+func forcedConditions(forced []scenario.ForcedCondition) map[corev1.NodeConditionType]*condition.Forced {
+	result := make(map[corev1.NodeConditionType]*condition.Forced, len(forced))
+	for _, f := range forced {
+		result[corev1.NodeConditionType(f.Type)] = &condition.Forced{
+			Status:  corev1.ConditionStatus(f.Status),
+			Reason:  f.Reason,
+			Message: f.Message,
+		}
+	}
+
+	return result
+}
+
+// forceCustom forces the given conditions which are not standard, custom conditions which are no longer forced are removed
+func (n *nodeConditions) forceCustom(forced []scenario.ForcedCondition) {
+	var custom []condition.NodeCondition
+
+	for _, f := range forced {
+		conditionType := corev1.NodeConditionType(f.Type)
+		status := corev1.ConditionStatus(f.Status)
+
+		standard := false
+		for _, c := range n.standard() {
+			standard = standard || c.Type() == conditionType
+		}
+
+		if standard {
+			continue
+		}
+
+		// Custom conditions keep their transition time as long as they stay forced
+		c := condition.New(false, conditionType)
+		for _, existing := range n.custom {
+			if existing.Type() == conditionType {
+				c = existing
+			}
+		}
+
+		c.Force(status, f.Reason, f.Message)
+		custom = append(custom, c)
+	}
+
+	n.custom = custom
+}
+
 func (p *Provider) nodeConditions() []corev1.NodeCondition {
-	// Return static thumbs-up values for all conditions.
-	return []corev1.NodeCondition{
-		p.Conditions.ready.Get(),
-		p.Conditions.outOfDisk.Get(),
-		p.Conditions.memoryPressure.Get(),
-		p.Conditions.diskPressure.Get(),
-		p.Conditions.networkUnavailable.Get(),
-		p.Conditions.pidPressure.Get(),
+	var conditions []corev1.NodeCondition
+	for _, c := range p.Conditions.standard() {
+		conditions = append(conditions, c.Get())
+	}
+
+	for i := range p.Conditions.custom {
+		conditions = append(conditions, p.Conditions.custom[i].Get())
 	}
+
+	return conditions
 }
 
 func (p *Provider) nodeStatus() corev1.NodeStatus {
@@ -169,6 +311,7 @@ func (p *Provider) nodeStatus() corev1.NodeStatus {
 		Capacity:        p.capacity(),
 		Conditions:      p.nodeConditions(),
 		Allocatable:     p.allocatable(),
+		Images:          p.nodeImages(),
 	}
 }
 
@@ -232,20 +375,22 @@ func (p *Provider) nodeDaemonEndpoints() corev1.NodeDaemonEndpoints {
 }
 
 func (p *Provider) capacity() corev1.ResourceList {
+	resources := p.resources()
+
 	var cpu resource.Quantity
-	cpu.Set(p.Resources.CPU)
+	cpu.Set(resources.CPU)
 
 	var mem resource.Quantity
-	mem.Set(p.Resources.Memory)
+	mem.Set(resources.Memory)
 
 	var pods resource.Quantity
-	pods.Set(p.Resources.MaxPods)
+	pods.Set(resources.MaxPods)
 
 	var storage resource.Quantity
-	storage.Set(p.Resources.Storage)
+	storage.Set(resources.Storage)
 
 	var ephemeralStorage resource.Quantity
-	ephemeralStorage.Set(p.Resources.EphemeralStorage)
+	ephemeralStorage.Set(resources.EphemeralStorage)
 
 	return corev1.ResourceList{
 		corev1.ResourceCPU:              cpu,
@@ -263,14 +408,16 @@ func (p *Provider) allocatable() corev1.ResourceList {
 		return corev1.ResourceList{}
 	}
 
+	resources := p.resources()
+
 	var cpu resource.Quantity
-	cpu.Set(p.Resources.CPU - int64(summary.Node.UsageNanoCores))
+	cpu.Set(resources.CPU - int64(summary.Node.UsageNanoCores))
 
 	var mem resource.Quantity
 	mem.Set(int64(summary.Node.AvailableBytesMemory))
 
 	var pods resource.Quantity
-	pods.Set(p.Resources.MaxPods - int64(summary.Node.Pods))
+	pods.Set(resources.MaxPods - int64(summary.Node.Pods))
 
 	var storage resource.Quantity
 	storage.Set(int64(summary.Node.AvailableBytesStorage))
@@ -46,7 +46,8 @@ func TestPing(t *testing.T) {
 		Store: &st,
 	}
 
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodePingLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetNodeFlag(events.NodePingResponse).Return(scenario.ResponseNormal, nil)
 
 	res := prov.Ping(context.Background())
@@ -65,7 +66,8 @@ func TestPingError(t *testing.T) {
 	pmm := mock_podmanager.NewMockPodManager(ctrl)
 	var pm podmanager.PodManager = pmm
 
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodePingLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetNodeFlag(events.NodePingResponse).Return(scenario.ResponseError, nil)
 
 	prov := Provider{
@@ -90,6 +92,15 @@ func TestConfigureNode(t *testing.T) {
 	var pm podmanager.PodManager = pmm
 
 	pmm.EXPECT().GetAllPods().Return([]*corev1.Pod{})
+	ms.EXPECT().GetNodeFlag(events.NodeImages).Return(&scenario.NodeImages{
+		DefaultSize: 100,
+		Cached:      []string{"nginx"},
+	}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeLabels).Return(map[string]string{"pool": "gpu", "type": "overridden"}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAnnotations).Return(map[string]string{"team": "infra"}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeTaints).Return([]corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+	}, nil)
 
 	u := uuid.UUID{}
 	prov := Provider{
@@ -132,6 +143,9 @@ func TestConfigureNode(t *testing.T) {
 		},
 		DisableTaints: false,
 		Stats:         &Stats{},
+		images:        newImageCache(),
+		nodeCapacity:  newCapacityTracker(),
+		metadata:      newMetadataTracker(),
 	}
 
 	newNode := &corev1.Node{}
@@ -144,6 +158,11 @@ func TestConfigureNode(t *testing.T) {
 				Key:    nodeconfigv1.EmulatedLabel,
 				Effect: corev1.TaintEffectNoSchedule,
 			},
+			{
+				Key:    "dedicated",
+				Value:  "gpu",
+				Effect: corev1.TaintEffectNoExecute,
+			},
 		},
 	}, newNode.Spec)
 
@@ -158,6 +177,10 @@ func TestConfigureNode(t *testing.T) {
 			nodeconfigv1.NodeConfigurationLabel:          "apate",
 			nodeconfigv1.NodeConfigurationLabelNamespace: "my",
 			nodeconfigv1.NodeIDLabel:                     u.String(),
+			"pool":                                       "gpu",
+		},
+		Annotations: map[string]string{
+			"team": "infra",
 		},
 	}, newNode.ObjectMeta)
 
@@ -189,12 +212,19 @@ func TestConfigureNode(t *testing.T) {
 		KubeletVersion: "42",
 		Architecture:   "amd64",
 	}, newNode.Status.NodeInfo)
+
+	assert.EqualValues(t, []corev1.ContainerImage{
+		{
+			Names:     []string{"nginx"},
+			SizeBytes: 100,
+		},
+	}, newNode.Status.Images)
 }
 
 func TestUpdateConditionNoPressure(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := createProviderForUpdateConditionTests(t, 500, 2048, 1024)
+	prov, ctrl := createProviderForUpdateConditionTests(t, 500, 2048, 1024, 0, scenario.DefaultNodeConditions())
 	defer ctrl.Finish()
 
 	prov.updateConditions(func(node *corev1.Node) {
@@ -221,10 +251,10 @@ func TestUpdateConditionNoPressure(t *testing.T) {
 func TestUpdateConditionMemoryAndDiskPressure(t *testing.T) {
 	t.Parallel()
 
-	mt := memThresh * 4096
-	dt := diskThresh * 2048
+	mt := scenario.DefaultNodeConditions().MemoryPressure * 4096
+	dt := scenario.DefaultNodeConditions().DiskPressure * 2048
 
-	prov, ctrl := createProviderForUpdateConditionTests(t, 5000, int64(mt)+2, int64(dt)+2)
+	prov, ctrl := createProviderForUpdateConditionTests(t, 5000, int64(mt)+2, int64(dt)+2, 0, scenario.DefaultNodeConditions())
 	defer ctrl.Finish()
 
 	prov.updateConditions(func(node *corev1.Node) {
@@ -251,10 +281,10 @@ func TestUpdateConditionMemoryAndDiskPressure(t *testing.T) {
 func TestUpdateConditionDiskFull(t *testing.T) {
 	t.Parallel()
 
-	mtf := memThresh * 4096
-	dtf := diskFullThresh * 2048
+	mtf := scenario.DefaultNodeConditions().MemoryPressure * 4096
+	dtf := scenario.DefaultNodeConditions().OutOfDisk * 2048
 
-	prov, ctrl := createProviderForUpdateConditionTests(t, 5000, int64(mtf)+2, int64(dtf)+2)
+	prov, ctrl := createProviderForUpdateConditionTests(t, 5000, int64(mtf)+2, int64(dtf)+2, 0, scenario.DefaultNodeConditions())
 	defer ctrl.Finish()
 
 	prov.updateConditions(func(node *corev1.Node) {
@@ -278,7 +308,95 @@ func TestUpdateConditionDiskFull(t *testing.T) {
 	})
 }
 
-func createProviderForUpdateConditionTests(t *testing.T, podCPU, podMemory, podStorage int64) (Provider, *gomock.Controller) {
+func TestUpdateConditionPIDPressure(t *testing.T) {
+	t.Parallel()
+
+	conditions, err := scenario.ParseNodeConditions(scenario.NodeConditionsSpec{PIDPressure: "0.5", MaxPIDs: 100})
+	assert.NoError(t, err)
+
+	prov, ctrl := createProviderForUpdateConditionTests(t, 500, 2048, 1024, 51, conditions)
+	defer ctrl.Finish()
+
+	prov.updateConditions(func(node *corev1.Node) {
+		assert.EqualValues(t, corev1.ConditionTrue, node.Status.Conditions[0].Status)
+		assert.EqualValues(t, corev1.NodeReady, node.Status.Conditions[0].Type)
+
+		assert.EqualValues(t, corev1.ConditionTrue, node.Status.Conditions[5].Status)
+		assert.EqualValues(t, corev1.NodePIDPressure, node.Status.Conditions[5].Type)
+	})
+}
+
+func TestUpdateConditionForced(t *testing.T) {
+	t.Parallel()
+
+	conditions, err := scenario.ParseNodeConditions(scenario.NodeConditionsSpec{
+		Forced: []scenario.ForcedCondition{
+			{Type: "NetworkUnavailable", Status: "True", Reason: "NoRouteCreated"},
+			{Type: "KernelDeadlock", Status: "True", Reason: "DockerHung", Message: "task docker blocked"},
+		},
+	})
+	assert.NoError(t, err)
+
+	prov, ctrl := createProviderForUpdateConditionTests(t, 500, 2048, 1024, 0, conditions)
+	defer ctrl.Finish()
+
+	prov.updateConditions(func(node *corev1.Node) {
+		assert.Len(t, node.Status.Conditions, 7)
+
+		assert.EqualValues(t, corev1.ConditionTrue, node.Status.Conditions[4].Status)
+		assert.EqualValues(t, corev1.NodeNetworkUnavailable, node.Status.Conditions[4].Type)
+		assert.EqualValues(t, "NoRouteCreated", node.Status.Conditions[4].Reason)
+
+		assert.EqualValues(t, corev1.NodeCondition{
+			Type:               "KernelDeadlock",
+			Status:             corev1.ConditionTrue,
+			LastHeartbeatTime:  node.Status.Conditions[6].LastHeartbeatTime,
+			LastTransitionTime: node.Status.Conditions[6].LastTransitionTime,
+			Reason:             "DockerHung",
+			Message:            "task docker blocked",
+		}, node.Status.Conditions[6])
+	})
+
+	// Custom conditions disappear once they are no longer forced
+	prov.Conditions.forceCustom(nil)
+	assert.Len(t, prov.nodeConditions(), 6)
+}
+
+func TestUpdateConditionForcedTransition(t *testing.T) {
+	t.Parallel()
+
+	// The forced status differs from the status the condition would have otherwise
+	conditions, err := scenario.ParseNodeConditions(scenario.NodeConditionsSpec{
+		Forced: []scenario.ForcedCondition{
+			{Type: "Ready", Status: "False", Reason: "KubeletNotReady"},
+		},
+	})
+	assert.NoError(t, err)
+
+	prov, ctrl := createProviderForUpdateConditionTests(t, 500, 2048, 1024, 0, conditions)
+	defer ctrl.Finish()
+
+	var transition metav1.Time
+	prov.updateConditions(func(node *corev1.Node) {
+		assert.EqualValues(t, corev1.ConditionFalse, node.Status.Conditions[0].Status)
+		transition = node.Status.Conditions[0].LastTransitionTime
+	})
+
+	ms := (*prov.Store).(*mock_store.MockStore)
+	ms.EXPECT().GetNodeFlag(events.NodePingResponse).Return(scenario.ResponseNormal, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeImages).Return(&scenario.NodeImages{}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeConditions).Return(conditions, nil)
+	prov.Pods.(*mock_podmanager.MockPodManager).EXPECT().GetAllPods().Return(nil)
+
+	// The condition stays forced, so it keeps its transition time
+	time.Sleep(time.Millisecond)
+	prov.updateConditions(func(node *corev1.Node) {
+		assert.EqualValues(t, corev1.ConditionFalse, node.Status.Conditions[0].Status)
+		assert.Equal(t, transition, node.Status.Conditions[0].LastTransitionTime)
+	})
+}
+
+func createProviderForUpdateConditionTests(t *testing.T, podCPU, podMemory, podStorage, processes int64, conditions *scenario.NodeConditions) (Provider, *gomock.Controller) {
 	ctrl := gomock.NewController(t)
 	// no defer ctrl.Finish() here because this function returns the ctrl
 
@@ -296,7 +414,7 @@ func createProviderForUpdateConditionTests(t *testing.T, podCPU, podMemory, podS
 		Spec:       corev1.PodSpec{},
 		Status:     corev1.PodStatus{},
 	}
-	pmm.EXPECT().GetAllPods().Return([]*corev1.Pod{&pod})
+	pmm.EXPECT().GetAllPods().Return([]*corev1.Pod{&pod}).Times(2)
 
 	cores := uint64(podCPU)
 	memory := uint64(podMemory)
@@ -308,6 +426,9 @@ func createProviderForUpdateConditionTests(t *testing.T, podCPU, podMemory, podS
 	}, nil)
 
 	ms.EXPECT().GetNodeFlag(events.NodePingResponse).Return(scenario.ResponseNormal, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeImages).Return(&scenario.NodeImages{}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeConditions).Return(conditions, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodProcesses).Return(processes, nil)
 
 	u := uuid.UUID{}
 	prov := Provider{
@@ -344,6 +465,9 @@ func createProviderForUpdateConditionTests(t *testing.T, podCPU, podMemory, podS
 		Cfg: &provider.InitConfig{
 			DaemonPort: 100,
 		},
+		usage:        newUsageTracker(),
+		images:       newImageCache(),
+		nodeCapacity: newCapacityTracker(),
 	}
 
 	prov.updateStatsSummary()
@@ -1,10 +1,8 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"io"
-	"io/ioutil"
 	"log"
 	"time"
 
@@ -28,7 +26,7 @@ func (p *Provider) CreatePod(ctx context.Context, pod *corev1.Pod) error {
 		return errors.Wrap(err, "context cancelled in CreatePod")
 	}
 
-	return p.createOrUpdate(ctx, pod, events.PodCreatePodResponse, true)
+	return p.createOrUpdate(ctx, pod, events.PodCreatePodResponse, events.PodCreatePodLatency, true)
 }
 
 // UpdatePod takes a Kubernetes Pod and updates it within the provider.
@@ -41,11 +39,11 @@ func (p *Provider) UpdatePod(ctx context.Context, pod *corev1.Pod) error {
 		return errors.Wrap(err, "context cancelled in UpdatePod")
 	}
 
-	return p.createOrUpdate(ctx, pod, events.PodUpdatePodResponse, false)
+	return p.createOrUpdate(ctx, pod, events.PodUpdatePodResponse, events.PodUpdatePodLatency, false)
 }
 
-func (p *Provider) createOrUpdate(ctx context.Context, pod *corev1.Pod, pf events.PodEventFlag, updateStartTime bool) error {
-	if err := p.runLatency(ctx); err != nil {
+func (p *Provider) createOrUpdate(ctx context.Context, pod *corev1.Pod, pf events.PodEventFlag, lf events.PodEventFlag, updateStartTime bool) error {
+	if err := p.runPodLatency(ctx, pod, lf); err != nil {
 		err = errors.Wrap(err, "failed to run latency (Create or Update)")
 		log.Println(err)
 		return err
@@ -78,6 +76,7 @@ func updateMap(p *Provider, pod *corev1.Pod, updateStartTime bool) func() (inter
 			pod.Status.StartTime = &now
 		}
 		p.Pods.AddPod(pod)
+		(*p.Store).AddPod(pod)
 		return nil, nil
 	}
 }
@@ -92,7 +91,7 @@ func (p *Provider) DeletePod(ctx context.Context, pod *corev1.Pod) error {
 		return errors.Wrap(err, "context cancelled in DeletePod")
 	}
 
-	if err := p.runLatency(ctx); err != nil {
+	if err := p.runPodLatency(ctx, pod, events.PodDeletePodLatency); err != nil {
 		err = errors.Wrap(err, "failed to run latency DeletePod")
 		log.Println(err)
 		return err
@@ -101,6 +100,11 @@ func (p *Provider) DeletePod(ctx context.Context, pod *corev1.Pod) error {
 	_, err := podResponse(
 		responseArgs{ctx, p, func() (interface{}, error) {
 			p.Pods.DeletePod(pod)
+			(*p.Store).RemovePod(pod)
+			p.usage.remove(pod.UID)
+			p.restarts.remove(pod.UID)
+			p.startups.remove(pod.UID)
+			p.runtimes.remove(pod.UID)
 			return nil, nil
 		}},
 		pod,
@@ -128,13 +132,13 @@ func (p *Provider) GetPod(ctx context.Context, namespace, name string) (*corev1.
 		return nil, errors.Wrap(err, "context cancelled in GetPod")
 	}
 
-	if err := p.runLatency(ctx); err != nil {
+	pod, ok := p.Pods.GetPodByName(namespace, name)
+	if err := p.runPodLatency(ctx, pod, events.PodGetPodLatency); err != nil {
 		err = errors.Wrap(err, "failed to run latency GetPod")
 		log.Println(err)
 		return nil, err
 	}
 
-	pod, ok := p.Pods.GetPodByName(namespace, name)
 	if !ok {
 		return nil, nil
 	}
@@ -170,7 +174,7 @@ func (p *Provider) GetPods(ctx context.Context) ([]*corev1.Pod, error) {
 		return nil, errors.Wrap(err, "context cancelled in GetPods")
 	}
 
-	if err := p.runLatency(ctx); err != nil {
+	if err := p.runLatency(ctx, events.NodeGetPodsLatency); err != nil {
 		err = errors.Wrap(err, "failed to run latency in GetPods")
 		log.Println(err)
 		return nil, err
@@ -200,41 +204,32 @@ func (p *Provider) GetPods(ctx context.Context) ([]*corev1.Pod, error) {
 }
 
 // GetContainerLogs retrieves the log of a specific container.
-func (p *Provider) GetContainerLogs(_ context.Context, ns, name, _ string, _ api.ContainerLogOpts) (io.ReadCloser, error) {
+func (p *Provider) GetContainerLogs(ctx context.Context, ns, name, container string, opts api.ContainerLogOpts) (io.ReadCloser, error) {
 	if p.Environment.DebugEnabled {
 		log.Printf("GetContainerLogs for %s/%s\n", ns, name)
 	}
 
-	// We return empty string as the emulated containers don't have a log.
-	return ioutil.NopCloser(bytes.NewReader([]byte("This container is emulated by Apate\n"))), nil
+	pod, ok := p.Pods.GetPodByName(ns, name)
+	if !ok {
+		return nil, errors.Errorf("unable to find pod %s/%s", ns, name)
+	}
+
+	logs, err := p.containerLogs(pod, container, newLogOptions(opts, time.Now()))
+	return logs, errors.Wrapf(err, "failed to get logs of container %v of pod %s/%s", container, ns, name)
 }
 
 // RunInContainer runs a command in a specific container.
-func (p *Provider) RunInContainer(_ context.Context, ns, name, _ string, _ []string, _ api.AttachIO) error {
+func (p *Provider) RunInContainer(ctx context.Context, ns, name, container string, cmd []string, attach api.AttachIO) error {
 	if p.Environment.DebugEnabled {
 		log.Printf("RunInContainer for %s/%s\n", ns, name)
 	}
 
-	// There is no actual process running in the containers, so we can't do anything.
-	return nil
-}
-
-func (p *Provider) runLatency(ctx context.Context) error {
-	durationFlag, err := (*p.Store).GetNodeFlag(events.NodeAddedLatency)
-	if err != nil {
-		return errors.Wrap(err, "failed to get node flag (msec)")
-	}
-
-	duration, ok := durationFlag.(time.Duration)
+	pod, ok := p.Pods.GetPodByName(ns, name)
 	if !ok {
-		return errors.New("NodeAddedLatency is not a duration")
+		return errors.Errorf("unable to find pod %s/%s", ns, name)
 	}
 
-	select {
-	case <-ctx.Done():
-		return errors.Wrap(ctx.Err(), "context cancelled while running latency")
-	case <-time.After(duration):
-		// Do the actual latency
-		return nil
-	}
+	// There is no actual process running in the containers, so the response to the command is scripted
+	// The error is not wrapped, so the exit code of the command reaches the client
+	return p.runScriptedCommand(ctx, pod, container, cmd, attach)
 }
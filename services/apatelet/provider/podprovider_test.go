@@ -48,9 +48,10 @@ func TestRunLatencyError(t *testing.T) {
 
 	p := Provider{
 		Store: &s,
+		Pods:  podmanager.New(),
 	}
 
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), errors.New("test error")).Times(6)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), errors.New("test error")).Times(6)
 
 	assert.Error(t, p.UpdatePod(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "Test", Namespace: "Test"}}))
 	assert.Error(t, p.CreatePod(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "Test", Namespace: "Test"}}))
@@ -103,9 +104,12 @@ func TestCancelContextWhileRunningLatency(t *testing.T) {
 
 	p := Provider{
 		Store: &s,
+		Pods:  podmanager.New(),
 	}
 
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(100000*time.Millisecond, nil).Times(6)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(100000*time.Millisecond), nil).Times(6)
+	ms.EXPECT().GetNodeFlag(gomock.Any()).Return(scenario.ConstantLatency(0), nil).Times(6)
+	ms.EXPECT().GetPodFlag(gomock.Any(), gomock.Any()).Return(scenario.ConstantLatency(0), nil).Times(3)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
@@ -151,20 +155,25 @@ func TestCreatePod(t *testing.T) {
 	pod.UID = types.UID(uuid.New().String())
 
 	// expect
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeCreatePodLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodCreatePodLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodCreatePodResponse).Return(scenario.ResponseNormal, nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodResources).Return(&stats.PodStats{}, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeCreatePodResponse).Return(scenario.ResponseUnset, nil)
+	ms.EXPECT().AddPod(&pod)
 
 	// sot
 	var s store.Store = ms
 
 	p := Provider{
-		Store:     &s,
-		Pods:      podmanager.New(),
-		NodeInfo:  &node.Info{},
-		Resources: &scenario.NodeResources{},
-		Stats:     NewStats(),
+		Store:        &s,
+		Pods:         podmanager.New(),
+		NodeInfo:     &node.Info{},
+		Resources:    &scenario.NodeResources{},
+		Stats:        NewStats(),
+		usage:        newUsageTracker(),
+		nodeCapacity: newCapacityTracker(),
 	}
 
 	err := p.CreatePod(context.Background(), &pod)
@@ -194,19 +203,24 @@ func TestUpdatePod(t *testing.T) {
 	pod.UID = types.UID(uuid.New().String())
 
 	// expect
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeUpdatePodLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodUpdatePodLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodUpdatePodResponse).Return(scenario.ResponseNormal, nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodResources).Return(&stats.PodStats{}, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeUpdatePodResponse).Return(scenario.ResponseUnset, nil)
+	ms.EXPECT().AddPod(&pod)
 
 	// sot
 	var s store.Store = ms
 	p := Provider{
-		Store:     &s,
-		Pods:      podmanager.New(),
-		NodeInfo:  &node.Info{},
-		Resources: &scenario.NodeResources{},
-		Stats:     NewStats(),
+		Store:        &s,
+		Pods:         podmanager.New(),
+		NodeInfo:     &node.Info{},
+		Resources:    &scenario.NodeResources{},
+		Stats:        NewStats(),
+		usage:        newUsageTracker(),
+		nodeCapacity: newCapacityTracker(),
 	}
 
 	err := p.UpdatePod(context.Background(), &pod)
@@ -235,15 +249,22 @@ func TestDeletePod(t *testing.T) {
 	pod.UID = types.UID(uuid.New().String())
 
 	// expect
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeDeletePodLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodDeletePodLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodDeletePodResponse).Return(scenario.ResponseNormal, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeDeletePodResponse).Return(scenario.ResponseUnset, nil)
+	ms.EXPECT().RemovePod(&pod)
 
 	// sot
 	var s store.Store = ms
 	p := Provider{
-		Store: &s,
-		Pods:  podmanager.New(),
+		Store:    &s,
+		Pods:     podmanager.New(),
+		usage:    newUsageTracker(),
+		restarts: newRestartTracker(),
+		startups: newStartupTracker(),
+		runtimes: newRuntimeTracker(),
 	}
 
 	err := p.DeletePod(context.Background(), &pod)
@@ -270,7 +291,9 @@ func TestGetPod(t *testing.T) {
 	pod.UID = types.UID(uuid.New().String())
 
 	// expect
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeGetPodLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodResponse).Return(scenario.ResponseNormal, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeGetPodResponse).Return(scenario.ResponseUnset, nil)
 
@@ -309,7 +332,8 @@ func TestGetPods(t *testing.T) {
 
 	// expect
 	ms.EXPECT().GetNodeFlag(PCPRF).Return(scenario.ResponseNormal, nil)
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeGetPodsLatency).Return(scenario.ConstantLatency(0), nil)
 
 	// sot
 	var s store.Store = ms
@@ -330,7 +354,23 @@ func TestGetPods(t *testing.T) {
 }
 
 func TestGetContainerLogs(t *testing.T) {
-	prov := Provider{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	pod := corev1.Pod{}
+	pod.Namespace = "ns"
+	pod.Name = "name"
+	ms.EXPECT().GetPodFlag(&pod, events.PodLogs).Return(&scenario.LogModel{}, nil)
+
+	prov := Provider{
+		Store: &s,
+		Pods:  podmanager.New(),
+	}
+	prov.Pods.AddPod(&pod)
+
 	logs, err := prov.GetContainerLogs(context.Background(), "ns", "name", "", api.ContainerLogOpts{})
 	assert.NoError(t, err)
 
@@ -338,6 +378,9 @@ func TestGetContainerLogs(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, "This container is emulated by Apate\n", string(all))
+
+	_, err = prov.GetContainerLogs(context.Background(), "ns", "unknown", "", api.ContainerLogOpts{})
+	assert.Error(t, err)
 }
 
 type fakeAttachIO struct{}
@@ -363,7 +406,23 @@ func (f fakeAttachIO) Resize() <-chan api.TermSize {
 }
 
 func TestRunInContainerNoError(t *testing.T) {
-	prov := Provider{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	var s store.Store = ms
+
+	pod := corev1.Pod{}
+	pod.Namespace = "ns"
+	pod.Name = "name"
+	ms.EXPECT().GetPodFlag(&pod, events.PodExec).Return(scenario.ExecRules{}, nil)
+
+	prov := Provider{
+		Store: &s,
+		Pods:  podmanager.New(),
+	}
+	prov.Pods.AddPod(&pod)
+
 	err := prov.RunInContainer(context.Background(), "ns", "name", "", []string{}, fakeAttachIO{})
 	assert.NoError(t, err)
 }
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
@@ -10,8 +9,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/finitum/node-cli/stats"
-
 	"github.com/atlarge-research/apate/pkg/scenario"
 	"github.com/atlarge-research/apate/pkg/scenario/events"
 )
@@ -26,13 +23,13 @@ func (p *Provider) GetPodStatus(ctx context.Context, ns string, name string) (*c
 		return nil, errors.Wrap(err, "context cancelled in GetPodStatus")
 	}
 
-	if err := p.runLatency(ctx); err != nil {
+	pod, ok := p.Pods.GetPodByName(ns, name)
+	if err := p.runPodLatency(ctx, pod, events.PodGetPodStatusLatency); err != nil {
 		err = errors.Wrap(err, "failed to run latency in GetPodStatus")
 		log.Println(err)
 		return nil, err
 	}
 
-	pod, ok := p.Pods.GetPodByName(ns, name)
 	if !ok {
 		return nil, nil
 	}
@@ -43,31 +40,66 @@ func (p *Provider) GetPodStatus(ctx context.Context, ns string, name string) (*c
 			return nil, errors.Wrap(err, "failed to get pod status flag while getting pod status")
 		}
 
-		limitExceeded, err := p.doesPodExceedLimit(pod)
+		startup, err := p.evaluateStartup(pod, time.Now())
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to determine if limit is exceeded while getting pod status")
+			return nil, errors.Wrap(err, "failed to evaluate pod startup while getting pod status")
+		}
+
+		if startup.phase != startupDone && status != scenario.PodStatusPending {
+			return p.podStarting(pod, startup), nil
 		}
 
-		if limitExceeded {
-			return p.podFailed(pod, "Pod used too many resources and was then killed"), nil
+		limitExceeded, err := p.doesPodExceedLimit(pod)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to determine if limit is exceeded while getting pod status")
 		}
 
-		switch status {
-		case scenario.PodStatusPending:
+		var exit, limitExit *termination
+		switch {
+		case limitExceeded:
+			limitExit = &termination{exitCode: 137, reason: "OOMKilled", message: "Pod used too many resources and was then killed"}
+		case status == scenario.PodStatusPending:
 			return p.podPending(pod), nil
-		case scenario.PodStatusUnset:
-			fallthrough // act as a normal pod
-		case scenario.PodStatusRunning:
-			return p.podRunning(pod), nil
-		case scenario.PodStatusSucceeded:
-			return p.podSucceeded(pod), nil
-		case scenario.PodStatusFailed:
-			return p.podFailed(pod, "Emulated pod has failed"), nil
-		case scenario.PodStatusUnknown:
-			fallthrough
+		case status == scenario.PodStatusUnset, status == scenario.PodStatusRunning:
+			// act as a normal pod, which may still crash or complete once its runtime has elapsed
+			exit, err = p.evaluateRuntime(pod, startup.until, time.Now())
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to evaluate pod runtime while getting pod status")
+			}
+		case status == scenario.PodStatusSucceeded:
+			exit = &termination{exitCode: 0, reason: "Completed", message: "Pod has completed successfully"}
+		case status == scenario.PodStatusFailed:
+			exit = &termination{exitCode: 1, reason: "Error", message: "Emulated pod has failed"}
 		default:
 			return p.podUnknown(pod), nil
 		}
+
+		crash, err := (*p.Store).GetPodFlag(pod, events.PodCrash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get pod crash flag while getting pod status")
+		}
+
+		crashModel, ok := crash.(*scenario.CrashModel)
+		if !ok {
+			return nil, errors.Errorf("invalid pod crash flag %v", crash)
+		}
+
+		probes, err := (*p.Store).GetPodFlag(pod, events.PodProbes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get pod probes flag while getting pod status")
+		}
+
+		probeResults, ok := probes.(scenario.Probes)
+		if !ok {
+			return nil, errors.Errorf("invalid pod probes flag %v", probes)
+		}
+
+		containers, err := p.getContainerStates(pod)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get container states while getting pod status")
+		}
+
+		return p.emulatePod(pod, startup.until, exit, limitExit, containers, crashModel, probeResults), nil
 	}},
 		pod,
 		events.PodGetPodStatusResponse,
@@ -122,67 +154,6 @@ func (p *Provider) podUnknown(pod *corev1.Pod) *corev1.PodStatus {
 	}
 }
 
-func (p *Provider) podRunning(pod *corev1.Pod) *corev1.PodStatus {
-	startTime := metav1.Time{}
-	if pod.Status.StartTime != nil {
-		startTime = *pod.Status.StartTime
-	}
-
-	return &corev1.PodStatus{
-		Phase:   corev1.PodRunning,
-		Message: "Emulating pod successfully",
-		Conditions: []corev1.PodCondition{
-			{
-				Type:               corev1.PodReady,
-				Status:             corev1.ConditionTrue,
-				LastProbeTime:      metav1.Time{Time: time.Now()},
-				LastTransitionTime: metav1.Time{Time: time.Now()},
-				Message:            "Emulating pod...",
-			},
-		},
-		ContainerStatuses: p.createContainerStatuses(pod, true, corev1.ContainerState{
-			Running: &corev1.ContainerStateRunning{
-				StartedAt: startTime,
-			},
-		}),
-	}
-}
-
-func (p *Provider) podSucceeded(pod *corev1.Pod) *corev1.PodStatus {
-	return &corev1.PodStatus{
-		Phase:   corev1.PodSucceeded,
-		Message: "Pod has completed successfully",
-		ContainerStatuses: p.createContainerStatuses(pod, false, corev1.ContainerState{
-			Terminated: &corev1.ContainerStateTerminated{
-				ExitCode: 0,
-				Reason:   "Pod status is succeeded",
-			},
-		}),
-	}
-}
-
-func (p *Provider) podFailed(pod *corev1.Pod, reason string) *corev1.PodStatus {
-	return &corev1.PodStatus{
-		Phase:   corev1.PodFailed,
-		Message: reason,
-		Conditions: []corev1.PodCondition{
-			{
-				Type:               corev1.PodReady,
-				Status:             corev1.ConditionFalse,
-				LastProbeTime:      metav1.Time{Time: time.Now()},
-				LastTransitionTime: metav1.Time{Time: time.Now()},
-				Message:            "Failed pod...",
-			},
-		},
-		ContainerStatuses: p.createContainerStatuses(pod, false, corev1.ContainerState{
-			Terminated: &corev1.ContainerStateTerminated{
-				ExitCode: 1,
-				Reason:   fmt.Sprintf("Pod status is failed, for reason %v", reason),
-			},
-		}),
-	}
-}
-
 func (p *Provider) createContainerStatuses(pod *corev1.Pod, ready bool, state corev1.ContainerState) []corev1.ContainerStatus {
 	cs := make([]corev1.ContainerStatus, len(pod.Spec.Containers))
 	for i, c := range pod.Spec.Containers {
@@ -203,14 +174,9 @@ func (p *Provider) createContainerStatuses(pod *corev1.Pod, ready bool, state co
 func (p *Provider) doesPodExceedLimit(pod *corev1.Pod) (bool, error) {
 	limits := p.getPodResourceLimits(pod)
 
-	podResourcesFlag, err := (*p.Store).GetPodFlag(pod, events.PodResources)
+	podResources, err := p.getPodUsage(pod)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get pod resources flag while getting pod status")
-	}
-
-	podResources, ok := podResourcesFlag.(*stats.PodStats)
-	if !ok {
-		return false, errors.Wrapf(err, "unable to convert '%v' to PodStats", podResourcesFlag)
+		return false, errors.Wrap(err, "failed to get pod resources while getting pod status")
 	}
 
 	resources := resources{
@@ -226,10 +192,11 @@ func (p *Provider) doesPodExceedLimit(pod *corev1.Pod) (bool, error) {
 	// If the total amount of all pods resources exceed the resources on the node, just kill the current one
 	// TODO implement k8s OOM handling (much more complicated)
 	nodeStats := p.Stats.statsSummary.Node
+	nodeResources := p.resources()
 
-	totalLimitExceeded := nodeStats.UsageNanoCores > uint64(p.Resources.CPU) ||
-		nodeStats.UsageBytesMemory > uint64(p.Resources.Memory) ||
-		nodeStats.UsedBytesEphemeral > uint64(p.Resources.EphemeralStorage)
+	totalLimitExceeded := nodeStats.UsageNanoCores > uint64(nodeResources.CPU) ||
+		nodeStats.UsageBytesMemory > uint64(nodeResources.Memory) ||
+		nodeStats.UsedBytesEphemeral > uint64(nodeResources.EphemeralStorage)
 
 	return podExceedsPodLimit || totalLimitExceeded, nil
 }
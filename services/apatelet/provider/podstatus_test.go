@@ -23,7 +23,34 @@ import (
 	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
 )
 
-func prepareState(t *testing.T, nodeResources int64, podResources uint64, podMaxResources int64, podStatus scenario.PodStatus, response scenario.Response) (Provider, *gomock.Controller) {
+// podStateOptions configures the pod prepared by prepareState
+// The node resources and pod limits default to 1000 when unset, the other options default to a pod without any configuration
+type podStateOptions struct {
+	nodeResources   int64
+	podResources    uint64
+	podMaxResources int64
+	podStatus       scenario.PodStatus
+	response        scenario.Response
+	containers      scenario.ContainerStates
+	startup         *scenario.StartupPhases
+	imagePull       *scenario.PodImagePull
+	restartPolicy   corev1.RestartPolicy
+}
+
+func prepareState(t *testing.T, opts podStateOptions) (Provider, *gomock.Controller) {
+	if opts.nodeResources == 0 {
+		opts.nodeResources = 1000
+	}
+	if opts.podMaxResources == 0 {
+		opts.podMaxResources = 1000
+	}
+	if opts.startup == nil {
+		opts.startup = &scenario.StartupPhases{}
+	}
+	if opts.imagePull == nil {
+		opts.imagePull = &scenario.PodImagePull{}
+	}
+
 	ctrl := gomock.NewController(t)
 	ms := mock_store.NewMockStore(ctrl)
 
@@ -35,41 +62,82 @@ func prepareState(t *testing.T, nodeResources int64, podResources uint64, podMax
 		podconfigv1.PodConfigurationLabel: podLabel,
 	}
 	pod.UID = types.UID(uuid.New().String())
+	pod.Spec.RestartPolicy = opts.restartPolicy
+	limits := corev1.ResourceList{
+		corev1.ResourceCPU:              *resource.NewQuantity(opts.podMaxResources, ""),
+		corev1.ResourceMemory:           *resource.NewQuantity(opts.podMaxResources, ""),
+		corev1.ResourceEphemeralStorage: *resource.NewQuantity(opts.podMaxResources, ""),
+	}
 	pod.Spec.Containers = []corev1.Container{
 		{
-			Name:  podContainerName,
-			Image: podImageName,
-			Resources: corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					corev1.ResourceCPU:              *resource.NewQuantity(podMaxResources, ""),
-					corev1.ResourceMemory:           *resource.NewQuantity(podMaxResources, ""),
-					corev1.ResourceEphemeralStorage: *resource.NewQuantity(podMaxResources, ""),
-				},
-			},
+			Name:      podContainerName,
+			Image:     podImageName,
+			Resources: corev1.ResourceRequirements{Limits: limits},
 		},
 	}
 
+	// Containers configured on top of the default one
+	for name := range opts.containers {
+		if name != podContainerName {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Name:      name,
+				Image:     podImageName,
+				Resources: corev1.ResourceRequirements{Limits: limits},
+			})
+		}
+	}
+
 	// expect
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
-	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodStatusResponse).Return(response, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeGetPodStatusLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodStatusLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodStatusResponse).Return(opts.response, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeGetPodStatusResponse).Return(scenario.ResponseUnset, nil)
 
-	isNormal := response == scenario.ResponseNormal || response == scenario.ResponseUnset
+	isNormal := opts.response == scenario.ResponseNormal || opts.response == scenario.ResponseUnset
+
+	// Pods which are still starting are not checked against their limits
+	started := *opts.startup == scenario.StartupPhases{} && !opts.imagePull.Fail
+	limitExceeded := int64(opts.podResources) > opts.podMaxResources || int64(opts.podResources) > opts.nodeResources
+
+	// Pending and unknown pods are not emulated, unless they exceed their limits
+	emulated := isNormal && started && (limitExceeded || opts.podStatus != scenario.PodStatusPending && opts.podStatus != scenario.PodStatusUnknown)
 
 	expectedResourceGets := 1
-	if isNormal {
+	if isNormal && started {
 		expectedResourceGets = 2 // First by updateStatsSummary and then in getPodStatus limitReached
 	}
 
+	expectedContainerGets := expectedResourceGets
+	if emulated {
+		expectedContainerGets++ // And finally when emulating the containers
+	}
+
 	// Because we compute the resources up front
 	ms.EXPECT().GetPodFlag(&pod, events.PodResources).Return(&stats.PodStats{
-		UsageNanoCores:     podResources,
-		UsageBytesMemory:   podResources,
-		UsedBytesEphemeral: podResources,
+		UsageNanoCores:     opts.podResources,
+		UsageBytesMemory:   opts.podResources,
+		UsedBytesEphemeral: opts.podResources,
 	}, nil).Times(expectedResourceGets)
+	ms.EXPECT().GetPodFlag(&pod, events.PodContainers).Return(opts.containers, nil).Times(expectedContainerGets)
 
 	if isNormal {
-		ms.EXPECT().GetPodFlag(&pod, events.PodStatus).Return(podStatus, nil)
+		ms.EXPECT().GetPodFlag(&pod, events.PodStatus).Return(opts.podStatus, nil)
+		ms.EXPECT().GetPodFlag(&pod, events.PodStartup).Return(opts.startup, nil)
+		ms.EXPECT().GetNodeFlag(events.NodePodStartup).Return(&scenario.StartupPhases{}, nil)
+		ms.EXPECT().GetPodFlag(&pod, events.PodImagePull).Return(opts.imagePull, nil)
+		ms.EXPECT().GetNodeFlag(events.NodeImages).Return(&scenario.NodeImages{}, nil)
+	}
+
+	if emulated {
+		ms.EXPECT().GetPodFlag(&pod, events.PodCrash).Return(&scenario.CrashModel{}, nil)
+		ms.EXPECT().GetPodFlag(&pod, events.PodProbes).Return(scenario.Probes{}, nil)
+	}
+
+	// Only running pods which are within their limits can complete once their runtime has elapsed
+	running := opts.podStatus == scenario.PodStatusUnset || opts.podStatus == scenario.PodStatusRunning
+	if emulated && !limitExceeded && running {
+		ms.EXPECT().GetPodFlag(&pod, events.PodRuntime).Return(&scenario.RuntimeModel{}, nil)
 	}
 
 	// sot
@@ -78,14 +146,20 @@ func prepareState(t *testing.T, nodeResources int64, podResources uint64, podMax
 		Store: &s,
 		Pods:  podmanager.New(),
 		Resources: &scenario.NodeResources{
-			CPU:              nodeResources,
-			Memory:           nodeResources,
-			EphemeralStorage: nodeResources,
+			CPU:              opts.nodeResources,
+			Memory:           opts.nodeResources,
+			EphemeralStorage: opts.nodeResources,
 		},
 		NodeInfo: &node.Info{},
 		Stats: &Stats{
 			statsSummary: &stats.Summary{},
 		},
+		usage:        newUsageTracker(),
+		restarts:     newRestartTracker(),
+		startups:     newStartupTracker(),
+		images:       newImageCache(),
+		runtimes:     newRuntimeTracker(),
+		nodeCapacity: newCapacityTracker(),
 	}
 	prov.Pods.AddPod(&pod)
 
@@ -96,7 +170,7 @@ func prepareState(t *testing.T, nodeResources int64, podResources uint64, podMax
 func TestGetPodStatus(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 1, 1000, scenario.PodStatusRunning, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{podResources: 1, podStatus: scenario.PodStatusRunning})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -110,10 +184,14 @@ func TestGetPodStatus(t *testing.T) {
 	assert.Equal(t, corev1.ConditionTrue, ps.Conditions[0].Status)
 
 	assert.Len(t, ps.ContainerStatuses, 1)
+	startedAt := ps.ContainerStatuses[0].State.Running.StartedAt
+	assert.False(t, startedAt.IsZero())
 	assert.EqualValues(t, corev1.ContainerStatus{
 		Name: podContainerName,
 		State: corev1.ContainerState{
-			Running: &corev1.ContainerStateRunning{},
+			Running: &corev1.ContainerStateRunning{
+				StartedAt: startedAt,
+			},
 		},
 		Ready:       true,
 		Image:       podImageName,
@@ -127,7 +205,12 @@ func TestGetPodStatus(t *testing.T) {
 func TestGetPodStatusPodLimitReached(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 128, 64, scenario.PodStatusRunning, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources:    128,
+		podMaxResources: 64,
+		podStatus:       scenario.PodStatusRunning,
+		restartPolicy:   corev1.RestartPolicyNever,
+	})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -143,7 +226,12 @@ func TestGetPodStatusPodLimitReached(t *testing.T) {
 func TestGetPodStatusNodeLimitReached(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 64, 128, 1000, scenario.PodStatusRunning, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{
+		nodeResources: 64,
+		podResources:  128,
+		podStatus:     scenario.PodStatusRunning,
+		restartPolicy: corev1.RestartPolicyNever,
+	})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -156,12 +244,16 @@ func TestGetPodStatusNodeLimitReached(t *testing.T) {
 	assert.Len(t, prov.Pods.GetAllPods(), 1)
 
 	assert.Len(t, ps.ContainerStatuses, 1)
+	finishedAt := ps.ContainerStatuses[0].State.Terminated.FinishedAt
+	assert.False(t, finishedAt.IsZero())
 	assert.EqualValues(t, corev1.ContainerStatus{
 		Name: podContainerName,
 		State: corev1.ContainerState{
 			Terminated: &corev1.ContainerStateTerminated{
-				ExitCode: 1,
-				Reason:   "Pod status is failed, for reason Pod used too many resources and was then killed",
+				ExitCode:   137,
+				Reason:     "OOMKilled",
+				Message:    "Pod used too many resources and was then killed",
+				FinishedAt: finishedAt,
 			},
 		},
 		Ready:       false,
@@ -174,7 +266,11 @@ func TestGetPodStatusNodeLimitReached(t *testing.T) {
 func TestGetPodStatusSucceeded(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 128, 1000, scenario.PodStatusSucceeded, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources:  128,
+		podStatus:     scenario.PodStatusSucceeded,
+		restartPolicy: corev1.RestartPolicyNever,
+	})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -186,12 +282,16 @@ func TestGetPodStatusSucceeded(t *testing.T) {
 	assert.Len(t, prov.Pods.GetAllPods(), 1)
 
 	assert.Len(t, ps.ContainerStatuses, 1)
+	finishedAt := ps.ContainerStatuses[0].State.Terminated.FinishedAt
+	assert.False(t, finishedAt.IsZero())
 	assert.EqualValues(t, corev1.ContainerStatus{
 		Name: podContainerName,
 		State: corev1.ContainerState{
 			Terminated: &corev1.ContainerStateTerminated{
-				ExitCode: 0,
-				Reason:   "Pod status is succeeded",
+				ExitCode:   0,
+				Reason:     "Completed",
+				Message:    "Pod has completed successfully",
+				FinishedAt: finishedAt,
 			},
 		},
 		Ready:       false,
@@ -204,7 +304,7 @@ func TestGetPodStatusSucceeded(t *testing.T) {
 func TestGetPodStatusPending(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 128, 1000, scenario.PodStatusPending, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{podResources: 128, podStatus: scenario.PodStatusPending})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -234,7 +334,7 @@ func TestGetPodStatusPending(t *testing.T) {
 func TestGetPodStatusUnknown(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 128, 1000, scenario.PodStatusUnknown, scenario.ResponseNormal)
+	prov, ctrl := prepareState(t, podStateOptions{podResources: 128, podStatus: scenario.PodStatusUnknown})
 	defer ctrl.Finish()
 
 	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -262,7 +362,7 @@ func TestGetPodStatusUnknown(t *testing.T) {
 func TestGetPodStatusEmulationError(t *testing.T) {
 	t.Parallel()
 
-	prov, ctrl := prepareState(t, 1000, 128, 1000, scenario.PodStatusUnknown, scenario.ResponseError)
+	prov, ctrl := prepareState(t, podStateOptions{podResources: 128, podStatus: scenario.PodStatusUnknown, response: scenario.ResponseError})
 	defer ctrl.Finish()
 
 	_, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
@@ -286,12 +386,21 @@ func TestGetPodStatusDefault(t *testing.T) {
 		podconfigv1.PodConfigurationLabel: podLabel,
 	}
 
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetNodeFlag(events.NodeGetPodStatusLatency).Return(scenario.ConstantLatency(0), nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodStatusLatency).Return(scenario.ConstantLatency(0), nil)
 	ms.EXPECT().GetPodFlag(&pod, events.PodGetPodStatusResponse).Return(scenario.ResponseUnset, nil)
 	ms.EXPECT().GetNodeFlag(events.NodeGetPodStatusResponse).Return(scenario.ResponseUnset, nil)
 
 	ms.EXPECT().GetPodFlag(&pod, events.PodResources).Return(&stats.PodStats{}, nil).Times(2)
 	ms.EXPECT().GetPodFlag(&pod, events.PodStatus).Return(scenario.PodStatusUnset, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodStartup).Return(&scenario.StartupPhases{}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodePodStartup).Return(&scenario.StartupPhases{}, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodImagePull).Return(&scenario.PodImagePull{}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeImages).Return(&scenario.NodeImages{}, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodCrash).Return(&scenario.CrashModel{}, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodProbes).Return(scenario.Probes{}, nil)
+	ms.EXPECT().GetPodFlag(&pod, events.PodRuntime).Return(&scenario.RuntimeModel{}, nil)
 
 	var s store.Store = ms
 	nodeResources := int64(1000)
@@ -307,6 +416,12 @@ func TestGetPodStatusDefault(t *testing.T) {
 		Stats: &Stats{
 			statsSummary: &stats.Summary{},
 		},
+		usage:        newUsageTracker(),
+		restarts:     newRestartTracker(),
+		startups:     newStartupTracker(),
+		images:       newImageCache(),
+		runtimes:     newRuntimeTracker(),
+		nodeCapacity: newCapacityTracker(),
 	}
 	prov.Pods.AddPod(&pod)
 
@@ -321,3 +436,167 @@ func TestGetPodStatusDefault(t *testing.T) {
 	assert.Equal(t, corev1.ConditionTrue, ps.Conditions[0].Status)
 	assert.Len(t, prov.Pods.GetAllPods(), 1)
 }
+
+func TestGetPodStatusCrashLoopBackOff(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources:  128,
+		podStatus:     scenario.PodStatusFailed,
+		restartPolicy: corev1.RestartPolicyAlways,
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, ps.Phase)
+	assert.Equal(t, corev1.ConditionFalse, ps.Conditions[0].Status)
+
+	assert.Len(t, ps.ContainerStatuses, 1)
+	assert.False(t, ps.ContainerStatuses[0].Ready)
+	assert.Equal(t, "CrashLoopBackOff", ps.ContainerStatuses[0].State.Waiting.Reason)
+}
+
+func TestGetPodStatusSidecarFailed(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources: 1,
+		podStatus:    scenario.PodStatusRunning,
+		containers: scenario.ContainerStates{
+			"sidecar": &scenario.ContainerState{Status: scenario.ContainerStatusTerminated, ExitCode: 2, Reason: "SidecarError"},
+		},
+		restartPolicy: corev1.RestartPolicyNever,
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, ps.Phase)
+	assert.Equal(t, corev1.ConditionFalse, ps.Conditions[0].Status)
+
+	assert.Len(t, ps.ContainerStatuses, 2)
+	assert.True(t, ps.ContainerStatuses[0].Ready)
+	assert.NotNil(t, ps.ContainerStatuses[0].State.Running)
+
+	assert.Equal(t, "sidecar", ps.ContainerStatuses[1].Name)
+	assert.False(t, ps.ContainerStatuses[1].Ready)
+	assert.Equal(t, int32(2), ps.ContainerStatuses[1].State.Terminated.ExitCode)
+	assert.Equal(t, "SidecarError", ps.ContainerStatuses[1].State.Terminated.Reason)
+}
+
+func TestGetPodStatusPartiallyReady(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources: 1,
+		podStatus:    scenario.PodStatusRunning,
+		containers: scenario.ContainerStates{
+			podContainerName: &scenario.ContainerState{Status: scenario.ContainerStatusRunning, Ready: false},
+			"sidecar":        &scenario.ContainerState{Status: scenario.ContainerStatusRunning, Ready: true},
+		},
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, ps.Phase)
+	assert.Equal(t, corev1.ConditionFalse, ps.Conditions[0].Status)
+
+	assert.Len(t, ps.ContainerStatuses, 2)
+	assert.False(t, ps.ContainerStatuses[0].Ready)
+	assert.True(t, ps.ContainerStatuses[1].Ready)
+}
+
+func TestGetPodStatusContainerLimitReached(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		nodeResources: 100000,
+		podResources:  1,
+		podStatus:     scenario.PodStatusRunning,
+		containers: scenario.ContainerStates{
+			"sidecar": &scenario.ContainerState{Status: scenario.ContainerStatusRunning, Ready: true, Resources: &stats.PodStats{UsageBytesMemory: 1500}},
+		},
+		restartPolicy: corev1.RestartPolicyNever,
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, ps.Phase)
+
+	assert.Len(t, ps.ContainerStatuses, 2)
+	assert.NotNil(t, ps.ContainerStatuses[0].State.Running)
+	assert.Equal(t, "OOMKilled", ps.ContainerStatuses[1].State.Terminated.Reason)
+}
+
+func TestExceedsContainerLimitCPU(t *testing.T) {
+	t.Parallel()
+
+	c := corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+		},
+	}
+
+	assert.False(t, exceedsContainerLimit(c, &stats.PodStats{UsageNanoCores: 400000000}))
+	assert.True(t, exceedsContainerLimit(c, &stats.PodStats{UsageNanoCores: 600000000}))
+}
+
+func TestGetPodStatusStartup(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources: 1,
+		podStatus:    scenario.PodStatusRunning,
+		startup: &scenario.StartupPhases{
+			Scheduled: scenario.ConstantLatency(0),
+			ImagePull: scenario.ConstantLatency(time.Hour),
+		},
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodPending, ps.Phase)
+
+	assert.Len(t, ps.ContainerStatuses, 1)
+	assert.False(t, ps.ContainerStatuses[0].Ready)
+	assert.Equal(t, "ContainerCreating", ps.ContainerStatuses[0].State.Waiting.Reason)
+	assert.Equal(t, "Pulling images", ps.ContainerStatuses[0].State.Waiting.Message)
+}
+
+func TestGetPodStatusImagePullFailed(t *testing.T) {
+	t.Parallel()
+
+	prov, ctrl := prepareState(t, podStateOptions{
+		podResources: 1,
+		podStatus:    scenario.PodStatusRunning,
+		imagePull:    &scenario.PodImagePull{Fail: true},
+	})
+	defer ctrl.Finish()
+
+	ps, err := prov.GetPodStatus(context.Background(), podNamespace, podName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.PodPending, ps.Phase)
+
+	assert.Len(t, ps.ContainerStatuses, 1)
+	assert.False(t, ps.ContainerStatuses[0].Ready)
+	assert.Equal(t, "ErrImagePull", ps.ContainerStatuses[0].State.Waiting.Reason)
+	assert.Contains(t, ps.ContainerStatuses[0].State.Waiting.Message, podImageName)
+}
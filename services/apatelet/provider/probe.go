@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+const (
+	// The defaults the api server uses for the timings of probes
+	defaultProbePeriod           = 10 * time.Second
+	defaultProbeFailureThreshold = 3
+	defaultProbeSuccessThreshold = 1
+)
+
+// probeTiming determines when the kubelet runs a probe and when it acts on its results
+type probeTiming struct {
+	initialDelay     time.Duration
+	period           time.Duration
+	failureThreshold int32
+	successThreshold int32
+}
+
+// probeState is the state of a probe of the current run of a container
+type probeState struct {
+	// The last seen result of the probe
+	result scenario.ProbeResult
+
+	// When the result was last changed, or when the run started
+	since time.Time
+
+	// Whether the container was ready when the result was last changed, only used for readiness probes
+	ready bool
+}
+
+func newProbeTiming(probe *corev1.Probe) probeTiming {
+	timing := probeTiming{
+		initialDelay:     time.Duration(probe.InitialDelaySeconds) * time.Second,
+		period:           time.Duration(probe.PeriodSeconds) * time.Second,
+		failureThreshold: probe.FailureThreshold,
+		successThreshold: probe.SuccessThreshold,
+	}
+
+	if timing.period <= 0 {
+		timing.period = defaultProbePeriod
+	}
+
+	if timing.failureThreshold <= 0 {
+		timing.failureThreshold = defaultProbeFailureThreshold
+	}
+
+	if timing.successThreshold <= 0 {
+		timing.successThreshold = defaultProbeSuccessThreshold
+	}
+
+	return timing
+}
+
+// probeAt returns the time of the first probe at or after the given time, of a container which started at startedAt
+func (t probeTiming) probeAt(startedAt, after time.Time) time.Time {
+	first := startedAt.Add(t.initialDelay)
+	if !after.After(first) {
+		return first
+	}
+
+	periods := (after.Sub(first) + t.period - 1) / t.period
+	return first.Add(periods * t.period)
+}
+
+// failedAt returns when the kubelet acts on a probe which fails from the given time onwards
+func (t probeTiming) failedAt(startedAt, since time.Time) time.Time {
+	return t.probeAt(startedAt, since).Add(time.Duration(t.failureThreshold-1) * t.period)
+}
+
+// succeededAt returns when the kubelet acts on a probe which succeeds from the given time onwards
+func (t probeTiming) succeededAt(startedAt, since time.Time) time.Time {
+	return t.probeAt(startedAt, since).Add(time.Duration(t.successThreshold-1) * t.period)
+}
+
+// resetProbes starts probing the current run of the container with the given probe results
+func (c *containerRun) resetProbes(probes scenario.Probes) {
+	c.readiness = probeState{result: probes.Readiness, since: c.startedAt}
+	c.liveness = probeState{result: probes.Liveness, since: c.startedAt}
+}
+
+// observeProbes updates the probe states of the current run of the container with the current probe results
+func (c *containerRun) observeProbes(container corev1.Container, probes scenario.Probes, now time.Time) {
+	if c.readiness.since.IsZero() {
+		c.resetProbes(probes)
+		return
+	}
+
+	if probes.Readiness != c.readiness.result {
+		c.readiness = probeState{result: probes.Readiness, since: now, ready: c.isReady(container, now)}
+	}
+
+	if probes.Liveness != c.liveness.result {
+		c.liveness = probeState{result: probes.Liveness, since: now}
+	}
+}
+
+// isReady returns whether the readiness probe of the running container has made it ready at the given time
+// Containers without a readiness probe are ready as soon as they run
+func (c *containerRun) isReady(container corev1.Container, now time.Time) bool {
+	if container.ReadinessProbe == nil {
+		return true
+	}
+
+	timing := newProbeTiming(container.ReadinessProbe)
+	if c.readiness.result == scenario.ProbeResultFail {
+		return c.readiness.ready && now.Before(timing.failedAt(c.startedAt, c.readiness.since))
+	}
+
+	return c.readiness.ready || !now.Before(timing.succeededAt(c.startedAt, c.readiness.since))
+}
+
+// livenessFails returns when the liveness probe of the running container makes the kubelet kill it, if it does so before the given time
+func (c *containerRun) livenessFails(container corev1.Container, now time.Time) (time.Time, bool) {
+	if container.LivenessProbe == nil || c.liveness.result != scenario.ProbeResultFail {
+		return time.Time{}, false
+	}
+
+	at := newProbeTiming(container.LivenessProbe).failedAt(c.startedAt, c.liveness.since)
+	return at, !at.After(now)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+func createProbe(initialDelay, period, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		InitialDelaySeconds: initialDelay,
+		PeriodSeconds:       period,
+		FailureThreshold:    failureThreshold,
+	}
+}
+
+func TestProbeTiming(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	timing := newProbeTiming(createProbe(5, 10, 3))
+
+	assert.Equal(t, start.Add(5*time.Second), timing.probeAt(start, start))
+	assert.Equal(t, start.Add(15*time.Second), timing.probeAt(start, start.Add(6*time.Second)))
+	assert.Equal(t, start.Add(15*time.Second), timing.probeAt(start, start.Add(15*time.Second)))
+
+	// Three consecutive failures are needed
+	assert.Equal(t, start.Add(35*time.Second), timing.failedAt(start, start.Add(6*time.Second)))
+	assert.Equal(t, start.Add(5*time.Second), timing.succeededAt(start, start))
+
+	// Unset timings get the defaults of the api server
+	assert.Equal(t, probeTiming{period: 10 * time.Second, failureThreshold: 3, successThreshold: 1}, newProbeTiming(&corev1.Probe{}))
+}
+
+func TestReadinessProbe(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	pod := createRestartPod(corev1.RestartPolicyAlways, start)
+	container := corev1.Container{Name: "c", ReadinessProbe: createProbe(5, 10, 3)}
+	tracker := newRestartTracker()
+	random := fixedRandom{}
+	succeed := scenario.Probes{}
+	fail := scenario.Probes{Readiness: scenario.ProbeResultFail}
+
+	// Not ready before the first probe
+	run := tracker.evaluate(pod, container, start, nil, nil, succeed, start.Add(time.Second), random)
+	assert.False(t, run.ready)
+
+	run = tracker.evaluate(pod, container, start, nil, nil, succeed, start.Add(5*time.Second), random)
+	assert.True(t, run.ready)
+
+	// Stays ready until the probe has failed three times
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute), random)
+	assert.True(t, run.ready)
+
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute+24*time.Second), random)
+	assert.True(t, run.ready)
+
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute+25*time.Second), random)
+	assert.False(t, run.ready)
+
+	// Becomes ready again at the next successful probe
+	run = tracker.evaluate(pod, container, start, nil, nil, succeed, start.Add(2*time.Minute), random)
+	assert.False(t, run.ready)
+
+	run = tracker.evaluate(pod, container, start, nil, nil, succeed, start.Add(2*time.Minute+5*time.Second), random)
+	assert.True(t, run.ready)
+	assert.Nil(t, run.terminated)
+}
+
+func TestReadinessWithoutProbe(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	pod := createRestartPod(corev1.RestartPolicyAlways, start)
+	tracker := newRestartTracker()
+
+	run := tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{Readiness: scenario.ProbeResultFail}, start, fixedRandom{})
+	assert.True(t, run.ready)
+}
+
+func TestLivenessProbe(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	pod := createRestartPod(corev1.RestartPolicyAlways, start)
+	container := corev1.Container{Name: "c", LivenessProbe: createProbe(0, 10, 3)}
+	tracker := newRestartTracker()
+	random := fixedRandom{}
+	fail := scenario.Probes{Liveness: scenario.ProbeResultFail}
+
+	run := tracker.evaluate(pod, container, start, nil, nil, scenario.Probes{}, start.Add(50*time.Second), random)
+	assert.Nil(t, run.terminated)
+
+	// The probe fails from the moment the failure is seen
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute), random)
+	assert.Nil(t, run.terminated)
+
+	// Killed at the third failed probe
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute+25*time.Second), random)
+	assert.Equal(t, start.Add(time.Minute+20*time.Second), run.terminated.at)
+	assert.Equal(t, int32(137), run.terminated.exitCode)
+
+	// Restarted after the backoff and killed again after three probes
+	run = tracker.evaluate(pod, container, start, nil, nil, fail, start.Add(time.Minute+55*time.Second), random)
+	assert.Equal(t, int32(1), run.restarts)
+	assert.Equal(t, start.Add(time.Minute+50*time.Second), run.terminated.at)
+}
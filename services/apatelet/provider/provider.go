@@ -3,6 +3,7 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	cli "github.com/finitum/node-cli"
 	"github.com/finitum/node-cli/opts"
@@ -15,6 +16,10 @@ import (
 	"github.com/atlarge-research/apate/pkg/env"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/kubernetes/kubeconfig"
 
 	"github.com/atlarge-research/apate/services/apatelet/provider/condition"
 
@@ -48,6 +53,18 @@ type Provider struct {
 	Resources *scenario.NodeResources // static resource information sent to kubernetes
 
 	Conditions nodeConditions // a wrapper around kubernetes conditions
+
+	random   *random         // the source of random numbers for probabilistic behaviour
+	usage    *usageTracker   // the resource usage of pods which changes over time
+	restarts *restartTracker // the restarts of the containers of pods
+	startups *startupTracker // the startup phases of pods
+	images   *imageCache     // the images pulled by the node
+	runtimes *runtimeTracker // the runtimes of pods which complete by themselves
+
+	nodeCapacity *capacityTracker // the changes to the resources of the node made by tasks
+	metadata     *metadataTracker // the labels, annotations and taints added to the node
+
+	Nodes corev1client.NodeInterface // the nodes in kubernetes, used to update the metadata of the node
 }
 
 // VirtualKubelet is a struct containing everything needed to start virtual kubelet
@@ -92,9 +109,24 @@ func CreateProvider(env *env.ApateletEnvironment, res *scenario.NodeResources, s
 		return nil, errors.Wrap(err, "failed to create kubernetes node info")
 	}
 
+	config, err := kubeconfig.FromPath(env.KubeConfigLocation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get restconfig from kubeconfig")
+	}
+
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get clientset from rest config")
+	}
+
 	providerStore := provider.NewStore()
 	providerStore.Register(baseName, func(cfg *provider.InitConfig) (provider.Provider, error) {
-		return NewProvider(podmanager.New(), NewStats(), res, cfg, &nodeInfo, store, env.DisableTaints, *env), nil
+		return NewProvider(podmanager.New(), NewStats(), res, cfg, &nodeInfo, store, env.DisableTaints, *env, clientSet.CoreV1().Nodes()), nil
 	})
 
 	return &VirtualKubelet{
@@ -105,7 +137,7 @@ func CreateProvider(env *env.ApateletEnvironment, res *scenario.NodeResources, s
 }
 
 // NewProvider returns the provider but with the vk type instead of our own.
-func NewProvider(pods podmanager.PodManager, nodeStats *Stats, resources *scenario.NodeResources, cfg *provider.InitConfig, nodeInfo *node.Info, store *store.Store, disableTaints bool, environment env.ApateletEnvironment) provider.Provider {
+func NewProvider(pods podmanager.PodManager, nodeStats *Stats, resources *scenario.NodeResources, cfg *provider.InitConfig, nodeInfo *node.Info, store *store.Store, disableTaints bool, environment env.ApateletEnvironment, nodes corev1client.NodeInterface) provider.Provider {
 	p := &Provider{
 		Pods:        pods,
 		Store:       store,
@@ -127,12 +159,36 @@ func NewProvider(pods podmanager.PodManager, nodeStats *Stats, resources *scenar
 			networkUnavailable: condition.New(false, corev1.NodeNetworkUnavailable),
 			pidPressure:        condition.New(false, corev1.NodePIDPressure),
 		},
+
+		random:   newRandom(environment.Seed),
+		usage:    newUsageTracker(),
+		restarts: newRestartTracker(),
+		startups: newStartupTracker(),
+		images:   newImageCache(),
+		runtimes: newRuntimeTracker(),
+
+		nodeCapacity: newCapacityTracker(),
+		metadata:     newMetadataTracker(),
+
+		Nodes: nodes,
 	}
 
 	(*store).AddPodFlagListener(events.PodResources, func(obj interface{}) {
 		p.updateStatsSummary()
 	})
 
+	(*store).AddNodeFlagListener(events.NodeCapacity, func(obj interface{}) {
+		capacity, ok := obj.(*scenario.NodeCapacity)
+		if !ok {
+			log.Printf("invalid node capacity flag %v", obj)
+			return
+		}
+
+		p.nodeCapacity.set(capacity)
+		p.updateStatsSummary()
+	})
+
+	p.watchNodeMetadata()
 	p.updateStatsSummary()
 
 	return p
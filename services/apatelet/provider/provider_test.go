@@ -54,10 +54,12 @@ func TestNewProvider(t *testing.T) {
 	var s store.Store = ms
 
 	ms.EXPECT().AddPodFlagListener(events.PodResources, gomock.Any())
+	ms.EXPECT().AddNodeFlagListener(events.NodeCapacity, gomock.Any())
+	ms.EXPECT().AddNodeFlagListener(gomock.Any(), gomock.Any()).Times(3)
 
 	e, err := env.ApateletEnv()
 	assert.NoError(t, err)
-	p, ok := NewProvider(pm, sts, &resources, &cfg, &ni, &s, true, e).(*Provider)
+	p, ok := NewProvider(pm, sts, &resources, &cfg, &ni, &s, true, e, nil).(*Provider)
 
 	assert.True(t, ok)
 
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// random is a thread safe, seedable source of random numbers
+type random struct {
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// newRandom creates a new source of random numbers with the given seed, or the current time if the seed is 0
+func newRandom(seed int64) *random {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &random{
+		rand: rand.New(rand.NewSource(seed)), //nolint:gosec // emulation does not need secure random numbers
+	}
+}
+
+// Float64 returns a random number between 0 and 1
+func (r *random) Float64() float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.Float64()
+}
+
+// NormFloat64 returns a normally distributed number with mean 0 and standard deviation 1
+func (r *random) NormFloat64() float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed number with mean 1
+func (r *random) ExpFloat64() float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.ExpFloat64()
+}
@@ -24,12 +24,7 @@ func getPodResponseFlag(args responseArgs, pod *corev1.Pod, podEventFlag events.
 		return scenario.ResponseUnset, errors.Wrapf(err, "failed to get pod flag %v", podEventFlag)
 	}
 
-	flag, ok := iflag.(scenario.Response)
-	if !ok {
-		return scenario.ResponseUnset, errors.Errorf("couldn't cast %v to response", flag)
-	}
-
-	return flag, nil
+	return drawResponse(args.provider, iflag)
 }
 
 func getNodeResponseFlag(args responseArgs, nodeEventFlag events.NodeEventFlag) (scenario.Response, error) {
@@ -38,12 +33,19 @@ func getNodeResponseFlag(args responseArgs, nodeEventFlag events.NodeEventFlag)
 		return scenario.ResponseUnset, errors.Wrapf(err, "failed to get node flag %v", nodeEventFlag)
 	}
 
-	flag, ok := iflag.(scenario.Response)
-	if !ok {
-		return scenario.ResponseUnset, errors.Errorf("couldn't cast %v to response", flag)
-	}
+	return drawResponse(args.provider, iflag)
+}
 
-	return flag, nil
+// drawResponse returns the response stored in a flag, if the flag is a weighted mix of responses one is drawn
+func drawResponse(provider *Provider, iflag interface{}) (scenario.Response, error) {
+	switch flag := iflag.(type) {
+	case scenario.Response:
+		return flag, nil
+	case scenario.ResponseDistribution:
+		return flag.Draw(provider.random.Float64()), nil
+	default:
+		return scenario.ResponseUnset, errors.Errorf("couldn't cast %v to response", iflag)
+	}
 }
 
 // nodeResponse checks the passed flags and calls the passed function on success
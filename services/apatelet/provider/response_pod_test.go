@@ -35,7 +35,7 @@ func setup(t *testing.T) (*mock_store.MockStore, *gomock.Controller, *corev1.Pod
 		// Run code under test
 		return podResponse(responseArgs{
 			ctx:      context.Background(),
-			provider: &Provider{Store: &s},
+			provider: &Provider{Store: &s, random: newRandom(0)},
 			action: func() (i interface{}, err error) {
 				return tStr, nil
 			}},
@@ -268,3 +268,23 @@ func TestErrorVsNormal(t *testing.T) {
 	assert.True(t, IsExpected(err))
 	assert.Nil(t, out)
 }
+
+func TestPodResponseDistribution(t *testing.T) {
+	t.Parallel()
+
+	ms, ctrl, pod, executor := setup(t)
+	defer ctrl.Finish()
+
+	// Expectations
+	ms.EXPECT().GetPodFlag(pod, events.PodCreatePodResponse).Return(scenario.ResponseDistribution{
+		{Response: scenario.ResponseError, Probability: 1},
+	}, nil)
+	ms.EXPECT().GetNodeFlag(events.NodeCreatePodResponse).Return(scenario.ResponseUnset, nil)
+
+	// Execute
+	out, err := executor(events.PodCreatePodResponse)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, out)
+}
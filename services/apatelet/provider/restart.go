@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+const (
+	// initialBackoff is the time the kubelet waits before restarting a container which terminated for the first time
+	initialBackoff = 10 * time.Second
+
+	// maxBackoff is the maximum time the kubelet waits before restarting a container, the backoff doubles up to it
+	maxBackoff = 5 * time.Minute
+
+	// backoffReset is how long a container has to run before its backoff is reset, just like the kubelet does
+	backoffReset = 2 * maxBackoff
+
+	// maxRestartSteps bounds the amount of terminations and restarts simulated in a single evaluation
+	maxRestartSteps = 1000
+)
+
+// restartTracker keeps track of the terminations and restarts of every container of every pod
+type restartTracker struct {
+	lock sync.Mutex
+	pods map[types.UID]map[string]*containerRun
+}
+
+// termination describes why and when a container terminated
+type termination struct {
+	at       time.Time
+	exitCode int32
+	reason   string
+
+	// The message used for the pod if the termination is final
+	message string
+}
+
+// containerRun is the current run of a container
+type containerRun struct {
+	// The amount of times the container has been restarted
+	restarts int32
+
+	// When the current run started
+	startedAt time.Time
+
+	// Why the current run terminated, nil while the container is running
+	terminated *termination
+
+	// Why the previous run terminated, nil if the container has not been restarted
+	last *termination
+
+	// The time the kubelet waits before restarting the container after the current run terminates
+	backoff time.Duration
+
+	// When the current run crashes according to the crash model it was sampled from, zero if it doesn't crash
+	crashModel *scenario.CrashModel
+	crashAt    time.Time
+
+	// The states of the probes of the current run
+	readiness probeState
+	liveness  probeState
+
+	// Whether the readiness probe has made the current run ready
+	ready bool
+}
+
+func newRestartTracker() *restartTracker {
+	return &restartTracker{
+		pods: make(map[types.UID]map[string]*containerRun),
+	}
+}
+
+// restartable returns whether the kubelet restarts a container which terminated with the given exit code
+func restartable(policy corev1.RestartPolicy, exitCode int32) bool {
+	switch policy {
+	case corev1.RestartPolicyNever:
+		return false
+	case corev1.RestartPolicyOnFailure:
+		return exitCode != 0
+	default:
+		return true
+	}
+}
+
+// evaluate returns the state of the given container of the given pod at the given time, the container first started at startedAt
+// Exit is why the container should terminate according to the pod flags, nil if it should be running
+// The container terminates when exit happened if that is known, and otherwise at the given time
+// While it is running, it may still crash according to the crash model or be killed because its liveness probe fails
+func (r *restartTracker) evaluate(pod *corev1.Pod, container corev1.Container, startedAt time.Time, exit *termination, crash *scenario.CrashModel,
+	probes scenario.Probes, now time.Time, random scenario.RandomSource) containerRun {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.pods[pod.UID]; !ok {
+		r.pods[pod.UID] = make(map[string]*containerRun)
+	}
+
+	run, ok := r.pods[pod.UID][container.Name]
+	if !ok {
+		run = &containerRun{startedAt: startedAt}
+		r.pods[pod.UID][container.Name] = run
+	}
+
+	if run.terminated == nil {
+		run.observeProbes(container, probes, now)
+	}
+
+	restarted := false
+	for step := 0; step < maxRestartSteps; step++ {
+		if run.terminated == nil {
+			switch {
+			case exit != nil:
+				// A container restarted during this evaluation terminates as soon as it starts
+				at := now
+				if !exit.at.IsZero() {
+					at = exit.at
+				}
+				if restarted {
+					at = run.startedAt
+				}
+				run.terminate(*exit, at)
+			default:
+				crashed := run.crashes(crash, now, random)
+				killedAt, killed := run.livenessFails(container, now)
+
+				switch {
+				case killed && (!crashed || killedAt.Before(run.crashAt)):
+					run.terminate(termination{
+						exitCode: 137,
+						reason:   "Error",
+						message:  "Emulated container has failed its liveness probe",
+					}, killedAt)
+				case crashed:
+					run.terminate(termination{
+						exitCode: crash.ExitCode,
+						reason:   "Error",
+						message:  "Emulated container has crashed",
+					}, run.crashAt)
+				default:
+					run.ready = run.isReady(container, now)
+					return *run
+				}
+			}
+		}
+
+		if !restartable(pod.Spec.RestartPolicy, run.terminated.exitCode) {
+			return *run
+		}
+
+		restartAt := run.terminated.at.Add(run.backoff)
+		if now.Before(restartAt) {
+			return *run
+		}
+
+		run.restarts++
+		run.startedAt = restartAt
+		run.last = run.terminated
+		run.terminated = nil
+		run.crashModel = nil
+		run.resetProbes(probes)
+		restarted = true
+	}
+
+	return *run
+}
+
+// terminate ends the current run at the given time, updating the backoff like the kubelet does
+func (c *containerRun) terminate(t termination, at time.Time) {
+	if at.Before(c.startedAt) {
+		at = c.startedAt
+	}
+
+	t.at = at
+	c.terminated = &t
+	c.ready = false
+
+	switch {
+	case c.backoff == 0 || at.Sub(c.startedAt) >= backoffReset:
+		c.backoff = initialBackoff
+	case c.backoff*2 > maxBackoff:
+		c.backoff = maxBackoff
+	default:
+		c.backoff *= 2
+	}
+}
+
+// crashes returns whether the current run has crashed before the given time according to the crash model
+// The crash time is sampled once for every run and crash model
+func (c *containerRun) crashes(crash *scenario.CrashModel, now time.Time, random scenario.RandomSource) bool {
+	if crash == nil {
+		return false
+	}
+
+	if c.crashModel != crash {
+		c.crashModel = crash
+		c.crashAt = time.Time{}
+
+		if after, ok := crash.SampleCrash(random); ok {
+			c.crashAt = c.startedAt.Add(after)
+		}
+	}
+
+	return !c.crashAt.IsZero() && !c.crashAt.After(now)
+}
+
+// current returns the current run of the given container of the pod with the given uid, as it was last evaluated
+func (r *restartTracker) current(uid types.UID, container string) (containerRun, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	run, ok := r.pods[uid][container]
+	if !ok {
+		return containerRun{}, false
+	}
+
+	return *run, true
+}
+
+// remove forgets the restarts of the containers of the pod with the given uid
+func (r *restartTracker) remove(uid types.UID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.pods, uid)
+}
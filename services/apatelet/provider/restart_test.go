@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+func createRestartPod(policy corev1.RestartPolicy, start time.Time) *corev1.Pod {
+	startTime := metav1.NewTime(start)
+
+	pod := &corev1.Pod{}
+	pod.UID = "uid"
+	pod.Spec.RestartPolicy = policy
+	pod.Status.StartTime = &startTime
+	return pod
+}
+
+func TestRestartBackoff(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	pod := createRestartPod(corev1.RestartPolicyAlways, start)
+	tracker := newRestartTracker()
+	exit := &termination{exitCode: 1, reason: "Error"}
+	random := fixedRandom{}
+
+	run := tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{}, start.Add(time.Minute), random)
+	assert.Nil(t, run.terminated)
+	assert.Equal(t, int32(0), run.restarts)
+
+	// The container fails and waits for the initial backoff
+	failedAt := start.Add(2 * time.Minute)
+	run = tracker.evaluate(pod, corev1.Container{}, start, exit, nil, scenario.Probes{}, failedAt, random)
+	assert.Equal(t, failedAt, run.terminated.at)
+	assert.Equal(t, initialBackoff, run.backoff)
+	assert.Equal(t, int32(0), run.restarts)
+
+	// After 10s it restarts and fails again, then 20s later once more
+	run = tracker.evaluate(pod, corev1.Container{}, start, exit, nil, scenario.Probes{}, failedAt.Add(35*time.Second), random)
+	assert.Equal(t, int32(2), run.restarts)
+	assert.Equal(t, failedAt.Add(30*time.Second), run.terminated.at)
+	assert.Equal(t, 4*initialBackoff, run.backoff)
+
+	// The backoff is capped
+	run = tracker.evaluate(pod, corev1.Container{}, start, exit, nil, scenario.Probes{}, failedAt.Add(time.Hour), random)
+	assert.Equal(t, maxBackoff, run.backoff)
+
+	// Once the pod may run again, it is restarted after the backoff
+	run = tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{}, failedAt.Add(2*time.Hour), random)
+	assert.Nil(t, run.terminated)
+	assert.Equal(t, exit.exitCode, run.last.exitCode)
+}
+
+func TestRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	random := fixedRandom{}
+	failed := &termination{exitCode: 1}
+	succeeded := &termination{exitCode: 0}
+
+	// Never restarts
+	tracker := newRestartTracker()
+	pod := createRestartPod(corev1.RestartPolicyNever, start)
+	tracker.evaluate(pod, corev1.Container{}, start, failed, nil, scenario.Probes{}, start, random)
+	run := tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{}, start.Add(time.Hour), random)
+	assert.Equal(t, int32(0), run.restarts)
+	assert.NotNil(t, run.terminated)
+
+	// On failure only restarts failed containers
+	tracker = newRestartTracker()
+	pod = createRestartPod(corev1.RestartPolicyOnFailure, start)
+	tracker.evaluate(pod, corev1.Container{}, start, succeeded, nil, scenario.Probes{}, start, random)
+	run = tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{}, start.Add(time.Hour), random)
+	assert.Equal(t, int32(0), run.restarts)
+	assert.NotNil(t, run.terminated)
+
+	// Always restarts succeeded containers as well
+	tracker = newRestartTracker()
+	pod = createRestartPod(corev1.RestartPolicyAlways, start)
+	tracker.evaluate(pod, corev1.Container{}, start, succeeded, nil, scenario.Probes{}, start, random)
+	run = tracker.evaluate(pod, corev1.Container{}, start, nil, nil, scenario.Probes{}, start.Add(time.Hour), random)
+	assert.Equal(t, int32(1), run.restarts)
+	assert.Nil(t, run.terminated)
+}
+
+func TestRestartCrash(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	pod := createRestartPod(corev1.RestartPolicyAlways, start)
+	tracker := newRestartTracker()
+	crash := &scenario.CrashModel{After: time.Minute, ExitCode: 3}
+	random := fixedRandom{}
+
+	run := tracker.evaluate(pod, corev1.Container{}, start, nil, crash, scenario.Probes{}, start.Add(30*time.Second), random)
+	assert.Nil(t, run.terminated)
+
+	// Crashes after a minute, restarts 10s later and crashes again a minute after that
+	run = tracker.evaluate(pod, corev1.Container{}, start, nil, crash, scenario.Probes{}, start.Add(2*time.Minute+15*time.Second), random)
+	assert.Equal(t, int32(1), run.restarts)
+	assert.Equal(t, start.Add(2*time.Minute+10*time.Second), run.terminated.at)
+	assert.Equal(t, int32(3), run.terminated.exitCode)
+	assert.Equal(t, 2*initialBackoff, run.backoff)
+
+	// Containers which run long enough get their backoff reset
+	crash = &scenario.CrashModel{After: time.Hour, ExitCode: 3}
+	run = tracker.evaluate(pod, corev1.Container{}, start, nil, crash, scenario.Probes{}, start.Add(3*time.Hour), random)
+	assert.Equal(t, initialBackoff, run.backoff)
+}
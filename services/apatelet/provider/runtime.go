@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// runtimeTracker keeps track of the sampled runtime of every pod
+type runtimeTracker struct {
+	lock sync.Mutex
+	pods map[types.UID]*sampledRuntime
+}
+
+// sampledRuntime is the runtime of a pod, sampled from the runtime model it was last evaluated with
+type sampledRuntime struct {
+	model   *scenario.RuntimeModel
+	runtime scenario.PodRuntime
+
+	// Whether the pod completes at all
+	completes bool
+}
+
+func newRuntimeTracker() *runtimeTracker {
+	return &runtimeTracker{
+		pods: make(map[types.UID]*sampledRuntime),
+	}
+}
+
+// evaluateRuntime returns why the containers of the pod, which started at startedAt, have terminated according to its runtime model
+// It returns nil if the runtime of the pod hasn't elapsed yet at the given time, or if the pod runs indefinitely
+func (p *Provider) evaluateRuntime(pod *corev1.Pod, startedAt, now time.Time) (*termination, error) {
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodRuntime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod runtime flag")
+	}
+
+	model, ok := flag.(*scenario.RuntimeModel)
+	if !ok {
+		return nil, errors.Errorf("invalid pod runtime flag %v", flag)
+	}
+
+	tracker := p.runtimes
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	// The runtime is sampled once for every pod and runtime model
+	sampled, ok := tracker.pods[pod.UID]
+	if !ok || sampled.model != model {
+		runtime, completes, err := model.Sample(pod.Annotations, p.random)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sample pod runtime")
+		}
+
+		sampled = &sampledRuntime{model: model, runtime: runtime, completes: completes}
+		tracker.pods[pod.UID] = sampled
+	}
+
+	completedAt := startedAt.Add(sampled.runtime.Runtime)
+	if !sampled.completes || now.Before(completedAt) {
+		return nil, nil
+	}
+
+	if sampled.runtime.Failed {
+		return &termination{
+			at:       completedAt,
+			exitCode: model.ExitCode,
+			reason:   "Error",
+			message:  fmt.Sprintf("Emulated pod has failed after running for %v", sampled.runtime.Runtime),
+		}, nil
+	}
+
+	return &termination{
+		at:       completedAt,
+		exitCode: 0,
+		reason:   "Completed",
+		message:  fmt.Sprintf("Emulated pod has completed after running for %v", sampled.runtime.Runtime),
+	}, nil
+}
+
+// remove forgets the runtime of the pod with the given uid
+func (r *runtimeTracker) remove(uid types.UID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.pods, uid)
+}
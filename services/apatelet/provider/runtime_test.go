@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+	"github.com/atlarge-research/apate/services/apatelet/store"
+	"github.com/atlarge-research/apate/services/apatelet/store/mock_store"
+)
+
+func prepareRuntime(t *testing.T, model *scenario.RuntimeModel) (*Provider, *corev1.Pod, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	ms := mock_store.NewMockStore(ctrl)
+
+	pod := &corev1.Pod{}
+	pod.UID = "uid"
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	pod.Spec.Containers = []corev1.Container{{Name: "job"}}
+
+	ms.EXPECT().GetPodFlag(pod, events.PodRuntime).Return(model, nil).AnyTimes()
+
+	var s store.Store = ms
+	prov := &Provider{
+		Store:    &s,
+		random:   newRandom(0),
+		runtimes: newRuntimeTracker(),
+		restarts: newRestartTracker(),
+	}
+
+	return prov, pod, ctrl
+}
+
+func TestEvaluateRuntimeSucceeded(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareRuntime(t, &scenario.RuntimeModel{Runtime: scenario.ConstantLatency(time.Minute)})
+	defer ctrl.Finish()
+
+	start := time.Now().Add(-2 * time.Minute)
+
+	exit, err := prov.evaluateRuntime(pod, start, start.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, exit)
+
+	exit, err = prov.evaluateRuntime(pod, start, start.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), exit.exitCode)
+	assert.Equal(t, start.Add(time.Minute), exit.at)
+
+	// The containers terminate when the runtime elapsed, not when the status is requested
+	status := prov.emulatePod(pod, start, exit, nil, nil, nil, scenario.Probes{})
+	assert.Equal(t, corev1.PodSucceeded, status.Phase)
+	assert.Equal(t, start.Add(time.Minute).Unix(), status.ContainerStatuses[0].State.Terminated.FinishedAt.Unix())
+}
+
+func TestEvaluateRuntimeFailed(t *testing.T) {
+	t.Parallel()
+
+	prov, pod, ctrl := prepareRuntime(t, &scenario.RuntimeModel{Annotation: "runtime", FailureProbability: 1, ExitCode: 3})
+	defer ctrl.Finish()
+
+	start := time.Now()
+
+	// Pods without the annotation run indefinitely
+	exit, err := prov.evaluateRuntime(pod, start, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, exit)
+
+	pod.UID = "annotated"
+	pod.Annotations = map[string]string{"runtime": "10s"}
+	exit, err = prov.evaluateRuntime(pod, start, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), exit.exitCode)
+	assert.Equal(t, start.Add(10*time.Second), exit.at)
+
+	status := prov.emulatePod(pod, start, exit, nil, nil, nil, scenario.Probes{})
+	assert.Equal(t, corev1.PodFailed, status.Phase)
+}
@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// startupPhase is a phase a pod goes through before its containers run
+type startupPhase int
+
+const (
+	// startupScheduled means the pod has been scheduled, but the node hasn't started pulling its images yet
+	startupScheduled startupPhase = iota
+	// startupImagePulling means the images of the pod are being pulled
+	startupImagePulling
+	// startupContainerCreating means the containers of the pod are being created
+	startupContainerCreating
+	// startupDone means the containers of the pod have been started
+	startupDone
+	// startupImagePullFailed means pulling the images of the pod has failed, and will be retried after a backoff
+	startupImagePullFailed
+)
+
+// startupTracker keeps track of the startup phases of every pod
+type startupTracker struct {
+	lock sync.Mutex
+	pods map[types.UID]*startupState
+}
+
+// startupState contains the durations and progress of the startup of a pod
+type startupState struct {
+	// When the scheduled phase ends
+	scheduledUntil time.Time
+
+	// The latency of pulling an image, and the duration of the container creating phase
+	pullLatency time.Duration
+	creating    time.Duration
+
+	// When the images of the pod have been pulled, zero while this isn't known yet
+	pulledAt time.Time
+
+	// When pulling the images of the pod first failed, zero if it hasn't
+	pullFailedAt time.Time
+}
+
+// podStartup is the startup of a pod at some point in time
+type podStartup struct {
+	phase startupPhase
+
+	// When the current phase ends, or when the containers started if the startup is done
+	// If pulling the images has failed, it is when this happened for the first time
+	until time.Time
+
+	// The image which couldn't be pulled if pulling the images has failed
+	image string
+}
+
+func newStartupTracker() *startupTracker {
+	return &startupTracker{
+		pods: make(map[types.UID]*startupState),
+	}
+}
+
+// getStartupPhases returns the startup phases of the pod, of which the unset phases are taken from the node
+func (p *Provider) getStartupPhases(pod *corev1.Pod) (*scenario.StartupPhases, error) {
+	podFlag, err := (*p.Store).GetPodFlag(pod, events.PodStartup)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod startup flag")
+	}
+
+	podPhases, ok := podFlag.(*scenario.StartupPhases)
+	if !ok {
+		return nil, errors.Errorf("invalid pod startup flag %v", podFlag)
+	}
+
+	nodeFlag, err := (*p.Store).GetNodeFlag(events.NodePodStartup)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node pod startup flag")
+	}
+
+	nodePhases, ok := nodeFlag.(*scenario.StartupPhases)
+	if !ok {
+		return nil, errors.Errorf("invalid node pod startup flag %v", nodeFlag)
+	}
+
+	return podPhases.WithDefaults(nodePhases), nil
+}
+
+// evaluateStartup returns the startup of the given pod at the given time
+func (p *Provider) evaluateStartup(pod *corev1.Pod, now time.Time) (podStartup, error) {
+	tracker := p.startups
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	state, ok := tracker.pods[pod.UID]
+	if !ok {
+		phases, err := p.getStartupPhases(pod)
+		if err != nil {
+			return podStartup{}, errors.Wrap(err, "failed to get startup phases")
+		}
+
+		// The durations of the phases are sampled once, when the pod is first seen
+		startedAt := now
+		if pod.Status.StartTime != nil {
+			startedAt = pod.Status.StartTime.Time
+		}
+
+		durations := phases.Sample(p.random)
+		state = &startupState{
+			scheduledUntil: startedAt.Add(durations[0]),
+			pullLatency:    durations[1],
+			creating:       durations[2],
+		}
+		tracker.pods[pod.UID] = state
+	}
+
+	if now.Before(state.scheduledUntil) {
+		return podStartup{phase: startupScheduled, until: state.scheduledUntil}, nil
+	}
+
+	if state.pulledAt.IsZero() {
+		if failed, err := p.pullImages(pod, state, now); err != nil || failed.phase == startupImagePullFailed {
+			return failed, err
+		}
+	}
+
+	if now.Before(state.pulledAt) {
+		return podStartup{phase: startupImagePulling, until: state.pulledAt}, nil
+	}
+
+	created := state.pulledAt.Add(state.creating)
+	if now.Before(created) {
+		return podStartup{phase: startupContainerCreating, until: created}, nil
+	}
+
+	return podStartup{phase: startupDone, until: created}, nil
+}
+
+// pullImages starts pulling the images of the pod, unless pulling them fails
+// After a failure the pull is retried with the same backoff the kubelet uses
+func (p *Provider) pullImages(pod *corev1.Pod, state *startupState, now time.Time) (podStartup, error) {
+	node, err := p.getNodeImages()
+	if err != nil {
+		return podStartup{}, errors.Wrap(err, "failed to get node images")
+	}
+
+	imagePull, err := p.getPodImagePull(pod)
+	if err != nil {
+		return podStartup{}, errors.Wrap(err, "failed to get pod image pull")
+	}
+
+	cache := p.images
+	images := podImages(pod)
+
+	if image, missing := cache.missing(images, node); imagePull.Fail && missing {
+		if state.pullFailedAt.IsZero() {
+			state.pullFailedAt = state.scheduledUntil
+		}
+
+		return podStartup{phase: startupImagePullFailed, until: state.pullFailedAt, image: image}, nil
+	}
+
+	from := state.scheduledUntil
+	if !state.pullFailedAt.IsZero() {
+		from = nextImagePullRetry(state.pullFailedAt, now)
+	}
+
+	size := imagePull.Size
+	if size == 0 {
+		size = node.DefaultSize
+	}
+
+	state.pulledAt = cache.pull(images, from, state.pullLatency, size, node)
+	return podStartup{}, nil
+}
+
+// remove forgets the startup of the pod with the given uid
+func (s *startupTracker) remove(uid types.UID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pods, uid)
+}
+
+// podStarting is the status of a pod of which the containers haven't been started yet
+func (p *Provider) podStarting(pod *corev1.Pod, startup podStartup) *corev1.PodStatus {
+	now := metav1.Now()
+	status := &corev1.PodStatus{
+		Phase:   corev1.PodPending,
+		Message: "Pod is starting",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionTrue,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+			},
+		},
+	}
+
+	// Like the kubelet, container statuses are only reported once the node has started working on the pod
+	if startup.phase == startupScheduled {
+		return status
+	}
+
+	status.Conditions = append(status.Conditions, corev1.PodCondition{
+		Type:               corev1.PodInitialized,
+		Status:             corev1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	}, corev1.PodCondition{
+		Type:               corev1.PodReady,
+		Status:             corev1.ConditionFalse,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             "ContainersNotReady",
+	})
+
+	waiting := &corev1.ContainerStateWaiting{
+		Reason:  "ContainerCreating",
+		Message: "Creating containers",
+	}
+
+	switch startup.phase {
+	case startupImagePulling:
+		waiting.Message = "Pulling images"
+	case startupImagePullFailed:
+		// The first failure is reported as is, after which the kubelet backs off
+		waiting.Reason = "ImagePullBackOff"
+		waiting.Message = fmt.Sprintf("Back-off pulling image %q", startup.image)
+		if time.Since(startup.until) < initialBackoff {
+			waiting.Reason = "ErrImagePull"
+			waiting.Message = fmt.Sprintf("Failed to pull image %q: emulated registry failure", startup.image)
+		}
+	}
+
+	status.ContainerStatuses = p.createContainerStatuses(pod, false, corev1.ContainerState{Waiting: waiting})
+
+	return status
+}
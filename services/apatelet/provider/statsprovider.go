@@ -9,8 +9,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
-	"github.com/atlarge-research/apate/pkg/scenario/events"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -94,35 +92,44 @@ func (p *Provider) memoryStats(pods []stats.PodStats) (uint64, uint64) {
 		usage += pod.UsageBytesMemory
 	}
 
-	available := uint64(p.Resources.Memory) - usage
+	available := available(uint64(p.resources().Memory), usage)
 
 	return available, usage
 }
 
 func (p *Provider) ephemeralStats(pods []stats.PodStats) (uint64, uint64, uint64) {
-	capacity := uint64(p.Resources.EphemeralStorage)
+	capacity := uint64(p.resources().EphemeralStorage)
 	usage := uint64(0)
 
 	for _, pod := range pods {
 		usage += pod.UsedBytesEphemeral
 	}
 
-	free := capacity - usage
+	free := available(capacity, usage)
 	return free, capacity, usage
 }
 
 func (p *Provider) storageStats(pods []stats.PodStats) (uint64, uint64, uint64) {
-	capacity := uint64(p.Resources.Storage)
+	capacity := uint64(p.resources().Storage)
 	usage := uint64(0)
 
 	for _, pod := range pods {
 		usage += pod.UsedBytesStorage
 	}
 
-	free := capacity - usage
+	free := available(capacity, usage)
 	return free, capacity, usage
 }
 
+// available returns the amount of a resource which is not used, which is 0 if the capacity has been lowered below the usage
+func available(capacity, usage uint64) uint64 {
+	if usage > capacity {
+		return 0
+	}
+
+	return capacity - usage
+}
+
 func (p *Provider) getAggregatePodStats() []stats.PodStats {
 	var statistics []stats.PodStats
 
@@ -134,15 +141,9 @@ func (p *Provider) getAggregatePodStats() []stats.PodStats {
 }
 
 func (p *Provider) getPodStats(pod *corev1.Pod) *stats.PodStats {
-	unconvertedStats, err := (*p.Store).GetPodFlag(pod, events.PodResources)
+	statistics, err := p.getPodUsage(pod)
 	if err != nil {
-		log.Printf("error while retrieving pod flag for resources: %v\n", err)
-		return addPodSpecificStats(pod, &stats.PodStats{})
-	}
-
-	statistics, ok := unconvertedStats.(*stats.PodStats)
-	if !ok {
-		log.Printf("unable to convert '%v' to PodStats\n", unconvertedStats)
+		log.Printf("error while retrieving pod resources: %v\n", err)
 		return addPodSpecificStats(pod, &stats.PodStats{})
 	}
 
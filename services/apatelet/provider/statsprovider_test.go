@@ -46,10 +46,12 @@ func createProvider(t *testing.T, cpu, mem, fs int64) (*Provider, *gomock.Contro
 	assert.NoError(t, err)
 
 	ms.EXPECT().AddPodFlagListener(events.PodResources, gomock.Any())
+	ms.EXPECT().AddNodeFlagListener(events.NodeCapacity, gomock.Any())
+	ms.EXPECT().AddNodeFlagListener(gomock.Any(), gomock.Any()).Times(3)
 
 	e, err := env.ApateletEnv()
 	assert.NoError(t, err)
-	prov := NewProvider(pm, NewStats(), &res, &provider.InitConfig{}, &info, &s, true, e)
+	prov := NewProvider(pm, NewStats(), &res, &provider.InitConfig{}, &info, &s, true, e, nil)
 	p := prov.(*Provider)
 	return p, ctrl, ms, pm
 }
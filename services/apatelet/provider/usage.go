@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// usageUpdateInterval is how often the stats summary is updated, so usage curves are seen to change gradually
+const usageUpdateInterval = time.Second
+
+// usageTracker keeps track of how the resource usage of every pod evolves, so it can change gradually
+type usageTracker struct {
+	lock sync.Mutex
+	pods map[types.UID]*podUsage
+}
+
+// podUsage is the state of the usage curve of a single pod
+type podUsage struct {
+	// The value of the pod resources flag the curve belongs to, used to detect when a new usage has been set
+	flag interface{}
+
+	// When the current flag value was first seen, and what the usage was at that time
+	since time.Time
+	from  stats.PodStats
+
+	// The most recently returned usage
+	last stats.PodStats
+
+	// The position of the random walk and the amount of steps it has taken
+	walk  float64
+	steps int64
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{
+		pods: make(map[types.UID]*podUsage),
+	}
+}
+
+// getPodUsage returns the current resource usage of the given pod
+func (p *Provider) getPodUsage(pod *corev1.Pod) (*stats.PodStats, error) {
+	flag, err := (*p.Store).GetPodFlag(pod, events.PodResources)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pod resources flag")
+	}
+
+	usage, err := p.usage.evaluate(pod.UID, flag, time.Now(), p.random)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate pod resources")
+	}
+
+	containers, err := p.getContainerStates(pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get container states")
+	}
+
+	// Containers with their own resources use them on top of the resources of the pod
+	containerUsage := getContainerUsage(pod, containers)
+	usage.UsageNanoCores += containerUsage.UsageNanoCores
+	usage.UsageBytesMemory += containerUsage.UsageBytesMemory
+	usage.UsedBytesEphemeral += containerUsage.UsedBytesEphemeral
+	usage.UsedBytesStorage += containerUsage.UsedBytesStorage
+
+	return usage, nil
+}
+
+// evaluate returns the usage of the pod with the given uid at the given time, given the current value of its pod resources flag
+func (u *usageTracker) evaluate(uid types.UID, flag interface{}, now time.Time, random scenario.RandomSource) (*stats.PodStats, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	state, ok := u.pods[uid]
+	if !ok {
+		state = &podUsage{flag: flag, since: now}
+		u.pods[uid] = state
+	} else if state.flag != flag {
+		// A new usage has been set, so the curve starts from the last known usage
+		*state = podUsage{flag: flag, since: now, from: state.last}
+	}
+
+	var usage stats.PodStats
+	switch f := flag.(type) {
+	case *stats.PodStats:
+		usage = *f
+	case *scenario.ResourceCurve:
+		elapsed := now.Sub(state.since)
+		for steps := f.Curve.Steps(elapsed); state.steps < steps; state.steps++ {
+			state.walk = f.Curve.Walk(state.walk, random)
+		}
+
+		value := func(from, target uint64) uint64 {
+			return uint64(math.Max(0, math.Round(f.Curve.Value(float64(from), float64(target), elapsed, state.walk))))
+		}
+
+		usage = stats.PodStats{
+			UsageNanoCores:     value(state.from.UsageNanoCores, f.Usage.UsageNanoCores),
+			UsageBytesMemory:   value(state.from.UsageBytesMemory, f.Usage.UsageBytesMemory),
+			UsedBytesEphemeral: value(state.from.UsedBytesEphemeral, f.Usage.UsedBytesEphemeral),
+			UsedBytesStorage:   value(state.from.UsedBytesStorage, f.Usage.UsedBytesStorage),
+		}
+	default:
+		return nil, errors.Errorf("unable to convert '%v' to pod resources", flag)
+	}
+
+	state.last = usage
+	return &usage, nil
+}
+
+// remove forgets the usage of the pod with the given uid
+func (u *usageTracker) remove(uid types.UID) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	delete(u.pods, uid)
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+type fixedRandom struct {
+	normal float64
+}
+
+func (f fixedRandom) Float64() float64     { return 0 }
+func (f fixedRandom) NormFloat64() float64 { return f.normal }
+func (f fixedRandom) ExpFloat64() float64  { return 0 }
+
+func TestUsageTrackerStep(t *testing.T) {
+	t.Parallel()
+
+	tracker := newUsageTracker()
+	usage, err := tracker.evaluate("a", &stats.PodStats{UsageNanoCores: 42}, time.Now(), fixedRandom{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), usage.UsageNanoCores)
+}
+
+func TestUsageTrackerLinear(t *testing.T) {
+	t.Parallel()
+
+	tracker := newUsageTracker()
+	start := time.Now()
+
+	_, err := tracker.evaluate("a", &stats.PodStats{UsageBytesMemory: 100}, start, fixedRandom{})
+	assert.NoError(t, err)
+
+	curve := &scenario.ResourceCurve{
+		Usage: &stats.PodStats{UsageBytesMemory: 200},
+		Curve: scenario.UsageCurve{Shape: scenario.UsageLinear, Ramp: 10 * time.Second},
+	}
+
+	// The ramp starts from the previous usage once the new usage is first seen
+	usage, err := tracker.evaluate("a", curve, start.Add(time.Second), fixedRandom{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), usage.UsageBytesMemory)
+
+	usage, err = tracker.evaluate("a", curve, start.Add(6*time.Second), fixedRandom{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(150), usage.UsageBytesMemory)
+
+	usage, err = tracker.evaluate("a", curve, start.Add(time.Minute), fixedRandom{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200), usage.UsageBytesMemory)
+
+	// Other pods start from scratch
+	usage, err = tracker.evaluate("b", curve, start.Add(6*time.Second), fixedRandom{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), usage.UsageBytesMemory)
+}
+
+func TestUsageTrackerRandomWalk(t *testing.T) {
+	t.Parallel()
+
+	tracker := newUsageTracker()
+	start := time.Now()
+
+	curve := &scenario.ResourceCurve{
+		Usage: &stats.PodStats{UsageNanoCores: 1000},
+		Curve: scenario.UsageCurve{Shape: scenario.UsageRandomWalk, Interval: time.Second, Amplitude: 0.2},
+	}
+
+	_, err := tracker.evaluate("a", curve, start, fixedRandom{normal: 1})
+	assert.NoError(t, err)
+
+	usage, err := tracker.evaluate("a", curve, start.Add(2*time.Second), fixedRandom{normal: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1100), usage.UsageNanoCores)
+
+	// The walk is bounded by the amplitude
+	usage, err = tracker.evaluate("a", curve, start.Add(time.Minute), fixedRandom{normal: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1200), usage.UsageNanoCores)
+}
+
+func TestUsageTrackerInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	tracker := newUsageTracker()
+	_, err := tracker.evaluate("a", "resources", time.Now(), fixedRandom{})
+	assert.Error(t, err)
+
+	tracker.remove("a")
+	assert.Empty(t, tracker.pods)
+}
@@ -76,6 +76,11 @@ func StartApateletInternalWithStopCh(originalCtx context.Context, apateletEnv en
 		return errors.Wrap(err, "failed to start health client")
 	}
 
+	// Report the status of the pod configurations
+	if err = startPodStatusReporter(ctx, connectionInfo, res.UUID, &st); err != nil {
+		return errors.Wrap(err, "failed to start pod status reporter")
+	}
+
 	// Start the Apatelet
 	nc, err := vkProvider.CreateProvider(&apateletEnv, res, &st)
 	if err != nil {
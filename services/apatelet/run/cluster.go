@@ -50,7 +50,7 @@ func createInformers(config *kubeconfig.KubeConfig, st store.Store, stopInformer
 		return errors.Wrap(err, "failed creating crd pod informer")
 	}
 
-	err = crdNode.CreateNodeInformer(config, &st, res.Label, stopInformerCh.GetChannel(), sch.WakeScheduler)
+	err = crdNode.CreateNodeInformer(config, &st, res.Label, res.Index, stopInformerCh.GetChannel(), sch.WakeScheduler)
 	if err != nil {
 		return errors.Wrap(err, "failed creating crd node informer")
 	}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/atlarge-research/apate/pkg/channel"
 
@@ -14,12 +15,18 @@ import (
 
 	healthpb "github.com/atlarge-research/apate/api/health"
 	"github.com/atlarge-research/apate/internal/service"
+	"github.com/atlarge-research/apate/pkg/clients/controlplane"
 	"github.com/atlarge-research/apate/pkg/clients/health"
 	"github.com/atlarge-research/apate/services/apatelet/scheduler"
 	vkService "github.com/atlarge-research/apate/services/apatelet/services"
 	"github.com/atlarge-research/apate/services/apatelet/store"
 )
 
+const (
+	// podStatusInterval is the interval at which the status of the pod configurations is reported to the control plane
+	podStatusInterval = 10 * time.Second
+)
+
 func createGRPC(store *store.Store, sch *scheduler.Scheduler, listenAddress string, listenPort int, stopCh chan<- struct{}, stopInformerCh *channel.StopChannel) (*service.GRPCServer, error) {
 	// Connection settings
 	connectionInfo := service.NewConnectionInfo(listenAddress, listenPort)
@@ -62,3 +69,31 @@ func startHealth(ctx context.Context, connectionInfo *service.ConnectionInfo, uu
 	})
 	return hc, nil
 }
+
+func startPodStatusReporter(ctx context.Context, connectionInfo *service.ConnectionInfo, uuid uuid.UUID, st *store.Store) error {
+	client, err := controlplane.GetPodStatusClient(connectionInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pod status client")
+	}
+
+	go func() {
+		defer func() {
+			if err := client.Conn.Close(); err != nil {
+				log.Printf("could not close pod status connection: %v\n", err)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(podStatusInterval):
+				if err := client.ReportPodStatus(ctx, uuid.String(), (*st).GetPodConfigurationStatuses()); err != nil {
+					log.Printf("error while reporting pod status: %v\n", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
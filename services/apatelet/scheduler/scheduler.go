@@ -133,9 +133,22 @@ func (s *Scheduler) runner(ech chan<- error) (bool, time.Duration) {
 			return false, 0
 		}
 
+		// Recurring tasks are put back in the queue for their next execution
+		if next := task.Next(s.startTime, now); next != nil {
+			(*s.store).AddTask(next)
+		}
+
 		if s.prevT.Before(scheduledTime) || s.prevT.Equal(scheduledTime) {
 			s.prevT = scheduledTime
-			go s.taskHandler(ech, task)
+
+			// The flags of a task with a duration are set in a window, which is closed by a revert task once the duration is over
+			var window store.Window
+			if task.Duration > 0 {
+				window = (*s.store).OpenWindow()
+				(*s.store).AddTask(store.NewRevertTask(task.RelativeTimestamp+task.Duration, window))
+			}
+
+			go s.taskHandler(ech, task, window)
 		}
 	}
 
@@ -159,19 +172,39 @@ func (s *Scheduler) runner(ech chan<- error) (bool, time.Duration) {
 	return false, 0
 }
 
-func (s *Scheduler) taskHandler(ech chan<- error, t *store.Task) {
+// taskHandler executes the given task, setting its flags in the given window if it is not 0
+func (s *Scheduler) taskHandler(ech chan<- error, t *store.Task, window store.Window) {
 	isPod, err := t.IsPod()
 	if err != nil {
 		ech <- errors.Wrap(err, "failed to determine task type")
 		return
 	}
 
-	if isPod {
-		err := pod.SetPodFlags(s.store, t.PodTask.Label, t.PodTask.State)
+	switch {
+	case t.RevertTask != nil:
+		(*s.store).CloseWindow(t.RevertTask.Window)
+	case isPod:
+		if window != 0 {
+			err = pod.SetPodWindowFlags(s.store, window, t.PodTask.Label, t.PodTask.Subset, t.PodTask.Jitter, t.JitterKey(), t.PodTask.State)
+		} else {
+			err = pod.SetPodFlags(s.store, t.PodTask.Label, t.PodTask.Subset, t.PodTask.Jitter, t.JitterKey(), t.PodTask.State)
+		}
+
 		if err != nil {
 			ech <- errors.Wrap(err, "failed to set pod flags")
+			return
+		}
+
+		(*s.store).MarkPodTaskFired(t)
+	default:
+		if window != 0 {
+			err = node.SetNodeWindowFlags(s.store, window, t.NodeTask.State)
+		} else {
+			err = node.SetNodeFlags(s.store, t.NodeTask.State)
+		}
+
+		if err != nil {
+			ech <- errors.Wrap(err, "failed to set node flags")
 		}
-	} else {
-		node.SetNodeFlags(s.store, t.NodeTask.State)
 	}
 }
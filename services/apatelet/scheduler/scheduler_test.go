@@ -46,7 +46,7 @@ func TestTaskHandlerSimpleNode(t *testing.T) {
 	// Run code under test
 	ech := make(chan error)
 
-	sched.taskHandler(ech, store.NewNodeTask(0, task))
+	sched.taskHandler(ech, store.NewNodeTask(0, task), 0)
 
 	select {
 	case <-ech:
@@ -64,12 +64,13 @@ func TestTaskHandlerSimplePod(t *testing.T) {
 	ms := mock_store.NewMockStore(ctrl)
 
 	// Test task:
-	task := store.NewPodTask(42, "la/clappe", &podconfigv1.PodConfigurationState{
+	task := store.NewPodTask(42, "la/clappe", 0, &podconfigv1.PodConfigurationState{
 		PodStatus: podconfigv1.PodStatusFailed,
 	})
 
 	// Set up expectations
 	ms.EXPECT().SetPodFlags("la/clappe", store.Flags{events.PodStatus: scenario.PodStatusFailed})
+	ms.EXPECT().MarkPodTaskFired(task)
 
 	var s store.Store = ms
 	sched := New(&s)
@@ -77,7 +78,7 @@ func TestTaskHandlerSimplePod(t *testing.T) {
 	// Run code under test
 	ech := make(chan error)
 
-	sched.taskHandler(ech, task)
+	sched.taskHandler(ech, task, 0)
 
 	select {
 	case <-ech:
@@ -103,10 +104,10 @@ func TestTaskHandlerMultiple(t *testing.T) {
 	}
 
 	// Set up expectations
-	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(time.Duration(0), nil).AnyTimes()
+	ms.EXPECT().GetNodeFlag(events.NodeAddedLatency).Return(scenario.ConstantLatency(0), nil).AnyTimes()
 	ms.EXPECT().SetNodeFlags(store.Flags{
 		events.NodeCreatePodResponse: scenario.ResponseError,
-		events.NodeAddedLatency:      42 * time.Second,
+		events.NodeAddedLatency:      scenario.ConstantLatency(42 * time.Second),
 	})
 
 	var s store.Store = ms
@@ -115,7 +116,7 @@ func TestTaskHandlerMultiple(t *testing.T) {
 	// Run code under test
 	ech := make(chan error)
 
-	sched.taskHandler(ech, store.NewNodeTask(0, &task))
+	sched.taskHandler(ech, store.NewNodeTask(0, &task), 0)
 
 	select {
 	case <-ech:
@@ -250,7 +251,7 @@ func TestRunnerDontHandleOldTask(t *testing.T) {
 
 	// Expectations
 	ms.EXPECT().PeekTask().Return(time.Duration(10), true, nil).AnyTimes()
-	ms.EXPECT().PopTask().Return(store.NewPodTask(10, "la/clappe", &podconfigv1.PodConfigurationState{
+	ms.EXPECT().PopTask().Return(store.NewPodTask(10, "la/clappe", 0, &podconfigv1.PodConfigurationState{
 		PodStatus: podconfigv1.PodStatusUnknown,
 	}), nil)
 
@@ -369,3 +370,131 @@ func TestStartScheduler(t *testing.T) {
 	default:
 	}
 }
+
+func TestRunnerRecurringTask(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	// Test task:
+	state := nodeconfigv1.NodeConfigurationState{
+		HeartbeatFailed: true,
+	}
+
+	recurrence, err := store.NewRecurrence("1h", "", 0, "")
+	assert.NoError(t, err)
+
+	task := store.NewNodeTask(0, &state)
+	task.Recurrence = recurrence
+
+	// Expectations
+	ms.EXPECT().PeekTask().Return(time.Duration(0), true, nil)
+	ms.EXPECT().PopTask().Return(task, nil)
+	ms.EXPECT().AddTask(gomock.Any()).Do(func(next *store.Task) {
+		assert.Equal(t, time.Hour, next.RelativeTimestamp)
+		assert.Equal(t, task.NodeTask, next.NodeTask)
+	})
+	ms.EXPECT().PeekTask().Return(time.Hour, true, nil)
+	ms.EXPECT().SetNodeFlags(gomock.Any()).AnyTimes()
+
+	var s store.Store = ms
+	sched := New(&s)
+	sched.startTime = time.Now()
+
+	// Run code under test
+	ech := make(chan error, 1)
+
+	done, _ := sched.runner(ech)
+	assert.False(t, done)
+
+	select {
+	case <-ech:
+		t.Fail()
+	default:
+	}
+}
+
+func TestRunnerTaskWithDuration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	// Test task:
+	state := nodeconfigv1.NodeConfigurationState{
+		HeartbeatFailed: true,
+	}
+
+	task := store.NewNodeTask(0, &state)
+	task.Duration = time.Minute
+
+	var window store.Window = 3
+
+	// Expectations
+	ms.EXPECT().PeekTask().Return(time.Duration(0), true, nil)
+	ms.EXPECT().PopTask().Return(task, nil)
+	ms.EXPECT().OpenWindow().Return(window)
+	ms.EXPECT().AddTask(gomock.Any()).Do(func(revert *store.Task) {
+		assert.Equal(t, time.Minute, revert.RelativeTimestamp)
+		assert.Equal(t, &store.RevertTask{Window: window}, revert.RevertTask)
+	})
+	ms.EXPECT().PeekTask().Return(time.Minute, true, nil)
+	ms.EXPECT().SetNodeWindowFlags(window, gomock.Any()).AnyTimes()
+
+	var s store.Store = ms
+	sched := New(&s)
+	sched.startTime = time.Now()
+
+	// Run code under test
+	ech := make(chan error, 1)
+
+	done, _ := sched.runner(ech)
+	assert.False(t, done)
+
+	select {
+	case <-ech:
+		t.Fail()
+	default:
+	}
+}
+
+func TestTaskHandlerWindow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+
+	// Test task:
+	state := podconfigv1.PodConfigurationState{
+		CreatePodResponse: podconfigv1.ResponseError,
+	}
+	task := store.NewPodTask(0, "a/b", 0, &state)
+
+	// Expectations
+	ms.EXPECT().SetPodWindowFlags(store.Window(3), "a/b", store.Flags{
+		events.PodCreatePodResponse: scenario.ResponseError,
+	})
+	ms.EXPECT().MarkPodTaskFired(task)
+	ms.EXPECT().CloseWindow(store.Window(3))
+
+	var s store.Store = ms
+	sched := New(&s)
+
+	// Run code under test
+	ech := make(chan error, 1)
+	sched.taskHandler(ech, task, 3)
+	sched.taskHandler(ech, store.NewRevertTask(time.Minute, 3), 0)
+
+	select {
+	case <-ech:
+		t.Fail()
+	default:
+	}
+}
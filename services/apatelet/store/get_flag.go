@@ -1,6 +1,7 @@
 package store
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -37,15 +38,21 @@ func (s *store) GetPodFlag(pod *corev1.Pod, flag events.PodEventFlag) (interface
 	s.podFlagLock.Lock()
 	defer s.podFlagLock.Unlock()
 
-	label, ok := getPodLabelByPod(pod)
+	label, ok := s.getPodLabel(pod)
 	if ok {
 		if val, ok := s.podFlags[label][flag]; ok {
-			return val, nil
+			if val, ok = resolvePartial(pod, val); ok {
+				return val, nil
+			}
 		}
 
 		if val, ok := s.getPodTimeFlag(pod, flag, label); ok {
 			return val, nil
 		}
+
+		if val, ok := s.getPodTraceFlag(pod, flag, label); ok {
+			return val, nil
+		}
 	}
 
 	if dv, ok := defaultPodValues[flag]; ok {
@@ -60,6 +67,10 @@ func (s *store) GetPodFlag(pod *corev1.Pod, flag events.PodEventFlag) (interface
 // It does this by retrieving the index cache for the flag/pod combination: the last index in the podTimeFlags that is checked for the current pod
 // From this index it will continue to check next indices for the flag
 func (s *store) getPodTimeFlag(pod *corev1.Pod, flag events.PodEventFlag, label string) (interface{}, bool) {
+	if s.podTimeScan[label] {
+		return scanPodTimeFlags(pod, flag, s.podTimeFlags[label])
+	}
+
 	if _, ok := s.podTimeIndexCache[pod]; !ok {
 		s.podTimeIndexCache[pod] = make(map[events.EventFlag]int)
 	}
@@ -82,7 +93,7 @@ func (s *store) getPodTimeFlag(pod *corev1.Pod, flag events.PodEventFlag, label
 		podSinceStart := podStartTime.Add(flags.TimeSincePodStart)
 
 		// The current index contains the expected flag and is still before the podSinceStart
-		if _, ok := flags.Flags[flag]; ok && podSinceStart.Before(time.Now()) {
+		if _, ok := flags.Flags[flag]; ok && podSinceStart.Before(time.Now()) && flags.Subset.Selects(pod) {
 			previousIndex = i
 		}
 
@@ -93,7 +104,7 @@ func (s *store) getPodTimeFlag(pod *corev1.Pod, flag events.PodEventFlag, label
 			currentPodFlags := timeFlags[previousIndex]
 
 			// If this index has time flags before now (it might not have if this is the first iteration)
-			if podStartTime.Add(currentPodFlags.TimeSincePodStart).Before(time.Now()) {
+			if podStartTime.Add(currentPodFlags.TimeSincePodStart).Before(time.Now()) && currentPodFlags.Subset.Selects(pod) {
 				if pf, ok := currentPodFlags.Flags[flag]; ok {
 					// Set cache and return it
 					s.podTimeIndexCache[pod][flag] = previousIndex
@@ -109,3 +120,57 @@ func (s *store) getPodTimeFlag(pod *corev1.Pod, flag events.PodEventFlag, label
 
 	return nil, false
 }
+
+// scanPodTimeFlags returns the pod time flag that is currently active for the given pod, when some time flags only last
+// for a while or are jittered
+// As flags may be restored to earlier values once their duration is over and jitter changes the order of the time flags,
+// the index cache can't be used
+// Instead, every time flag is checked, and the active one which set the flag with the latest timestamp is used
+func scanPodTimeFlags(pod *corev1.Pod, flag events.PodEventFlag, timeFlags []*TimeFlags) (interface{}, bool) {
+	sinceStart := time.Duration(0)
+	if pod.Status.StartTime != nil {
+		sinceStart = time.Since(pod.Status.StartTime.Time)
+	}
+
+	var value interface{}
+	found := false
+	latest := time.Duration(0)
+
+	for _, flags := range timeFlags {
+		pf, ok := flags.Flags[flag]
+		if !ok || !flags.Subset.Selects(pod) {
+			continue
+		}
+
+		timestamp := flags.TimeSincePodStart + podJitter(flags.Jitter, pod, timeFlagsKey(flags))
+		if timestamp >= sinceStart {
+			continue
+		}
+
+		if flags.Duration > 0 && timestamp+flags.Duration <= sinceStart {
+			continue
+		}
+
+		// Later time flags win ties, as the time flags are sorted by timestamp
+		if !found || timestamp >= latest {
+			value, found, latest = pf, true, timestamp
+		}
+	}
+
+	return value, found
+}
+
+// timeFlagsKey identifies the given time flags when sampling jitter, so repetitions of a task get different jitter
+func timeFlagsKey(flags *TimeFlags) string {
+	return strconv.Itoa(flags.Index) + "/" + strconv.FormatInt(int64(flags.TimeSincePodStart), 10)
+}
+
+// getPodTraceFlag returns the resource usage of the given pod according to the trace of its configuration, if any
+func (s *store) getPodTraceFlag(pod *corev1.Pod, flag events.PodEventFlag, label string) (interface{}, bool) {
+	trace, ok := s.podTraces[label]
+	if !ok || flag != events.PodResources || pod.Status.StartTime == nil {
+		return nil, false
+	}
+
+	return trace.usageAt(pod, time.Since(pod.Status.StartTime.Time))
+}
@@ -5,6 +5,7 @@
 package mock_store
 
 import (
+	controlplane "github.com/atlarge-research/apate/api/controlplane"
 	store "github.com/atlarge-research/apate/services/apatelet/store"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
@@ -35,6 +36,30 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddNodeFlagListener mocks base method
+func (m *MockStore) AddNodeFlagListener(arg0 int32, arg1 func(interface{})) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddNodeFlagListener", arg0, arg1)
+}
+
+// AddNodeFlagListener indicates an expected call of AddNodeFlagListener
+func (mr *MockStoreMockRecorder) AddNodeFlagListener(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNodeFlagListener", reflect.TypeOf((*MockStore)(nil).AddNodeFlagListener), arg0, arg1)
+}
+
+// AddPod mocks base method
+func (m *MockStore) AddPod(arg0 *v1.Pod) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddPod", arg0)
+}
+
+// AddPod indicates an expected call of AddPod
+func (mr *MockStoreMockRecorder) AddPod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPod", reflect.TypeOf((*MockStore)(nil).AddPod), arg0)
+}
+
 // AddPodFlagListener mocks base method
 func (m *MockStore) AddPodFlagListener(arg0 int32, arg1 func(interface{})) {
 	m.ctrl.T.Helper()
@@ -47,6 +72,30 @@ func (mr *MockStoreMockRecorder) AddPodFlagListener(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPodFlagListener", reflect.TypeOf((*MockStore)(nil).AddPodFlagListener), arg0, arg1)
 }
 
+// AddTask mocks base method
+func (m *MockStore) AddTask(arg0 *store.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddTask", arg0)
+}
+
+// AddTask indicates an expected call of AddTask
+func (mr *MockStoreMockRecorder) AddTask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockStore)(nil).AddTask), arg0)
+}
+
+// CloseWindow mocks base method
+func (m *MockStore) CloseWindow(arg0 store.Window) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CloseWindow", arg0)
+}
+
+// CloseWindow indicates an expected call of CloseWindow
+func (mr *MockStoreMockRecorder) CloseWindow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseWindow", reflect.TypeOf((*MockStore)(nil).CloseWindow), arg0)
+}
+
 // GetNodeFlag mocks base method
 func (m *MockStore) GetNodeFlag(arg0 int32) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +111,20 @@ func (mr *MockStoreMockRecorder) GetNodeFlag(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeFlag", reflect.TypeOf((*MockStore)(nil).GetNodeFlag), arg0)
 }
 
+// GetPodConfigurationStatuses mocks base method
+func (m *MockStore) GetPodConfigurationStatuses() []*controlplane.PodConfigurationStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodConfigurationStatuses")
+	ret0, _ := ret[0].([]*controlplane.PodConfigurationStatus)
+	return ret0
+}
+
+// GetPodConfigurationStatuses indicates an expected call of GetPodConfigurationStatuses
+func (mr *MockStoreMockRecorder) GetPodConfigurationStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodConfigurationStatuses", reflect.TypeOf((*MockStore)(nil).GetPodConfigurationStatuses))
+}
+
 // GetPodFlag mocks base method
 func (m *MockStore) GetPodFlag(arg0 *v1.Pod, arg1 int32) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +140,32 @@ func (mr *MockStoreMockRecorder) GetPodFlag(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodFlag", reflect.TypeOf((*MockStore)(nil).GetPodFlag), arg0, arg1)
 }
 
+// MarkPodTaskFired mocks base method
+func (m *MockStore) MarkPodTaskFired(arg0 *store.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkPodTaskFired", arg0)
+}
+
+// MarkPodTaskFired indicates an expected call of MarkPodTaskFired
+func (mr *MockStoreMockRecorder) MarkPodTaskFired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPodTaskFired", reflect.TypeOf((*MockStore)(nil).MarkPodTaskFired), arg0)
+}
+
+// OpenWindow mocks base method
+func (m *MockStore) OpenWindow() store.Window {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenWindow")
+	ret0, _ := ret[0].(store.Window)
+	return ret0
+}
+
+// OpenWindow indicates an expected call of OpenWindow
+func (mr *MockStoreMockRecorder) OpenWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenWindow", reflect.TypeOf((*MockStore)(nil).OpenWindow))
+}
+
 // PeekTask mocks base method
 func (m *MockStore) PeekTask() (time.Duration, bool, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +197,18 @@ func (mr *MockStoreMockRecorder) PopTask() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopTask", reflect.TypeOf((*MockStore)(nil).PopTask))
 }
 
+// RemovePod mocks base method
+func (m *MockStore) RemovePod(arg0 *v1.Pod) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemovePod", arg0)
+}
+
+// RemovePod indicates an expected call of RemovePod
+func (mr *MockStoreMockRecorder) RemovePod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePod", reflect.TypeOf((*MockStore)(nil).RemovePod), arg0)
+}
+
 // RemovePodTasks mocks base method
 func (m *MockStore) RemovePodTasks(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -148,6 +249,30 @@ func (mr *MockStoreMockRecorder) SetNodeTasks(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeTasks", reflect.TypeOf((*MockStore)(nil).SetNodeTasks), arg0)
 }
 
+// SetNodeWindowFlags mocks base method
+func (m *MockStore) SetNodeWindowFlags(arg0 store.Window, arg1 store.Flags) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNodeWindowFlags", arg0, arg1)
+}
+
+// SetNodeWindowFlags indicates an expected call of SetNodeWindowFlags
+func (mr *MockStoreMockRecorder) SetNodeWindowFlags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeWindowFlags", reflect.TypeOf((*MockStore)(nil).SetNodeWindowFlags), arg0, arg1)
+}
+
+// SetPodConfigurationError mocks base method
+func (m *MockStore) SetPodConfigurationError(arg0 string, arg1 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPodConfigurationError", arg0, arg1)
+}
+
+// SetPodConfigurationError indicates an expected call of SetPodConfigurationError
+func (mr *MockStoreMockRecorder) SetPodConfigurationError(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodConfigurationError", reflect.TypeOf((*MockStore)(nil).SetPodConfigurationError), arg0, arg1)
+}
+
 // SetPodFlags mocks base method
 func (m *MockStore) SetPodFlags(arg0 string, arg1 store.Flags) {
 	m.ctrl.T.Helper()
@@ -160,6 +285,18 @@ func (mr *MockStoreMockRecorder) SetPodFlags(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodFlags", reflect.TypeOf((*MockStore)(nil).SetPodFlags), arg0, arg1)
 }
 
+// SetPodSelector mocks base method
+func (m *MockStore) SetPodSelector(arg0 string, arg1 *store.PodSelector) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPodSelector", arg0, arg1)
+}
+
+// SetPodSelector indicates an expected call of SetPodSelector
+func (mr *MockStoreMockRecorder) SetPodSelector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodSelector", reflect.TypeOf((*MockStore)(nil).SetPodSelector), arg0, arg1)
+}
+
 // SetPodTasks mocks base method
 func (m *MockStore) SetPodTasks(arg0 string, arg1 []*store.Task) error {
 	m.ctrl.T.Helper()
@@ -185,3 +322,27 @@ func (mr *MockStoreMockRecorder) SetPodTimeFlags(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodTimeFlags", reflect.TypeOf((*MockStore)(nil).SetPodTimeFlags), arg0, arg1)
 }
+
+// SetPodTrace mocks base method
+func (m *MockStore) SetPodTrace(arg0 string, arg1 *store.Trace) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPodTrace", arg0, arg1)
+}
+
+// SetPodTrace indicates an expected call of SetPodTrace
+func (mr *MockStoreMockRecorder) SetPodTrace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodTrace", reflect.TypeOf((*MockStore)(nil).SetPodTrace), arg0, arg1)
+}
+
+// SetPodWindowFlags mocks base method
+func (m *MockStore) SetPodWindowFlags(arg0 store.Window, arg1 string, arg2 store.Flags) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPodWindowFlags", arg0, arg1, arg2)
+}
+
+// SetPodWindowFlags indicates an expected call of SetPodWindowFlags
+func (mr *MockStoreMockRecorder) SetPodWindowFlags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodWindowFlags", reflect.TypeOf((*MockStore)(nil).SetPodWindowFlags), arg0, arg1, arg2)
+}
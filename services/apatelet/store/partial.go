@@ -0,0 +1,148 @@
+package store
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// PartialValue is the value of a pod flag which is not set for all pods of a configuration at once
+// It is only set for the pods in the subset, and every pod only sees it once its jitter has passed or the flag is set again
+// Until then, or if the pod is not part of the subset, the pod keeps the value the flag had before it was set
+type PartialValue struct {
+	// The pods the value is set for, nil means all pods
+	Subset *PodSubset
+
+	// The distribution from which the delay before a pod sees the value is sampled, nil means there is no delay
+	Jitter scenario.LatencyDistribution
+
+	// Identifies the execution of the task which set the value, the jitter of every pod is derived from it
+	JitterKey string
+
+	Value interface{}
+
+	// When the value was set
+	since time.Time
+
+	// Whether the flag has been set again, after which all pods in the subset see the value regardless of their jitter
+	applied bool
+
+	// The value of the flag before this value was set, if any
+	previous    interface{}
+	hasPrevious bool
+}
+
+// withPrevious returns the value to store for the given flag
+// If it is a partial value, the current value is remembered for the pods which don't see the new value (yet)
+func withPrevious(current Flags, flag events.EventFlag, value interface{}) interface{} {
+	partialValue, ok := value.(*PartialValue)
+	if !ok {
+		return value
+	}
+
+	result := *partialValue
+	result.since = time.Now()
+	result.previous, result.hasPrevious = current[flag]
+	result.prune()
+	return &result
+}
+
+// prune drops the earlier values which no pod can see anymore, so repeatedly setting a flag doesn't grow its chain of values
+// An earlier value is shadowed by a later value which all pods see and which is set for at least the same pods
+// The kept values are copied, as the original chain may still be restored when a window closes
+func (p *PartialValue) prune() {
+	var kept []*PartialValue
+	var shadowing []*PodSubset
+
+	var tail interface{}
+	hasTail := false
+
+	for level := p; ; {
+		kept = append(kept, level)
+
+		// All pods see the earlier values now that the flag is set again, and the new value if it has no jitter
+		if level != p || level.Jitter == nil {
+			if level.Subset.selectsAll() {
+				break
+			}
+			shadowing = append(shadowing, level.Subset)
+		}
+
+		previous, hasPrevious := level.previous, level.hasPrevious
+		for hasPrevious {
+			partialValue, ok := previous.(*PartialValue)
+			if !ok || !isShadowed(shadowing, partialValue.Subset) {
+				break
+			}
+			previous, hasPrevious = partialValue.previous, partialValue.hasPrevious
+		}
+
+		if !hasPrevious {
+			break
+		}
+
+		partialValue, ok := previous.(*PartialValue)
+		if !ok {
+			tail, hasTail = previous, true
+			break
+		}
+		level = partialValue
+	}
+
+	for i := len(kept) - 1; i > 0; i-- {
+		level := *kept[i]
+		level.previous, level.hasPrevious = tail, hasTail
+		level.applied = true
+		tail, hasTail = &level, true
+	}
+	p.previous, p.hasPrevious = tail, hasTail
+}
+
+// isShadowed returns true if every pod in the subset is part of one of the shadowing subsets
+func isShadowed(shadowing []*PodSubset, subset *PodSubset) bool {
+	for _, s := range shadowing {
+		if s.covers(subset) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePartial returns the value of a flag for the given pod, returning false if the flag was not set for the pod
+func resolvePartial(pod *corev1.Pod, value interface{}) (interface{}, bool) {
+	for {
+		partialValue, ok := value.(*PartialValue)
+		if !ok {
+			return value, true
+		}
+
+		if partialValue.Subset.Selects(pod) && (partialValue.applied || time.Since(partialValue.since) >= partialValue.jitterFor(pod)) {
+			return partialValue.Value, true
+		}
+
+		if !partialValue.hasPrevious {
+			return nil, false
+		}
+
+		value = partialValue.previous
+	}
+}
+
+// jitterFor returns how long after the value was set the given pod sees it
+func (p *PartialValue) jitterFor(pod *corev1.Pod) time.Duration {
+	return podJitter(p.Jitter, pod, p.JitterKey)
+}
+
+// podJitter samples the jitter of the given pod from the distribution
+// The sample is derived from the uid of the pod and the key, so the same pod and key always get the same jitter
+func podJitter(jitter scenario.LatencyDistribution, pod *corev1.Pod, key string) time.Duration {
+	if jitter == nil {
+		return 0
+	}
+
+	return jitter.Sample(scenario.NewKeyedRandom(key + "/" + string(pod.UID)))
+}
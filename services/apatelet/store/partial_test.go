@@ -0,0 +1,109 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+func TestPodJitterDeterministic(t *testing.T) {
+	t.Parallel()
+
+	jitter, err := scenario.NewUniformLatency(0, time.Minute)
+	assert.NoError(t, err)
+
+	pods := createPodsWithUIDs(100)
+
+	differs := false
+	for _, pod := range pods {
+		sample := podJitter(jitter, pod, "key")
+		assert.Equal(t, sample, podJitter(jitter, pod, "key"))
+		assert.True(t, sample >= 0 && sample < time.Minute)
+
+		differs = differs || sample != podJitter(jitter, pods[0], "key")
+	}
+
+	assert.True(t, differs, "different pods should get different jitter")
+	assert.Equal(t, time.Duration(0), podJitter(nil, pods[0], "key"))
+}
+
+func TestJitterPodFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	pods := createPodsWithUIDs(10)
+
+	st.SetPodFlags("a/b", Flags{events.PodStatus: scenario.PodStatusRunning})
+
+	// No pod has reached its jitter yet, so they all keep the previous value
+	st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Jitter: scenario.ConstantLatency(time.Hour), Value: scenario.PodStatusFailed}})
+	for _, pod := range pods {
+		flag, err := st.GetPodFlag(pod, events.PodStatus)
+		assert.NoError(t, err)
+		assert.Equal(t, scenario.PodStatusRunning, flag)
+	}
+
+	// Every pod has reached its jitter
+	st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Jitter: scenario.ConstantLatency(0), Value: scenario.PodStatusSucceeded}})
+	for _, pod := range pods {
+		flag, err := st.GetPodFlag(pod, events.PodStatus)
+		assert.NoError(t, err)
+		assert.Equal(t, scenario.PodStatusSucceeded, flag)
+	}
+}
+
+func TestJitterPodTimeFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	jitter, _ := scenario.NewUniformLatency(0, time.Minute)
+
+	timeFlags := []*TimeFlags{
+		{TimeSincePodStart: 0, Flags: Flags{42: "a"}},
+		{TimeSincePodStart: time.Second, Flags: Flags{42: "b"}, Jitter: jitter, Index: 1},
+	}
+	st.SetPodTimeFlags("a/b", timeFlags)
+
+	for _, pod := range createPodsWithUIDs(50) {
+		startTime := metav1.NewTime(time.Now().Add(-30 * time.Second))
+		pod.Status.StartTime = &startTime
+
+		expected := "a"
+		if time.Second+podJitter(jitter, pod, timeFlagsKey(timeFlags[1])) < 30*time.Second {
+			expected = "b"
+		}
+
+		flag, err := st.GetPodFlag(pod, 42)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, flag)
+	}
+}
+
+func TestJitterPodFlagsSetAgain(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore().(*store)
+	pods := createPodsWithUIDs(10)
+
+	st.SetPodFlags("a/b", Flags{events.PodStatus: scenario.PodStatusRunning})
+
+	// Once the flag is set again, all pods see the earlier value even if their jitter hasn't passed yet
+	for i := 0; i < 10; i++ {
+		st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Jitter: scenario.ConstantLatency(time.Hour), JitterKey: strconv.Itoa(i), Value: scenario.PodStatusFailed}})
+	}
+	st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Jitter: scenario.ConstantLatency(time.Hour), JitterKey: "last", Value: scenario.PodStatusSucceeded}})
+
+	for _, pod := range pods {
+		flag, err := st.GetPodFlag(pod, events.PodStatus)
+		assert.NoError(t, err)
+		assert.Equal(t, scenario.PodStatusFailed, flag)
+	}
+
+	assert.Equal(t, 2, chainLength(st.podFlags["a/b"][events.PodStatus]))
+}
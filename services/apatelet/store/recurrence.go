@@ -0,0 +1,153 @@
+package store
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+// maxExpandedTimestamps is the maximum amount of timestamps a recurrence can be expanded to
+const maxExpandedTimestamps = 10000
+
+// Recurrence determines when a task is executed again after its timestamp
+type Recurrence struct {
+	// The time between two executions, only used if there is no schedule
+	Interval time.Duration
+
+	// The cron schedule the task is executed on, evaluated in wall clock time
+	Schedule *scenario.CronSchedule
+
+	// The amount of executions left, including the execution of the task this recurrence belongs to
+	// 0 means the task is repeated indefinitely
+	Remaining int
+
+	// The timestamp relative to the start of the scenario after which the task is no longer executed
+	// 0 means the task is repeated indefinitely
+	Until time.Duration
+}
+
+// NewRecurrence creates a new recurrence from its CRD representation
+// Exactly one of interval and cron should be given, until is in time.ParseDuration format and may be empty
+func NewRecurrence(interval, cron string, count int, until string) (*Recurrence, error) {
+	recurrence := &Recurrence{
+		Remaining: count,
+	}
+
+	switch {
+	case interval != "" && cron != "":
+		return nil, errors.New("a repeated task can't have both an interval and a cron schedule")
+	case interval != "":
+		duration, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while converting interval %v to a duration", interval)
+		} else if duration <= 0 {
+			return nil, errors.Errorf("interval %v should be positive", interval)
+		}
+		recurrence.Interval = duration
+	case cron != "":
+		schedule, err := scenario.ParseCronSchedule(cron)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse cron schedule")
+		}
+		recurrence.Schedule = schedule
+	default:
+		return nil, errors.New("a repeated task needs an interval or a cron schedule")
+	}
+
+	if count < 0 {
+		return nil, errors.Errorf("count %v should not be negative", count)
+	}
+
+	if until != "" {
+		duration, err := time.ParseDuration(until)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while converting until %v to a duration", until)
+		} else if duration <= 0 {
+			// An until of 0 would mean the task is repeated indefinitely, which is what leaving it empty is for
+			return nil, errors.Errorf("until %v should be positive", until)
+		}
+		recurrence.Until = duration
+	}
+
+	return recurrence, nil
+}
+
+// IsBounded returns true if the task stops repeating at some point
+func (r *Recurrence) IsBounded() bool {
+	return r.Remaining > 0 || r.Until > 0
+}
+
+// Timestamps returns every timestamp on which a task starting at the given timestamp is executed
+// This can only be used for bounded recurrences with an interval
+func (r *Recurrence) Timestamps(first time.Duration) ([]time.Duration, error) {
+	if r.Schedule != nil || !r.IsBounded() {
+		return nil, errors.New("only bounded recurrences with an interval can be expanded")
+	}
+
+	var timestamps []time.Duration
+	for t := first; (r.Remaining == 0 || len(timestamps) < r.Remaining) && (r.Until == 0 || t <= r.Until); t += r.Interval {
+		if len(timestamps) == maxExpandedTimestamps {
+			return nil, errors.Errorf("recurrence results in more than %v executions", maxExpandedTimestamps)
+		}
+
+		timestamps = append(timestamps, t)
+	}
+
+	return timestamps, nil
+}
+
+// Next returns the next execution of a recurring task, or nil if it is not executed again
+// Executions which should have happened before now are skipped
+func (t *Task) Next(startTime, now time.Time) *Task {
+	r := t.Recurrence
+	if r == nil || r.Remaining == 1 {
+		return nil
+	}
+
+	next := *r
+	timestamp := t.RelativeTimestamp
+
+	if r.Schedule != nil {
+		// The schedule determines the executions without the jitter, which is added to all of them
+		after := startTime.Add(timestamp - t.Jitter)
+		if now.Add(-t.Jitter).After(after) {
+			after = now.Add(-t.Jitter)
+		}
+
+		nextTime, ok := r.Schedule.Next(after)
+		if !ok {
+			return nil
+		}
+
+		timestamp = nextTime.Sub(startTime) + t.Jitter
+		if next.Remaining > 0 {
+			next.Remaining--
+		}
+	} else {
+		// Skip the executions which are already in the past
+		steps := 1
+		if behind := now.Sub(startTime.Add(timestamp)); behind >= 0 {
+			steps = int(behind/r.Interval) + 1
+		}
+
+		if next.Remaining > 0 {
+			if steps >= next.Remaining {
+				return nil
+			}
+			next.Remaining -= steps
+		}
+
+		timestamp += time.Duration(steps) * r.Interval
+	}
+
+	if r.Until > 0 && timestamp-t.Jitter > r.Until {
+		return nil
+	}
+
+	task := *t
+	task.RelativeTimestamp = timestamp
+	task.Recurrence = &next
+	return &task
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecurrenceInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRecurrence("", "", 0, "")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("1m", "* * * * *", 0, "")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("-1m", "", 0, "")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("1m", "", -1, "")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("1m", "", 0, "tomorrow")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("1m", "", 0, "0s")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("1m", "", 0, "-1h")
+	assert.Error(t, err)
+
+	_, err = NewRecurrence("", "* * *", 0, "")
+	assert.Error(t, err)
+}
+
+func TestTaskNextInterval(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("10m", "", 3, "")
+	assert.NoError(t, err)
+
+	start := time.Now()
+	task := NewNodeTask(time.Minute, nil)
+	task.Recurrence = recurrence
+
+	next := task.Next(start, start.Add(time.Minute))
+	assert.Equal(t, 11*time.Minute, next.RelativeTimestamp)
+	assert.Equal(t, 2, next.Recurrence.Remaining)
+
+	next = next.Next(start, start.Add(11*time.Minute))
+	assert.Equal(t, 21*time.Minute, next.RelativeTimestamp)
+	assert.Equal(t, 1, next.Recurrence.Remaining)
+
+	// The count has been reached
+	assert.Nil(t, next.Next(start, start.Add(21*time.Minute)))
+
+	// The original task is not modified
+	assert.Equal(t, 3, task.Recurrence.Remaining)
+}
+
+func TestTaskNextSkipsPast(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("10m", "", 0, "1h")
+	assert.NoError(t, err)
+
+	start := time.Now()
+	task := NewNodeTask(0, nil)
+	task.Recurrence = recurrence
+
+	next := task.Next(start, start.Add(35*time.Minute))
+	assert.Equal(t, 40*time.Minute, next.RelativeTimestamp)
+
+	// Until has been reached
+	assert.Nil(t, task.Next(start, start.Add(time.Hour)))
+}
+
+func TestTaskNextCron(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("", "0 * * * *", 0, "")
+	assert.NoError(t, err)
+
+	start := time.Date(2020, time.June, 1, 12, 30, 0, 0, time.UTC)
+	task := NewNodeTask(0, nil)
+	task.Recurrence = recurrence
+
+	next := task.Next(start, start)
+	assert.Equal(t, 30*time.Minute, next.RelativeTimestamp)
+}
+
+func TestTaskNextCronJitter(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("", "0 * * * *", 0, "")
+	assert.NoError(t, err)
+
+	start := time.Date(2020, time.June, 1, 12, 30, 0, 0, time.UTC)
+	task := NewNodeTask(30*time.Minute+5*time.Second, nil)
+	task.Recurrence = recurrence
+	task.Jitter = 5 * time.Second
+
+	// The jitter is kept for every execution on the schedule
+	next := task.Next(start, start.Add(task.RelativeTimestamp))
+	assert.Equal(t, 90*time.Minute+5*time.Second, next.RelativeTimestamp)
+	assert.Equal(t, 5*time.Second, next.Jitter)
+}
+
+func TestTaskNextOnce(t *testing.T) {
+	t.Parallel()
+
+	task := NewNodeTask(0, nil)
+	assert.Nil(t, task.Next(time.Now(), time.Now()))
+}
+
+func TestRecurrenceTimestamps(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("10s", "", 3, "")
+	assert.NoError(t, err)
+
+	timestamps, err := recurrence.Timestamps(5 * time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{5 * time.Second, 15 * time.Second, 25 * time.Second}, timestamps)
+
+	recurrence, err = NewRecurrence("10s", "", 0, "20s")
+	assert.NoError(t, err)
+
+	timestamps, err = recurrence.Timestamps(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{0, 10 * time.Second, 20 * time.Second}, timestamps)
+}
+
+func TestRecurrenceTimestampsUnbounded(t *testing.T) {
+	t.Parallel()
+
+	recurrence, err := NewRecurrence("10s", "", 0, "")
+	assert.NoError(t, err)
+
+	_, err = recurrence.Timestamps(0)
+	assert.Error(t, err)
+
+	recurrence, err = NewRecurrence("1ns", "", 0, "1h")
+	assert.NoError(t, err)
+
+	_, err = recurrence.Timestamps(0)
+	assert.Error(t, err)
+}
+
+func TestAddTask(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.AddTask(NewNodeTask(time.Minute, nil))
+	st.AddTask(NewNodeTask(time.Second, nil))
+
+	first, ok, err := st.PeekTask()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, first)
+}
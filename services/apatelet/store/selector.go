@@ -0,0 +1,92 @@
+package store
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	deploymentKind  = "Deployment"
+	replicaSetKind  = "ReplicaSet"
+	podTemplateHash = "pod-template-hash"
+)
+
+// PodSelector determines which pods belong to a pod CRD, besides the pods with the apate label
+type PodSelector struct {
+	// The namespace of the pod CRD, only pods in this namespace are selected
+	Namespace string
+
+	// The selector the labels of the pod should match, nil means the labels are not checked
+	Selector labels.Selector
+
+	// The kind and name of the owner of the pod, an empty kind means the owner is not checked
+	OwnerKind string
+	OwnerName string
+
+	// The priority of the pod CRD, used when multiple selectors match the same pod
+	Priority int
+}
+
+// Matches returns true if the given pod is selected
+func (p *PodSelector) Matches(pod *corev1.Pod) bool {
+	if pod.Namespace != p.Namespace {
+		return false
+	}
+
+	if p.Selector != nil && !p.Selector.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+
+	return p.OwnerKind == "" || p.ownerMatches(pod)
+}
+
+func (p *PodSelector) ownerMatches(pod *corev1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == p.OwnerKind && owner.Name == p.OwnerName {
+			return true
+		}
+
+		// Pods of a deployment are owned by a replica set named after the deployment and the hash of the pod template
+		if p.OwnerKind == deploymentKind && owner.Kind == replicaSetKind {
+			if hash, ok := pod.Labels[podTemplateHash]; ok && owner.Name == p.OwnerName+"-"+hash {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// precedes returns true if the selector with the given label takes precedence over the other selector and its label
+// Higher priorities go first, then selectors with an owner as they are more specific, then the lowest label
+func (p *PodSelector) precedes(label string, other *PodSelector, otherLabel string) bool {
+	if p.Priority != other.Priority {
+		return p.Priority > other.Priority
+	}
+
+	if (p.OwnerKind == "") != (other.OwnerKind == "") {
+		return p.OwnerKind != ""
+	}
+
+	return label < otherLabel
+}
+
+// getPodLabel returns the label of the pod CRD the given pod belongs to
+// Pods with the apate label always belong to that pod CRD, otherwise the matching selector with the highest precedence is used
+// The caller should hold the podFlagLock
+func (s *store) getPodLabel(pod *corev1.Pod) (string, bool) {
+	if label, ok := getPodLabelByPod(pod); ok {
+		return label, true
+	}
+
+	var best *PodSelector
+	var bestLabel string
+	for label, selector := range s.podSelectors {
+		if selector.Matches(pod) && (best == nil || selector.precedes(label, best, bestLabel)) {
+			best = selector
+			bestLabel = label
+		}
+	}
+
+	return bestLabel, best != nil
+}
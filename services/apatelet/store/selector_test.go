@@ -0,0 +1,116 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func createPodWithOwner(ns string, podLabels map[string]string, kind, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Labels:    podLabels,
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: kind, Name: name},
+			},
+		},
+	}
+}
+
+func TestPodSelectorLabels(t *testing.T) {
+	t.Parallel()
+
+	selector := PodSelector{
+		Namespace: "a",
+		Selector:  labels.SelectorFromSet(labels.Set{"app": "web"}),
+	}
+
+	assert.True(t, selector.Matches(createPodWithOwner("a", map[string]string{"app": "web", "x": "y"}, "", "")))
+	assert.False(t, selector.Matches(createPodWithOwner("a", map[string]string{"app": "db"}, "", "")))
+	assert.False(t, selector.Matches(createPodWithOwner("b", map[string]string{"app": "web"}, "", "")))
+}
+
+func TestPodSelectorOwner(t *testing.T) {
+	t.Parallel()
+
+	selector := PodSelector{
+		Namespace: "a",
+		OwnerKind: "StatefulSet",
+		OwnerName: "db",
+	}
+
+	assert.True(t, selector.Matches(createPodWithOwner("a", nil, "StatefulSet", "db")))
+	assert.False(t, selector.Matches(createPodWithOwner("a", nil, "StatefulSet", "web")))
+	assert.False(t, selector.Matches(createPodWithOwner("a", nil, "Job", "db")))
+}
+
+func TestPodSelectorDeployment(t *testing.T) {
+	t.Parallel()
+
+	selector := PodSelector{
+		Namespace: "a",
+		OwnerKind: "Deployment",
+		OwnerName: "web",
+	}
+
+	assert.True(t, selector.Matches(createPodWithOwner("a", map[string]string{podTemplateHash: "1234"}, "ReplicaSet", "web-1234")))
+	assert.False(t, selector.Matches(createPodWithOwner("a", map[string]string{podTemplateHash: "1234"}, "ReplicaSet", "web-5678")))
+	assert.False(t, selector.Matches(createPodWithOwner("a", nil, "ReplicaSet", "web-1234")))
+}
+
+func TestPodSelectorPrecedence(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore().(*store)
+	pod := createPodWithOwner("a", map[string]string{"app": "web"}, "StatefulSet", "web")
+
+	st.SetPodSelector("a/selector", &PodSelector{Namespace: "a", Selector: labels.Everything()})
+	st.SetPodSelector("a/b-selector", &PodSelector{Namespace: "a", Selector: labels.Everything()})
+
+	// Lowest label goes first
+	label, ok := st.getPodLabel(pod)
+	assert.True(t, ok)
+	assert.Equal(t, "a/b-selector", label)
+
+	// Owner goes before a plain selector
+	st.SetPodSelector("a/owner", &PodSelector{Namespace: "a", OwnerKind: "StatefulSet", OwnerName: "web"})
+	label, _ = st.getPodLabel(pod)
+	assert.Equal(t, "a/owner", label)
+
+	// Priority goes before everything else
+	st.SetPodSelector("a/selector", &PodSelector{Namespace: "a", Selector: labels.Everything(), Priority: 1})
+	label, _ = st.getPodLabel(pod)
+	assert.Equal(t, "a/selector", label)
+
+	// The apate label always goes first
+	pod.Labels["apate"] = "label"
+	label, _ = st.getPodLabel(pod)
+	assert.Equal(t, "a/label", label)
+}
+
+func TestPodFlagBySelector(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	pod := createPodWithOwner("a", map[string]string{"app": "web"}, "", "")
+
+	st.SetPodFlags("a/b", Flags{42: "k8s"})
+
+	_, err := st.GetPodFlag(pod, 42)
+	assert.Error(t, err)
+
+	st.SetPodSelector("a/b", &PodSelector{Namespace: "a", Selector: labels.SelectorFromSet(labels.Set{"app": "web"})})
+
+	flag, err := st.GetPodFlag(pod, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "k8s", flag)
+
+	st.SetPodSelector("a/b", nil)
+
+	_, err = st.GetPodFlag(pod, 42)
+	assert.Error(t, err)
+}
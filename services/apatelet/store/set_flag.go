@@ -16,15 +16,37 @@ type FlagSetter interface {
 
 	// SetNodeFlag sets the value of the given pod flag for a configuration
 	SetPodTimeFlags(string, []*TimeFlags)
+
+	// SetPodTrace sets the trace of which the resource usage is replayed on the pods of a configuration
+	// A nil trace removes the trace of the configuration
+	SetPodTrace(string, *Trace)
+
+	// SetPodSelector sets the selector which determines which pods belong to a configuration, besides the apate label
+	// A nil selector removes the selector of the configuration
+	SetPodSelector(string, *PodSelector)
 }
 
 func (s *store) SetNodeFlags(flags Flags) {
 	s.nodeFlagLock.Lock()
-	defer s.nodeFlagLock.Unlock()
-
+	dropWindowFlags(s.nodeFlagStacks, flags)
 	for k, v := range flags {
 		s.nodeFlags[k] = v
 	}
+	s.nodeFlagLock.Unlock()
+
+	s.callNodeListeners(flags)
+}
+
+// callNodeListeners calls the listeners of the given node flags with their new values
+func (s *store) callNodeListeners(flags Flags) {
+	s.nodeListenersLock.RLock()
+	defer s.nodeListenersLock.RUnlock()
+
+	for flag, val := range flags {
+		for _, listener := range s.nodeListeners[flag] {
+			listener(val)
+		}
+	}
 }
 
 func (s *store) SetPodFlags(label string, flags Flags) {
@@ -33,12 +55,20 @@ func (s *store) SetPodFlags(label string, flags Flags) {
 		s.podFlags[label] = make(Flags)
 	}
 
+	dropWindowFlags(s.podFlagStacks[label], flags)
 	for k, v := range flags {
-		s.podFlags[label][k] = v
+		s.podFlags[label][k] = withPrevious(s.podFlags[label], k, v)
 	}
 	s.podFlagLock.Unlock()
 
+	s.callPodListeners(flags)
+}
+
+// callPodListeners calls the listeners of the given pod flags with their new values
+func (s *store) callPodListeners(flags Flags) {
 	s.podListenersLock.RLock()
+	defer s.podListenersLock.RUnlock()
+
 	for flag, val := range flags {
 		if listeners, ok := s.podListeners[flag]; ok {
 			for _, listener := range listeners {
@@ -46,7 +76,6 @@ func (s *store) SetPodFlags(label string, flags Flags) {
 			}
 		}
 	}
-	s.podListenersLock.RUnlock()
 }
 
 func (s *store) SetPodTimeFlags(label string, flags []*TimeFlags) {
@@ -59,9 +88,42 @@ func (s *store) SetPodTimeFlags(label string, flags []*TimeFlags) {
 
 	s.podTimeFlags[label] = flags
 
+	// Time flags which only last for a while or are jittered can't use the index cache, as their order differs per pod
+	s.podTimeScan[label] = false
+	for _, timeFlags := range flags {
+		if timeFlags.Duration > 0 || timeFlags.Jitter != nil {
+			s.podTimeScan[label] = true
+		}
+	}
+
 	for pod := range s.podTimeIndexCache {
-		if pl, ok := getPodLabelByPod(pod); ok && pl == label {
+		if pl, ok := s.getPodLabel(pod); ok && pl == label {
 			s.podTimeIndexCache[pod] = make(map[events.EventFlag]int)
 		}
 	}
 }
+
+func (s *store) SetPodTrace(label string, trace *Trace) {
+	s.podFlagLock.Lock()
+	defer s.podFlagLock.Unlock()
+
+	if trace == nil {
+		delete(s.podTraces, label)
+	} else {
+		s.podTraces[label] = trace
+	}
+}
+
+func (s *store) SetPodSelector(label string, selector *PodSelector) {
+	s.podFlagLock.Lock()
+	defer s.podFlagLock.Unlock()
+
+	if selector == nil {
+		delete(s.podSelectors, label)
+	} else {
+		s.podSelectors[label] = selector
+	}
+
+	// Pods may belong to a different configuration now, so the cached time flag indices are no longer valid
+	s.podTimeIndexCache = make(podTimeIndexCache)
+}
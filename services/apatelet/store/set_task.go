@@ -15,6 +15,9 @@ type TaskSetter interface {
 	// SetPodTasks adds or updates pod CRD tasks to the queue based on their label (<namespace>/<name>)
 	// Existing pod tasks will be removed if not in the list of tasks
 	SetPodTasks(string, []*Task) error
+
+	// AddTask adds a single task to the queue, leaving the other tasks untouched
+	AddTask(*Task)
 }
 
 func (s *store) setTasksOfType(newTasks []*Task, check TaskTypeCheck) error {
@@ -71,3 +74,10 @@ func (s *store) SetPodTasks(label string, tasks []*Task) error {
 		return isPod && task.PodTask.Label == label, nil
 	})
 }
+
+func (s *store) AddTask(task *Task) {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	heap.Push(s.queue, task)
+}
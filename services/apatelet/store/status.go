@@ -0,0 +1,179 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+)
+
+// StatusTracker defines functions aiding in keeping track of how pod configurations are applied
+type StatusTracker interface {
+	// AddPod registers a pod which is emulated by this apatelet
+	AddPod(*corev1.Pod)
+
+	// RemovePod removes a pod which is no longer emulated by this apatelet
+	RemovePod(*corev1.Pod)
+
+	// SetPodConfigurationError sets the error which occurred while parsing the pod configuration with the given label
+	// A nil error means the pod configuration was parsed successfully
+	SetPodConfigurationError(string, error)
+
+	// MarkPodTaskFired registers that the given pod task has been executed on the pods currently matching its label and subset
+	MarkPodTaskFired(*Task)
+
+	// GetPodConfigurationStatuses returns the status of every pod configuration known to this apatelet
+	GetPodConfigurationStatuses() []*controlplane.PodConfigurationStatus
+}
+
+// podConfigurationStatus contains what the apatelet knows about how a pod configuration has been applied
+type podConfigurationStatus struct {
+	// The error which occurred while parsing the pod configuration
+	err error
+
+	// The pods on which each task was last executed, with the time from which they see its state, by task index
+	// This only contains tasks which are not relative to the start of the pod
+	firedTasks map[int]map[types.UID]time.Time
+}
+
+type podConfigurationStatuses map[string]*podConfigurationStatus
+
+func (s *store) AddPod(pod *corev1.Pod) {
+	s.podStatusLock.Lock()
+	defer s.podStatusLock.Unlock()
+
+	s.pods[pod.UID] = pod
+}
+
+func (s *store) RemovePod(pod *corev1.Pod) {
+	s.podStatusLock.Lock()
+	defer s.podStatusLock.Unlock()
+
+	delete(s.pods, pod.UID)
+}
+
+func (s *store) SetPodConfigurationError(label string, err error) {
+	s.podStatusLock.Lock()
+	defer s.podStatusLock.Unlock()
+
+	s.getPodConfigurationStatus(label).err = err
+}
+
+func (s *store) MarkPodTaskFired(task *Task) {
+	s.podFlagLock.RLock()
+	defer s.podFlagLock.RUnlock()
+
+	s.podStatusLock.Lock()
+	defer s.podStatusLock.Unlock()
+
+	now := time.Now()
+	reached := make(map[types.UID]time.Time)
+	for _, pod := range s.pods {
+		if label, ok := s.getPodLabel(pod); ok && label == task.PodTask.Label && task.PodTask.Subset.Selects(pod) {
+			reached[pod.UID] = now.Add(podJitter(task.PodTask.Jitter, pod, task.JitterKey()))
+		}
+	}
+
+	s.getPodConfigurationStatus(task.PodTask.Label).firedTasks[task.PodTask.Index] = reached
+}
+
+func (s *store) GetPodConfigurationStatuses() []*controlplane.PodConfigurationStatus {
+	s.podFlagLock.RLock()
+	defer s.podFlagLock.RUnlock()
+
+	s.podStatusLock.RLock()
+	defer s.podStatusLock.RUnlock()
+
+	podsByLabel := make(map[string][]*corev1.Pod)
+	for _, pod := range s.pods {
+		if label, ok := s.getPodLabel(pod); ok {
+			podsByLabel[label] = append(podsByLabel[label], pod)
+		}
+	}
+
+	statuses := make([]*controlplane.PodConfigurationStatus, 0, len(s.podStatuses))
+	for label, status := range s.podStatuses {
+		pods := podsByLabel[label]
+
+		// Pods only count once their jitter has passed, pods which have been removed since keep counting
+		now := time.Now()
+		fired := make(map[int]int64, len(status.firedTasks))
+		for index, reached := range status.firedTasks {
+			fired[index] = 0
+			for _, at := range reached {
+				if !at.After(now) {
+					fired[index]++
+				}
+			}
+		}
+
+		// Tasks relative to the start of the pod have been executed on every pod which has been running long enough
+		// Repeated tasks have several time flags with the same index, of which only the first one is counted
+		counted := make(map[int]bool)
+		for _, timeFlags := range s.podTimeFlags[label] {
+			if !counted[timeFlags.Index] {
+				counted[timeFlags.Index] = true
+				fired[timeFlags.Index] += countReached(pods, timeFlags)
+			}
+		}
+
+		result := &controlplane.PodConfigurationStatus{
+			Label:       label,
+			MatchedPods: int64(len(pods)),
+			Tasks:       make([]*controlplane.TaskStatus, 0, len(fired)),
+		}
+
+		for index, amount := range fired {
+			result.Tasks = append(result.Tasks, &controlplane.TaskStatus{
+				Index:     int32(index),
+				FiredPods: amount,
+			})
+		}
+
+		sort.Slice(result.Tasks, func(i, j int) bool {
+			return result.Tasks[i].Index < result.Tasks[j].Index
+		})
+
+		if status.err != nil {
+			result.Error = status.err.Error()
+		}
+
+		statuses = append(statuses, result)
+	}
+
+	return statuses
+}
+
+// getPodConfigurationStatus returns the status of the pod configuration with the given label, creating it if needed
+// The caller should hold the podStatusLock
+func (s *store) getPodConfigurationStatus(label string) *podConfigurationStatus {
+	status, ok := s.podStatuses[label]
+	if !ok {
+		status = &podConfigurationStatus{
+			firedTasks: make(map[int]map[types.UID]time.Time),
+		}
+		s.podStatuses[label] = status
+	}
+
+	return status
+}
+
+// countReached returns the amount of pods for which the given time flags have been reached, taking their subset and jitter into account
+func countReached(pods []*corev1.Pod, timeFlags *TimeFlags) int64 {
+	var cnt int64
+	for _, pod := range pods {
+		if pod.Status.StartTime == nil || !timeFlags.Subset.Selects(pod) {
+			continue
+		}
+
+		timestamp := timeFlags.TimeSincePodStart + podJitter(timeFlags.Jitter, pod, timeFlagsKey(timeFlags))
+		if pod.Status.StartTime.Add(timestamp).Before(time.Now()) {
+			cnt++
+		}
+	}
+
+	return cnt
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+func TestPodConfigurationStatusFiredTasks(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	pod1 := createPodWithLabel("a", "b")
+	pod1.UID = types.UID("1")
+	pod2 := createPodWithLabel("a", "b")
+	pod2.UID = types.UID("2")
+	other := createPodWithLabel("a", "c")
+	other.UID = types.UID("3")
+
+	st.AddPod(pod1)
+	st.AddPod(pod2)
+	st.AddPod(other)
+	st.SetPodConfigurationError("a/b", nil)
+
+	st.MarkPodTaskFired(NewPodTask(0, "a/b", 1, &podconfigv1.PodConfigurationState{}))
+
+	statuses := st.GetPodConfigurationStatuses()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "a/b", statuses[0].Label)
+	assert.Equal(t, int64(2), statuses[0].MatchedPods)
+	assert.Len(t, statuses[0].Tasks, 1)
+	assert.Equal(t, int32(1), statuses[0].Tasks[0].Index)
+	assert.Equal(t, int64(2), statuses[0].Tasks[0].FiredPods)
+	assert.Equal(t, "", statuses[0].Error)
+
+	// Removing a pod does not change the amount of pods on which the task was executed
+	st.RemovePod(pod2)
+
+	statuses = st.GetPodConfigurationStatuses()
+	assert.Equal(t, int64(1), statuses[0].MatchedPods)
+	assert.Equal(t, int64(2), statuses[0].Tasks[0].FiredPods)
+}
+
+func TestPodConfigurationStatusFiredTasksPartial(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	pods := createPodsWithUIDs(100)
+	for _, pod := range pods {
+		st.AddPod(pod)
+	}
+
+	subset, _ := NewPodSubset("", 10, 0)
+	jitter, _ := scenario.NewUniformLatency(0, time.Hour)
+
+	// Only the pods in the subset count
+	task := NewPodTask(0, "a/b", 1, &podconfigv1.PodConfigurationState{})
+	task.PodTask.Subset = subset
+	st.MarkPodTaskFired(task)
+
+	var selected int64
+	for _, pod := range pods {
+		if subset.Selects(pod) {
+			selected++
+		}
+	}
+
+	statuses := st.GetPodConfigurationStatuses()
+	assert.Equal(t, int64(100), statuses[0].MatchedPods)
+	assert.Equal(t, selected, statuses[0].Tasks[0].FiredPods)
+
+	// Pods only count once their jitter has passed
+	task = NewPodTask(time.Minute, "a/b", 2, &podconfigv1.PodConfigurationState{})
+	task.PodTask.Jitter = jitter
+	st.MarkPodTaskFired(task)
+
+	var reached int64
+	for _, pod := range pods {
+		if podJitter(jitter, pod, task.JitterKey()) == 0 {
+			reached++
+		}
+	}
+
+	statuses = st.GetPodConfigurationStatuses()
+	assert.Equal(t, reached, statuses[0].Tasks[1].FiredPods)
+	assert.True(t, statuses[0].Tasks[1].FiredPods < 100)
+}
+
+func TestPodConfigurationStatusTimeFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	started := createPodWithLabel("a", "b")
+	started.UID = types.UID("1")
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	started.Status.StartTime = &past
+
+	notStarted := createPodWithLabel("a", "b")
+	notStarted.UID = types.UID("2")
+
+	st.AddPod(started)
+	st.AddPod(notStarted)
+	st.SetPodConfigurationError("a/b", nil)
+
+	st.SetPodTimeFlags("a/b", []*TimeFlags{
+		{TimeSincePodStart: 30 * time.Second, Flags: Flags{42: "k8s"}, Index: 0},
+		{TimeSincePodStart: time.Hour, Flags: Flags{42: "k9s"}, Index: 1},
+	})
+
+	statuses := st.GetPodConfigurationStatuses()
+	assert.Len(t, statuses, 1)
+	assert.Len(t, statuses[0].Tasks, 2)
+	assert.Equal(t, int64(1), statuses[0].Tasks[0].FiredPods)
+	assert.Equal(t, int64(0), statuses[0].Tasks[1].FiredPods)
+}
+
+func TestPodConfigurationStatusRepeatedTimeFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	started := createPodWithLabel("a", "b")
+	started.UID = types.UID("1")
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	started.Status.StartTime = &past
+
+	st.AddPod(started)
+	st.SetPodConfigurationError("a/b", nil)
+
+	// A repeated task results in several time flags with the same index, which count the pod only once
+	st.SetPodTimeFlags("a/b", []*TimeFlags{
+		{TimeSincePodStart: 10 * time.Second, Flags: Flags{42: "k8s"}, Index: 0},
+		{TimeSincePodStart: 20 * time.Second, Flags: Flags{42: "k8s"}, Index: 0},
+	})
+
+	statuses := st.GetPodConfigurationStatuses()
+	assert.Len(t, statuses[0].Tasks, 1)
+	assert.Equal(t, int64(1), statuses[0].Tasks[0].FiredPods)
+}
+
+func TestPodConfigurationStatusError(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	st.SetPodConfigurationError("a/b", errors.New("invalid timestamp"))
+
+	statuses := st.GetPodConfigurationStatuses()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "invalid timestamp", statuses[0].Error)
+
+	// Removing the tasks also removes the status
+	assert.NoError(t, st.RemovePodTasks("a/b"))
+	assert.Len(t, st.GetPodConfigurationStatuses(), 0)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
 
@@ -23,8 +24,11 @@ type Store interface {
 	TaskSetter
 	FlagSetter
 	FlagGetter
+	WindowSetter
+	StatusTracker
 
 	// RemovePodTasks removes pod CRD tasks from the queue based on their label (<namespace>/<name>)
+	// This also removes the status which is kept for the pod CRD
 	RemovePodTasks(string) error
 
 	// PeekTask returns the start time of the next task in the priority queue, without removing it from the queue
@@ -35,6 +39,9 @@ type Store interface {
 
 	// AddPodFlagListener adds a listener which is called when the given flag is updated
 	AddPodFlagListener(events.PodEventFlag, func(interface{}))
+
+	// AddNodeFlagListener adds a listener which is called when the given flag is updated
+	AddNodeFlagListener(events.NodeEventFlag, func(interface{}))
 }
 
 // Flags is a map from event flags to their interface value
@@ -42,30 +49,61 @@ type Flags map[events.EventFlag]interface{}
 
 type podFlags map[string]Flags
 type podListeners map[events.EventFlag][]func(interface{})
+type nodeListeners map[events.EventFlag][]func(interface{})
 
 // TimeFlags contains Flags at a certain timestamp relative to the starting time of a pod
 type TimeFlags struct {
 	TimeSincePodStart time.Duration
 	Flags             Flags
+
+	// How long the flags last, 0 means they last until they are overwritten by later time flags
+	Duration time.Duration
+
+	// The index of the task in the CRD these flags originate from
+	Index int
+
+	// The pods the flags are set for, nil means all pods
+	Subset *PodSubset
+
+	// The distribution from which the delay added to the timestamp is sampled for every pod, nil means there is no delay
+	Jitter scenario.LatencyDistribution
 }
 type podTimeFlags map[string][]*TimeFlags
+type podSelectors map[string]*PodSelector
 type podTimeIndexCache map[*corev1.Pod]map[events.EventFlag]int
+type podsByUID map[types.UID]*corev1.Pod
 
 type store struct {
 	queue     *taskQueue
 	queueLock sync.RWMutex
 
-	nodeFlags    Flags
-	nodeFlagLock sync.RWMutex
+	nodeFlags      Flags
+	nodeFlagStacks flagStacks
+	nodeFlagLock   sync.RWMutex
+
+	podFlags      podFlags
+	podFlagStacks map[string]flagStacks
+	podFlagLock   sync.RWMutex
 
-	podFlags    podFlags
-	podFlagLock sync.RWMutex
+	windows    map[Window]*windowState
+	nextWindow Window
+	windowLock sync.Mutex
 
 	podListeners     podListeners
 	podListenersLock sync.RWMutex
 
+	nodeListeners     nodeListeners
+	nodeListenersLock sync.RWMutex
+
 	podTimeFlags      podTimeFlags
+	podTimeScan       map[string]bool
+	podTraces         map[string]*Trace
 	podTimeIndexCache podTimeIndexCache
+	podSelectors      podSelectors
+
+	pods          podsByUID
+	podStatuses   podConfigurationStatuses
+	podStatusLock sync.RWMutex
 }
 
 // NewStore returns an empty store
@@ -74,17 +112,32 @@ func NewStore() Store {
 	heap.Init(q)
 
 	return &store{
-		queue:        q,
-		nodeFlags:    make(Flags),
-		podListeners: make(podListeners),
-		podFlags:     make(podFlags),
+		queue:          q,
+		nodeFlags:      make(Flags),
+		nodeFlagStacks: make(flagStacks),
+		podListeners:   make(podListeners),
+		nodeListeners:  make(nodeListeners),
+		podFlags:       make(podFlags),
+		podFlagStacks:  make(map[string]flagStacks),
+
+		windows: make(map[Window]*windowState),
 
 		podTimeFlags:      make(podTimeFlags),
+		podTimeScan:       make(map[string]bool),
+		podTraces:         make(map[string]*Trace),
 		podTimeIndexCache: make(podTimeIndexCache),
+		podSelectors:      make(podSelectors),
+
+		pods:        make(podsByUID),
+		podStatuses: make(podConfigurationStatuses),
 	}
 }
 
 func (s *store) RemovePodTasks(label string) error {
+	s.podStatusLock.Lock()
+	delete(s.podStatuses, label)
+	s.podStatusLock.Unlock()
+
 	s.queueLock.Lock()
 	defer s.queueLock.Unlock()
 
@@ -147,6 +200,13 @@ func (s *store) AddPodFlagListener(flag events.PodEventFlag, cb func(interface{}
 	}
 }
 
+func (s *store) AddNodeFlagListener(flag events.NodeEventFlag, cb func(interface{})) {
+	s.nodeListenersLock.Lock()
+	defer s.nodeListenersLock.Unlock()
+
+	s.nodeListeners[flag] = append(s.nodeListeners[flag], cb)
+}
+
 func getPodLabelByPod(pod *corev1.Pod) (string, bool) {
 	label, ok := pod.Labels[podconfigv1.PodConfigurationLabel]
 	if !ok {
@@ -164,7 +224,23 @@ var defaultNodeValues = map[events.EventFlag]interface{}{
 	events.NodeGetPodsResponse:      scenario.ResponseUnset,
 	events.NodePingResponse:         scenario.ResponseUnset,
 
-	events.NodeAddedLatency: time.Duration(0),
+	events.NodeAddedLatency:        scenario.ConstantLatency(0),
+	events.NodeCreatePodLatency:    scenario.ConstantLatency(0),
+	events.NodeUpdatePodLatency:    scenario.ConstantLatency(0),
+	events.NodeDeletePodLatency:    scenario.ConstantLatency(0),
+	events.NodeGetPodLatency:       scenario.ConstantLatency(0),
+	events.NodeGetPodStatusLatency: scenario.ConstantLatency(0),
+	events.NodeGetPodsLatency:      scenario.ConstantLatency(0),
+	events.NodePingLatency:         scenario.ConstantLatency(0),
+
+	events.NodePodStartup: &scenario.StartupPhases{},
+	events.NodeImages:     &scenario.NodeImages{},
+	events.NodeConditions: scenario.DefaultNodeConditions(),
+	events.NodeCapacity:   &scenario.NodeCapacity{},
+
+	events.NodeLabels:      map[string]string{},
+	events.NodeAnnotations: map[string]string{},
+	events.NodeTaints:      []corev1.Taint{},
 }
 
 var defaultPodValues = map[events.PodEventFlag]interface{}{
@@ -177,4 +253,20 @@ var defaultPodValues = map[events.PodEventFlag]interface{}{
 	events.PodResources: &stats.PodStats{},
 
 	events.PodStatus: scenario.PodStatusUnset,
+
+	events.PodCreatePodLatency:    scenario.ConstantLatency(0),
+	events.PodUpdatePodLatency:    scenario.ConstantLatency(0),
+	events.PodDeletePodLatency:    scenario.ConstantLatency(0),
+	events.PodGetPodLatency:       scenario.ConstantLatency(0),
+	events.PodGetPodStatusLatency: scenario.ConstantLatency(0),
+
+	events.PodCrash:      &scenario.CrashModel{},
+	events.PodContainers: scenario.ContainerStates{},
+	events.PodProbes:     scenario.Probes{},
+	events.PodStartup:    &scenario.StartupPhases{},
+	events.PodImagePull:  &scenario.PodImagePull{},
+	events.PodRuntime:    &scenario.RuntimeModel{},
+	events.PodLogs:       &scenario.LogModel{},
+	events.PodExec:       scenario.ExecRules{},
+	events.PodProcesses:  int64(0),
 }
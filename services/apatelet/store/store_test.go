@@ -339,8 +339,8 @@ func TestEnqueueCRDUpdate(t *testing.T) {
 	// Testing whether updating CRDs works
 	// And if adding less means old CRDs are removed
 	err := st.SetPodTasks("la/clappe", []*Task{
-		NewPodTask(10, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(20, "la/clappe", &podconfigv1.PodConfigurationState{}),
+		NewPodTask(10, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(20, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
 	})
 	assert.NoError(t, err)
 
@@ -358,10 +358,10 @@ func TestEnqueueCRDUpdateMore(t *testing.T) {
 	// Testing whether updating CRDs works
 	// And if adding more means new CRDs are added
 	err := st.SetPodTasks("la/clappe", []*Task{
-		NewPodTask(10, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(20, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(220, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(120, "la/clappe", &podconfigv1.PodConfigurationState{}),
+		NewPodTask(10, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(20, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(220, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(120, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
 	})
 	assert.NoError(t, err)
 
@@ -381,7 +381,7 @@ func TestEnqueueCRDNewLabel(t *testing.T) {
 	// Testing whether updating CRDs works
 	// And if adding more means new CRDs are added
 	err := st.SetPodTasks("high/tech", []*Task{
-		NewPodTask(44, "high/tech", &podconfigv1.PodConfigurationState{}),
+		NewPodTask(44, "high/tech", 0, &podconfigv1.PodConfigurationState{}),
 	})
 	assert.NoError(t, err)
 
@@ -400,7 +400,7 @@ func TestRemoveCRD(t *testing.T) {
 
 	// Testing whether removig CRDs works, even when there are multiple
 	err := st.SetPodTasks("high/tech", []*Task{
-		NewPodTask(44, "high/tech", &podconfigv1.PodConfigurationState{}),
+		NewPodTask(44, "high/tech", 0, &podconfigv1.PodConfigurationState{}),
 	})
 	assert.NoError(t, err)
 
@@ -423,9 +423,9 @@ func insertBaselineCRD(t *testing.T) Store {
 
 	// Testing whether adding new CRDs works
 	err = st.SetPodTasks("la/clappe", []*Task{
-		NewPodTask(100, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(42, "la/clappe", &podconfigv1.PodConfigurationState{}),
-		NewPodTask(140, "la/clappe", &podconfigv1.PodConfigurationState{}),
+		NewPodTask(100, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(42, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
+		NewPodTask(140, "la/clappe", 0, &podconfigv1.PodConfigurationState{}),
 	})
 	assert.NoError(t, err)
 
@@ -529,6 +529,22 @@ func TestAddPodListener(t *testing.T) {
 	assert.True(t, cb3Called)
 }
 
+func TestAddNodeListener(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	var updates []interface{}
+	st.AddNodeFlagListener(events.NodeCreatePodResponse, func(obj interface{}) {
+		updates = append(updates, obj)
+	})
+
+	st.SetNodeFlags(Flags{events.NodeCreatePodResponse: scenario.ResponseTimeout})
+	st.SetNodeFlags(Flags{events.NodeUpdatePodResponse: scenario.ResponseError})
+
+	assert.Equal(t, []interface{}{scenario.ResponseTimeout}, updates)
+}
+
 func TestSetPodTimeFlags(t *testing.T) {
 	t.Parallel()
 
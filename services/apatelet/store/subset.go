@@ -0,0 +1,61 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodSubset selects a fraction of the pods of a configuration
+// Whether a pod is selected is determined by hashing a seed together with its uid, so the same pods are selected every time
+type PodSubset struct {
+	seed string
+
+	// The selected part of the hash space, as fractions between 0 and 1
+	from, to float64
+}
+
+// NewPodSubset creates a subset selecting the given percentage of pods, after skipping offset percent of the pods
+// Subsets with the same seed and consecutive offsets select disjoint sets of pods
+func NewPodSubset(seed string, percentage, offset int64) (*PodSubset, error) {
+	if percentage <= 0 || percentage > 100 {
+		return nil, errors.Errorf("percentage %v should be between 0 and 100", percentage)
+	} else if offset < 0 || offset+percentage > 100 {
+		return nil, errors.Errorf("offset %v should be between 0 and %v", offset, 100-percentage)
+	}
+
+	return &PodSubset{
+		seed: seed,
+		from: float64(offset) / 100,
+		to:   float64(offset+percentage) / 100,
+	}, nil
+}
+
+// Selects returns true if the given pod is part of the subset, a nil subset selects every pod
+func (s *PodSubset) Selects(pod *corev1.Pod) bool {
+	if s == nil {
+		return true
+	}
+
+	hash := sha256.Sum256([]byte(s.seed + "/" + string(pod.UID)))
+
+	position := float64(binary.BigEndian.Uint64(hash[:8])) / math.MaxUint64
+	return position >= s.from && (position < s.to || s.to == 1)
+}
+
+// selectsAll returns true if the subset selects every pod
+func (s *PodSubset) selectsAll() bool {
+	return s == nil || (s.from == 0 && s.to == 1)
+}
+
+// covers returns true if every pod selected by the other subset is selected by this subset as well
+func (s *PodSubset) covers(other *PodSubset) bool {
+	if s.selectsAll() {
+		return true
+	}
+
+	return other != nil && s.seed == other.seed && s.from <= other.from && other.to <= s.to
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+func createPodsWithUIDs(amount int) []*corev1.Pod {
+	pods := make([]*corev1.Pod, amount)
+	for i := range pods {
+		pods[i] = createPodWithLabel("a", "b")
+		pods[i].UID = types.UID("uid-" + strconv.Itoa(i))
+	}
+	return pods
+}
+
+func TestPodSubsetFraction(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewPodSubset("seed", 10, 0)
+	assert.NoError(t, err)
+	second, err := NewPodSubset("seed", 10, 10)
+	assert.NoError(t, err)
+
+	var selectedFirst, selectedSecond int
+	for _, pod := range createPodsWithUIDs(10000) {
+		inFirst, inSecond := first.Selects(pod), second.Selects(pod)
+		assert.False(t, inFirst && inSecond, "consecutive offsets should select different pods")
+
+		if inFirst {
+			selectedFirst++
+		}
+		if inSecond {
+			selectedSecond++
+		}
+	}
+
+	assert.InDelta(t, 1000, selectedFirst, 100)
+	assert.InDelta(t, 1000, selectedSecond, 100)
+
+	// A nil subset selects every pod
+	var all *PodSubset
+	assert.True(t, all.Selects(createPodWithLabel("a", "b")))
+}
+
+func TestPodSubsetDeterministic(t *testing.T) {
+	t.Parallel()
+
+	a, _ := NewPodSubset("a", 50, 0)
+	sameA, _ := NewPodSubset("a", 50, 0)
+	b, _ := NewPodSubset("b", 50, 0)
+
+	differs := false
+	for _, pod := range createPodsWithUIDs(100) {
+		assert.Equal(t, a.Selects(pod), sameA.Selects(pod))
+		differs = differs || a.Selects(pod) != b.Selects(pod)
+	}
+
+	assert.True(t, differs, "different seeds should select different pods")
+}
+
+func TestNewPodSubsetInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][2]int64{{0, 0}, {101, 0}, {10, -1}, {50, 60}} {
+		_, err := NewPodSubset("", args[0], args[1])
+		assert.Error(t, err, "percentage %v offset %v", args[0], args[1])
+	}
+}
+
+func TestSubsetPodFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	subset, _ := NewPodSubset("", 50, 0)
+	pods := createPodsWithUIDs(100)
+
+	st.SetPodFlags("a/b", Flags{events.PodStatus: scenario.PodStatusRunning})
+	st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Subset: subset, Value: scenario.PodStatusFailed}})
+
+	for _, pod := range pods {
+		expected := scenario.PodStatusRunning
+		if subset.Selects(pod) {
+			expected = scenario.PodStatusFailed
+		}
+
+		flag, err := st.GetPodFlag(pod, events.PodStatus)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, flag)
+	}
+
+	// Without a previous value, the pods which are not selected use the default
+	st.SetPodFlags("a/b", Flags{events.PodCreatePodResponse: &PartialValue{Subset: subset, Value: scenario.ResponseError}})
+	for _, pod := range pods {
+		flag, err := st.GetPodFlag(pod, events.PodCreatePodResponse)
+		assert.NoError(t, err)
+		if !subset.Selects(pod) {
+			assert.Equal(t, scenario.ResponseUnset, flag)
+		}
+	}
+}
+
+func TestSubsetPodTimeFlags(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	subset, _ := NewPodSubset("", 50, 0)
+
+	st.SetPodTimeFlags("a/b", []*TimeFlags{
+		{TimeSincePodStart: 0, Flags: Flags{42: "a"}},
+		{TimeSincePodStart: time.Second, Flags: Flags{42: "b"}, Subset: subset},
+	})
+
+	for _, pod := range createPodsWithUIDs(50) {
+		startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		pod.Status.StartTime = &startTime
+
+		expected := "a"
+		if subset.Selects(pod) {
+			expected = "b"
+		}
+
+		flag, err := st.GetPodFlag(pod, 42)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, flag)
+	}
+}
+
+func chainLength(value interface{}) int {
+	length := 1
+	for {
+		partialValue, ok := value.(*PartialValue)
+		if !ok || !partialValue.hasPrevious {
+			return length
+		}
+		value = partialValue.previous
+		length++
+	}
+}
+
+func TestSubsetPodFlagsPruned(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore().(*store)
+	first, _ := NewPodSubset("", 10, 0)
+	second, _ := NewPodSubset("", 10, 10)
+	all, _ := NewPodSubset("", 100, 0)
+	pods := createPodsWithUIDs(100)
+
+	st.SetPodFlags("a/b", Flags{events.PodStatus: scenario.PodStatusRunning})
+
+	// Setting the same subsets over and over again only keeps the latest values
+	for i := 0; i < 100; i++ {
+		st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Subset: first, Value: scenario.PodStatusFailed}})
+		st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Subset: second, Value: scenario.PodStatusSucceeded}})
+	}
+
+	flags := st.podFlags["a/b"]
+	assert.Equal(t, 3, chainLength(flags[events.PodStatus]))
+
+	for _, pod := range pods {
+		expected := scenario.PodStatusRunning
+		if first.Selects(pod) {
+			expected = scenario.PodStatusFailed
+		} else if second.Selects(pod) {
+			expected = scenario.PodStatusSucceeded
+		}
+
+		flag, err := st.GetPodFlag(pod, events.PodStatus)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, flag)
+	}
+
+	// A value set for all pods shadows every earlier value
+	st.SetPodFlags("a/b", Flags{events.PodStatus: &PartialValue{Subset: all, Value: scenario.PodStatusPending}})
+	flags = st.podFlags["a/b"]
+	assert.Equal(t, 1, chainLength(flags[events.PodStatus]))
+}
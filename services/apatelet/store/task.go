@@ -1,10 +1,12 @@
 package store
 
 import (
+	"fmt"
 	"time"
 
 	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
+	"github.com/atlarge-research/apate/pkg/scenario"
 
 	"github.com/pkg/errors"
 )
@@ -17,8 +19,23 @@ type Task struct {
 	// The timestamp on which this task should be executed, relative to the start of the scenario
 	RelativeTimestamp time.Duration
 
-	PodTask  *PodTask
-	NodeTask *NodeTask
+	PodTask    *PodTask
+	NodeTask   *NodeTask
+	RevertTask *RevertTask
+
+	// Determines when the task is executed again, nil if the task is only executed once
+	Recurrence *Recurrence
+
+	// How long the flags set by this task last, 0 means they last until they are overwritten
+	Duration time.Duration
+
+	// The delay added to every execution of the task, which is already included in RelativeTimestamp
+	Jitter time.Duration
+}
+
+// RevertTask is a task that closes a window, restoring the flags set by the task which opened it
+type RevertTask struct {
+	Window Window
 }
 
 // NodeTask is a task that should be executed on a node level
@@ -28,28 +45,66 @@ type NodeTask struct {
 
 // IsNode returns true if the task is a node task
 func (t *Task) IsNode() (bool, error) {
-	isPod, err := t.IsPod()
-	return !isPod, errors.Wrap(err, "isPod during IsNode failed")
+	if err := t.checkType(); err != nil {
+		return false, errors.Wrap(err, "invalid task during IsNode")
+	}
+	return t.NodeTask != nil, nil
 }
 
 // PodTask is a task that should be executed on a pod level
 type PodTask struct {
 	// The label of the CRD, should be <namespace>/<name>
 	Label string
+	// The index of the task in the CRD
+	Index int
 	State *podconfigv1.PodConfigurationState
+
+	// The pods the state is set for, nil means all pods
+	Subset *PodSubset
+
+	// The distribution from which the delay before a pod sees the state is sampled, nil means there is no delay
+	Jitter scenario.LatencyDistribution
+}
+
+// JitterKey identifies this execution of a pod task when sampling the jitter of the pods
+// Every execution gets a different jitter, which is the same whenever the scenario is run
+func (t *Task) JitterKey() string {
+	return fmt.Sprintf("%v/%v/%v", t.PodTask.Label, t.PodTask.Index, int64(t.RelativeTimestamp))
 }
 
 // IsPod returns whether we are dealing with a pod (then PodTask should be non-nil) or a node (then NodeTask should be non-nil)
 func (t *Task) IsPod() (bool, error) {
-	if t.PodTask == nil && t.NodeTask == nil {
-		return false, errors.New("pod task & node task are both nil")
-	}
-	if t.PodTask != nil && t.NodeTask != nil {
-		return false, errors.New("pod task & node task are both non-nil")
+	if err := t.checkType(); err != nil {
+		return false, errors.Wrap(err, "invalid task during IsPod")
 	}
 	return t.PodTask != nil, nil
 }
 
+// IsRevert returns true if the task is a revert task
+func (t *Task) IsRevert() (bool, error) {
+	if err := t.checkType(); err != nil {
+		return false, errors.Wrap(err, "invalid task during IsRevert")
+	}
+	return t.RevertTask != nil, nil
+}
+
+// checkType makes sure exactly one of the pod task, node task and revert task is set
+func (t *Task) checkType() error {
+	count := 0
+	for _, set := range []bool{t.PodTask != nil, t.NodeTask != nil, t.RevertTask != nil} {
+		if set {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return errors.New("pod task, node task & revert task are all nil")
+	} else if count > 1 {
+		return errors.New("more than one of pod task, node task & revert task are non-nil")
+	}
+	return nil
+}
+
 // NewNodeTask creates a new task for a node event
 func NewNodeTask(relativeTime time.Duration, state *nodeconfigv1.NodeConfigurationState) *Task {
 	return &Task{
@@ -62,12 +117,23 @@ func NewNodeTask(relativeTime time.Duration, state *nodeconfigv1.NodeConfigurati
 }
 
 // NewPodTask creates a new task for a pod event
-func NewPodTask(relativeTime time.Duration, label string, state *podconfigv1.PodConfigurationState) *Task {
+func NewPodTask(relativeTime time.Duration, label string, index int, state *podconfigv1.PodConfigurationState) *Task {
 	return &Task{
 		RelativeTimestamp: relativeTime,
 		PodTask: &PodTask{
 			Label: label,
+			Index: index,
 			State: state,
 		},
 	}
 }
+
+// NewRevertTask creates a new task which closes the given window
+func NewRevertTask(relativeTime time.Duration, window Window) *Task {
+	return &Task{
+		RelativeTimestamp: relativeTime,
+		RevertTask: &RevertTask{
+			Window: window,
+		},
+	}
+}
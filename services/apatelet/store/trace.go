@@ -0,0 +1,189 @@
+package store
+
+import (
+	"encoding/csv"
+	"hash/fnv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+)
+
+// Trace is recorded resource usage which is replayed relative to the start of pods
+// A trace may contain the usage of several recorded pods, every emulated pod replays one of them
+type Trace struct {
+	// The samples of every recorded pod, sorted by timestamp
+	series [][]traceSample
+}
+
+type traceSample struct {
+	// The time since the start of the pod at which the usage is reached
+	at    time.Duration
+	usage *stats.PodStats
+}
+
+// traceColumns are the columns a trace may contain, besides the required timestamp column
+var traceColumns = map[string]bool{
+	"pod":               true,
+	"cpu":               true,
+	"memory":            true,
+	"storage":           true,
+	"ephemeral_storage": true,
+}
+
+// NewTrace parses a trace from CSV data
+// The first row is a header naming the columns, of which only timestamp is required
+// Timestamps are either in time.ParseDuration format or a number of seconds, cpu is a number and the other resources are in bytes
+// If there is a pod column, rows with the same value in it form the usage of a single recorded pod
+// Other columns are ignored
+func NewTrace(reader io.Reader) (*Trace, error) {
+	r := csv.NewReader(reader)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("trace is empty")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read trace header")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "timestamp" || traceColumns[name] {
+			columns[name] = i
+		}
+	}
+
+	if _, ok := columns["timestamp"]; !ok {
+		return nil, errors.New("trace has no timestamp column")
+	}
+
+	trace := &Trace{}
+	seriesByPod := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read line %v of trace", line)
+		}
+
+		sample, err := parseTraceSample(record, columns)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid line %v of trace", line)
+		}
+
+		var pod string
+		if i, ok := columns["pod"]; ok {
+			pod = record[i]
+		}
+
+		index, ok := seriesByPod[pod]
+		if !ok {
+			index = len(trace.series)
+			seriesByPod[pod] = index
+			trace.series = append(trace.series, nil)
+		}
+		trace.series[index] = append(trace.series[index], sample)
+	}
+
+	if len(trace.series) == 0 {
+		return nil, errors.New("trace contains no samples")
+	}
+
+	for _, series := range trace.series {
+		series := series
+		sort.SliceStable(series, func(i, j int) bool {
+			return series[i].at < series[j].at
+		})
+	}
+
+	return trace, nil
+}
+
+func parseTraceSample(record []string, columns map[string]int) (traceSample, error) {
+	timestamp := record[columns["timestamp"]]
+
+	at, err := time.ParseDuration(timestamp)
+	if err != nil {
+		seconds, err := strconv.ParseFloat(timestamp, 64)
+		if err != nil || seconds < 0 {
+			return traceSample{}, errors.Errorf("invalid timestamp %v", timestamp)
+		}
+		at = time.Duration(seconds * float64(time.Second))
+	}
+
+	usage := &stats.PodStats{}
+
+	if i, ok := columns["cpu"]; ok {
+		cpu, err := strconv.ParseFloat(record[i], 64)
+		if err != nil || cpu < 0 {
+			return traceSample{}, errors.Errorf("invalid cpu %v", record[i])
+		}
+		// Traces such as those of Google and Alibaba record the cpu usage in (fractions of) cores
+		usage.UsageNanoCores = uint64(math.Round(cpu * 1e9))
+	}
+
+	bytes := []struct {
+		column string
+		target *uint64
+	}{
+		{"memory", &usage.UsageBytesMemory},
+		{"storage", &usage.UsedBytesStorage},
+		{"ephemeral_storage", &usage.UsedBytesEphemeral},
+	}
+
+	for _, b := range bytes {
+		if i, ok := columns[b.column]; ok {
+			value, err := scenario.GetInBytes(record[i], b.column)
+			if err != nil {
+				return traceSample{}, errors.Wrapf(err, "invalid %v %v", b.column, record[i])
+			}
+			*b.target = uint64(value)
+		}
+	}
+
+	return traceSample{at: at, usage: usage}, nil
+}
+
+// Pods returns the amount of recorded pods in the trace
+func (t *Trace) Pods() int {
+	return len(t.series)
+}
+
+// usageAt returns the usage of the given pod the given time after it started
+// Before the first sample there is no usage, after the last sample the usage of the last sample remains
+func (t *Trace) usageAt(pod *corev1.Pod, sinceStart time.Duration) (*stats.PodStats, bool) {
+	series := t.series[t.seriesOf(pod)]
+
+	index := sort.Search(len(series), func(i int) bool {
+		return series[i].at > sinceStart
+	})
+
+	if index == 0 {
+		return nil, false
+	}
+
+	return series[index-1].usage, true
+}
+
+// seriesOf determines which recorded pod the given pod replays, based on its uid
+func (t *Trace) seriesOf(pod *corev1.Pod) int {
+	if len(t.series) == 1 {
+		return 0
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(pod.UID))
+	return int(hash.Sum32() % uint32(len(t.series)))
+}
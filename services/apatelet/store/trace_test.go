@@ -0,0 +1,140 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/finitum/node-cli/stats"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+const testTrace = `timestamp,cpu,memory,ephemeral_storage,machine
+10s,0.1,1K,0,m1
+0,0.05,512,1M,m1
+20.5,0.2,2K,0,m1
+`
+
+func TestNewTrace(t *testing.T) {
+	t.Parallel()
+
+	trace, err := NewTrace(strings.NewReader(testTrace))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trace.Pods())
+
+	// Samples are sorted and both timestamp formats are accepted
+	series := trace.series[0]
+	assert.Equal(t, []time.Duration{0, 10 * time.Second, 20500 * time.Millisecond}, []time.Duration{series[0].at, series[1].at, series[2].at})
+	assert.Equal(t, &stats.PodStats{UsageNanoCores: 50000000, UsageBytesMemory: 512, UsedBytesEphemeral: 1024 * 1024}, series[0].usage)
+}
+
+func TestNewTraceInvalid(t *testing.T) {
+	t.Parallel()
+
+	traces := []string{
+		"",
+		"cpu,memory\n1,1K\n",
+		"timestamp,cpu\n",
+		"timestamp,cpu\nsoon,1\n",
+		"timestamp,cpu\n1s,-1\n",
+		"timestamp,memory\n1s,lots\n",
+		"timestamp,cpu\n1s,1,1\n",
+	}
+
+	for _, trace := range traces {
+		_, err := NewTrace(strings.NewReader(trace))
+		assert.Error(t, err, "trace %q", trace)
+	}
+}
+
+func TestTraceUsageAt(t *testing.T) {
+	t.Parallel()
+
+	trace, err := NewTrace(strings.NewReader(testTrace))
+	assert.NoError(t, err)
+
+	pod := createPodWithLabel("a", "b")
+
+	usage, ok := trace.usageAt(pod, 5*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(50000000), usage.UsageNanoCores)
+
+	usage, ok = trace.usageAt(pod, 10*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100000000), usage.UsageNanoCores)
+
+	// The last sample remains after the trace ends
+	usage, ok = trace.usageAt(pod, time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(200000000), usage.UsageNanoCores)
+
+	late, err := NewTrace(strings.NewReader("timestamp,cpu\n1m,1\n"))
+	assert.NoError(t, err)
+
+	_, ok = late.usageAt(pod, time.Second)
+	assert.False(t, ok)
+}
+
+func TestTraceMultiplePods(t *testing.T) {
+	t.Parallel()
+
+	trace, err := NewTrace(strings.NewReader("timestamp,pod,cpu\n0,a,1\n0,b,2\n0,c,3\n1s,a,4\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, trace.Pods())
+
+	// Every pod consistently replays one of the recorded pods
+	seen := make(map[uint64]bool)
+	for i := 0; i < 100; i++ {
+		pod := createPodWithLabel("a", "b")
+		pod.UID = types.UID(strings.Repeat("x", i))
+
+		first, ok := trace.usageAt(pod, 0)
+		assert.True(t, ok)
+
+		second, _ := trace.usageAt(pod, 0)
+		assert.Equal(t, first, second)
+
+		seen[first.UsageNanoCores] = true
+	}
+
+	assert.Equal(t, map[uint64]bool{1000000000: true, 2000000000: true, 3000000000: true}, seen)
+}
+
+func TestPodFlagFromTrace(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	trace, err := NewTrace(strings.NewReader(testTrace))
+	assert.NoError(t, err)
+	st.SetPodTrace("a/b", trace)
+
+	pod := createPodWithLabel("a", "b")
+	startTime := metav1.NewTime(time.Now().Add(-15 * time.Second))
+	pod.Status.StartTime = &startTime
+
+	flag, err := st.GetPodFlag(pod, events.PodResources)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100000000), flag.(*stats.PodStats).UsageNanoCores)
+
+	// Time flags go before the trace
+	st.SetPodTimeFlags("a/b", []*TimeFlags{
+		{TimeSincePodStart: 0, Flags: Flags{events.PodResources: &stats.PodStats{UsageNanoCores: 42}}},
+	})
+
+	flag, err = st.GetPodFlag(pod, events.PodResources)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), flag.(*stats.PodStats).UsageNanoCores)
+
+	// Without the trace, the default is used again
+	st.SetPodTimeFlags("a/b", nil)
+	st.SetPodTrace("a/b", nil)
+
+	flag, err = st.GetPodFlag(pod, events.PodResources)
+	assert.NoError(t, err)
+	assert.Equal(t, &stats.PodStats{}, flag)
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+// Window identifies a set of flags which are only set temporarily
+// Windows are numbered from 1, so 0 never identifies a window
+type Window uint64
+
+// WindowSetter defines functions aiding in setting flags for a limited amount of time
+type WindowSetter interface {
+	// OpenWindow returns a new window, in which flags can be set until it is closed
+	OpenWindow() Window
+
+	// SetNodeWindowFlags sets the given node flags until the window is closed
+	// If the window is already closed, nothing happens
+	SetNodeWindowFlags(Window, Flags)
+
+	// SetPodWindowFlags sets the given pod flags for a configuration until the window is closed
+	// If the window is already closed, nothing happens
+	SetPodWindowFlags(Window, string, Flags)
+
+	// CloseWindow closes the window, restoring every flag it has set to the value it would have had without the window
+	// Flags which have been set permanently after the window had set them are left untouched
+	CloseWindow(Window)
+}
+
+// windowState keeps track of where the flags of a window have been set
+type windowState struct {
+	// Whether the window has set any flags, and if so whether they are node flags or the pod flags of a label
+	set   bool
+	node  bool
+	label string
+}
+
+type windowValue struct {
+	window Window
+	value  interface{}
+}
+
+// flagStack contains the values of a flag which is set by one or more open windows
+type flagStack struct {
+	// The value of the flag before the first of these windows set it, if it had any
+	base    interface{}
+	hasBase bool
+
+	// The values set by the windows, in the order in which they were set
+	// The last value is the current value of the flag
+	values []windowValue
+}
+
+type flagStacks map[events.EventFlag]*flagStack
+
+func (s *store) OpenWindow() Window {
+	s.windowLock.Lock()
+	defer s.windowLock.Unlock()
+
+	s.nextWindow++
+	s.windows[s.nextWindow] = &windowState{}
+	return s.nextWindow
+}
+
+func (s *store) SetNodeWindowFlags(window Window, flags Flags) {
+	if !s.markWindowSet(window, true, "") {
+		return
+	}
+
+	s.nodeFlagLock.Lock()
+	pushWindowFlags(s.nodeFlags, s.nodeFlagStacks, window, flags)
+	s.nodeFlagLock.Unlock()
+
+	s.callNodeListeners(flags)
+}
+
+func (s *store) SetPodWindowFlags(window Window, label string, flags Flags) {
+	if !s.markWindowSet(window, false, label) {
+		return
+	}
+
+	s.podFlagLock.Lock()
+	if _, ok := s.podFlags[label]; !ok {
+		s.podFlags[label] = make(Flags)
+	}
+	if _, ok := s.podFlagStacks[label]; !ok {
+		s.podFlagStacks[label] = make(flagStacks)
+	}
+
+	pushWindowFlags(s.podFlags[label], s.podFlagStacks[label], window, flags)
+	s.podFlagLock.Unlock()
+
+	s.callPodListeners(flags)
+}
+
+func (s *store) CloseWindow(window Window) {
+	s.windowLock.Lock()
+	state, ok := s.windows[window]
+	delete(s.windows, window)
+	s.windowLock.Unlock()
+
+	if !ok || !state.set {
+		return
+	}
+
+	if state.node {
+		s.nodeFlagLock.Lock()
+		restored := make(Flags)
+		for _, flag := range popWindowFlags(s.nodeFlags, s.nodeFlagStacks, window) {
+			if value, ok := s.nodeFlags[flag]; ok {
+				restored[flag] = value
+			} else {
+				restored[flag] = defaultNodeValues[flag]
+			}
+		}
+		s.nodeFlagLock.Unlock()
+
+		s.callNodeListeners(restored)
+		return
+	}
+
+	s.podFlagLock.Lock()
+	current := s.podFlags[state.label]
+	restored := make(Flags)
+	for _, flag := range popWindowFlags(current, s.podFlagStacks[state.label], window) {
+		if value, ok := current[flag]; ok {
+			restored[flag] = value
+		} else {
+			restored[flag] = defaultPodValues[flag]
+		}
+	}
+
+	if len(s.podFlagStacks[state.label]) == 0 {
+		delete(s.podFlagStacks, state.label)
+	}
+	s.podFlagLock.Unlock()
+
+	s.callPodListeners(restored)
+}
+
+// markWindowSet registers where the given window sets its flags, returning false if the window is not open
+func (s *store) markWindowSet(window Window, node bool, label string) bool {
+	s.windowLock.Lock()
+	defer s.windowLock.Unlock()
+
+	state, ok := s.windows[window]
+	if !ok {
+		return false
+	}
+
+	state.set = true
+	state.node = node
+	state.label = label
+	return true
+}
+
+// pushWindowFlags sets the given flags on behalf of a window, remembering the values underneath
+func pushWindowFlags(current Flags, stacks flagStacks, window Window, flags Flags) {
+	for flag, value := range flags {
+		stack, ok := stacks[flag]
+		if !ok {
+			stack = &flagStack{}
+			stack.base, stack.hasBase = current[flag]
+			stacks[flag] = stack
+		}
+
+		value = withPrevious(current, flag, value)
+		stack.values = append(stack.values, windowValue{window: window, value: value})
+		current[flag] = value
+	}
+}
+
+// popWindowFlags removes the values set by the given window, restoring the value of every flag for which it was the current value
+// It returns the flags which have been restored, a flag without a previous value is removed so it falls back to its default
+func popWindowFlags(current Flags, stacks flagStacks, window Window) []events.EventFlag {
+	var restored []events.EventFlag
+
+	for flag, stack := range stacks {
+		for i := len(stack.values) - 1; i >= 0; i-- {
+			if stack.values[i].window != window {
+				continue
+			}
+
+			last := i == len(stack.values)-1
+			stack.values = append(stack.values[:i], stack.values[i+1:]...)
+
+			if last {
+				switch {
+				case len(stack.values) > 0:
+					current[flag] = stack.values[len(stack.values)-1].value
+				case stack.hasBase:
+					current[flag] = stack.base
+				default:
+					delete(current, flag)
+				}
+
+				restored = append(restored, flag)
+			}
+			break
+		}
+
+		if len(stack.values) == 0 {
+			delete(stacks, flag)
+		}
+	}
+
+	return restored
+}
+
+// dropWindowFlags forgets the values windows have set for the given flags, as they are overwritten permanently
+func dropWindowFlags(stacks flagStacks, flags Flags) {
+	for flag := range flags {
+		delete(stacks, flag)
+	}
+}
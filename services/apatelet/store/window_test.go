@@ -0,0 +1,174 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/pkg/scenario/events"
+)
+
+func TestNodeWindowRestoresFlag(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.SetNodeFlags(Flags{events.NodeCreatePodResponse: scenario.ResponseError})
+
+	window := st.OpenWindow()
+	st.SetNodeWindowFlags(window, Flags{
+		events.NodeCreatePodResponse: scenario.ResponseTimeout,
+		events.NodePingResponse:      scenario.ResponseTimeout,
+	})
+
+	flag, err := st.GetNodeFlag(events.NodeCreatePodResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ResponseTimeout, flag)
+
+	st.CloseWindow(window)
+
+	// A flag with a previous value gets it back, a flag without one falls back to its default
+	flag, err = st.GetNodeFlag(events.NodeCreatePodResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ResponseError, flag)
+
+	flag, err = st.GetNodeFlag(events.NodePingResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ResponseUnset, flag)
+}
+
+func TestNodeWindowOverlap(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.SetNodeFlags(Flags{42: "a"})
+
+	first := st.OpenWindow()
+	st.SetNodeWindowFlags(first, Flags{42: "b"})
+
+	second := st.OpenWindow()
+	st.SetNodeWindowFlags(second, Flags{42: "c"})
+
+	third := st.OpenWindow()
+	st.SetNodeWindowFlags(third, Flags{42: "d"})
+
+	// Closing a window which is no longer on top keeps the current value
+	st.CloseWindow(second)
+	flag, _ := st.GetNodeFlag(42)
+	assert.Equal(t, "d", flag)
+
+	// Closing the top window skips the value of the window which was already closed
+	st.CloseWindow(third)
+	flag, _ = st.GetNodeFlag(42)
+	assert.Equal(t, "b", flag)
+
+	st.CloseWindow(first)
+	flag, _ = st.GetNodeFlag(42)
+	assert.Equal(t, "a", flag)
+}
+
+func TestNodeWindowOverwrittenPermanently(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.SetNodeFlags(Flags{42: "a"})
+
+	window := st.OpenWindow()
+	st.SetNodeWindowFlags(window, Flags{42: "b"})
+	st.SetNodeFlags(Flags{42: "c"})
+	st.CloseWindow(window)
+
+	flag, _ := st.GetNodeFlag(42)
+	assert.Equal(t, "c", flag)
+}
+
+func TestWindowClosedBeforeSet(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.SetNodeFlags(Flags{42: "a"})
+
+	window := st.OpenWindow()
+	st.CloseWindow(window)
+	st.SetNodeWindowFlags(window, Flags{42: "b"})
+
+	flag, _ := st.GetNodeFlag(42)
+	assert.Equal(t, "a", flag)
+}
+
+func TestPodWindowRestoresFlag(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	pod := createPodWithLabel("a", "b")
+
+	var updates []interface{}
+	st.AddPodFlagListener(events.PodCreatePodResponse, func(obj interface{}) {
+		updates = append(updates, obj)
+	})
+
+	window := st.OpenWindow()
+	st.SetPodWindowFlags(window, "a/b", Flags{events.PodCreatePodResponse: scenario.ResponseError})
+
+	flag, err := st.GetPodFlag(pod, events.PodCreatePodResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ResponseError, flag)
+
+	st.CloseWindow(window)
+
+	flag, err = st.GetPodFlag(pod, events.PodCreatePodResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario.ResponseUnset, flag)
+
+	// Listeners are told about the restored value as well
+	assert.Equal(t, []interface{}{scenario.ResponseError, scenario.ResponseUnset}, updates)
+}
+
+func TestNodeWindowCallsListeners(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+
+	var updates []interface{}
+	st.AddNodeFlagListener(events.NodePingResponse, func(obj interface{}) {
+		updates = append(updates, obj)
+	})
+
+	window := st.OpenWindow()
+	st.SetNodeWindowFlags(window, Flags{events.NodePingResponse: scenario.ResponseError})
+	st.CloseWindow(window)
+
+	// Listeners are told about the restored value as well
+	assert.Equal(t, []interface{}{scenario.ResponseError, scenario.ResponseUnset}, updates)
+}
+
+func TestPodTimeFlagsWithDuration(t *testing.T) {
+	t.Parallel()
+
+	st := NewStore()
+	st.SetPodTimeFlags("a/b", []*TimeFlags{
+		{TimeSincePodStart: 0, Flags: Flags{42: "a"}},
+		{TimeSincePodStart: 10 * time.Second, Duration: 10 * time.Second, Flags: Flags{42: "b"}},
+		{TimeSincePodStart: 12 * time.Second, Duration: 2 * time.Second, Flags: Flags{42: "c"}},
+	})
+
+	expected := map[time.Duration]interface{}{
+		5 * time.Second:  "a",
+		11 * time.Second: "b",
+		13 * time.Second: "c",
+		15 * time.Second: "b",
+		25 * time.Second: "a",
+	}
+
+	for sinceStart, value := range expected {
+		pod := createPodWithLabel("a", "b")
+		startTime := metav1.NewTime(time.Now().Add(-sinceStart))
+		pod.Status.StartTime = &startTime
+
+		flag, err := st.GetPodFlag(pod, 42)
+		assert.NoError(t, err)
+		assert.Equal(t, value, flag, "flag %v after the pod started", sinceStart)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/atlarge-research/apate/pkg/scenario"
 
 	"github.com/atlarge-research/apate/services/controlplane/cluster"
+	"github.com/atlarge-research/apate/services/controlplane/crd/node"
+	"github.com/atlarge-research/apate/services/controlplane/crd/pod"
 
 	"github.com/atlarge-research/apate/api/health"
 	"github.com/atlarge-research/apate/pkg/kubernetes"
@@ -18,20 +20,29 @@ import (
 
 // StartWatchDog starts the watchdog
 // The watchdog checks for unhealthy nodes, and removes them
-func StartWatchDog(ctx context.Context, delay time.Duration, st *store.Store, cl *kubernetes.ClusterAPI) {
+// The status of the node and pod configurations is updated using the given handlers, also after removing nodes
+func StartWatchDog(ctx context.Context, delay time.Duration, st *store.Store, cl *kubernetes.ClusterAPI, handler *node.ApateletHandler, podHandler *pod.StatusHandler) {
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-time.After(delay):
-				checkUnhealthyApatelets(st, cl)
+				if err := (*handler).UpdateStatuses(); err != nil {
+					log.Printf("unable to update node configuration statuses: %v", err)
+				}
+
+				if err := (*podHandler).UpdateStatuses(); err != nil {
+					log.Printf("unable to update pod configuration statuses: %v", err)
+				}
+
+				checkUnhealthyApatelets(st, cl, handler)
 			}
 		}
 	}()
 }
 
-func checkUnhealthyApatelets(st *store.Store, cl *kubernetes.ClusterAPI) {
+func checkUnhealthyApatelets(st *store.Store, cl *kubernetes.ClusterAPI, handler *node.ApateletHandler) {
 	apatelets, err := (*st).GetNodes()
 
 	if err != nil {
@@ -53,6 +64,10 @@ func checkUnhealthyApatelets(st *store.Store, cl *kubernetes.ClusterAPI) {
 				if err != nil {
 					log.Printf("error while reading resources to queue: %v", err)
 				}
+
+				if err := (*handler).UpdateStatus(kubelet.Label); err != nil {
+					log.Printf("unable to update status of node configuration %v: %v", kubelet.Label, err)
+				}
 			}
 		}
 	}
@@ -13,6 +13,10 @@ import (
 	"github.com/atlarge-research/apate/pkg/kubernetes"
 	"github.com/atlarge-research/apate/pkg/kubernetes/mock_kubernetes"
 	"github.com/atlarge-research/apate/pkg/scenario"
+	"github.com/atlarge-research/apate/services/controlplane/crd/node"
+	"github.com/atlarge-research/apate/services/controlplane/crd/node/mock_node"
+	"github.com/atlarge-research/apate/services/controlplane/crd/pod"
+	"github.com/atlarge-research/apate/services/controlplane/crd/pod/mock_pod"
 	"github.com/atlarge-research/apate/services/controlplane/store"
 	"github.com/atlarge-research/apate/services/controlplane/store/mock_store"
 )
@@ -36,6 +40,7 @@ func TestWatchdogNoApateError(t *testing.T) {
 		},
 		{
 			UUID:   unhealthyUUID,
+			Label:  "default/nodes",
 			Status: health.Status_UNHEALTHY,
 			Resources: &scenario.NodeResources{
 				CPU: 1000,
@@ -55,9 +60,18 @@ func TestWatchdogNoApateError(t *testing.T) {
 	}).Return(nil)
 	mapi.EXPECT().RemoveNodeFromCluster("apatelet-" + unhealthyUUID.String()).Return(nil)
 
-	StartWatchDog(ctx, 1*time.Second, &st, &api)
+	mh := mock_node.NewMockApateletHandler(ctrl)
+	var handler node.ApateletHandler = mh
+	mh.EXPECT().UpdateStatuses().Return(nil).MinTimes(1)
+	mh.EXPECT().UpdateStatus("default/nodes").Return(nil)
 
-	time.Sleep(3 * time.Second)
+	mp := mock_pod.NewMockStatusHandler(ctrl)
+	var podHandler pod.StatusHandler = mp
+	mp.EXPECT().UpdateStatuses().Return(nil).MinTimes(1)
+
+	StartWatchDog(ctx, 1*time.Second, &st, &api, &handler, &podHandler)
+
+	time.Sleep(2500 * time.Millisecond)
 
 	cancel()
 }
@@ -90,9 +104,17 @@ func TestWatchdogApateError(t *testing.T) {
 	ms.EXPECT().RemoveNode(unhealthyUUID).Return(errors.New("f")).Times(2)
 	mapi.EXPECT().RemoveNodeFromCluster("apatelet-" + unhealthyUUID.String()).Return(nil).Times(2)
 
-	StartWatchDog(ctx, 1*time.Second, &st, &api)
+	mh := mock_node.NewMockApateletHandler(ctrl)
+	var handler node.ApateletHandler = mh
+	mh.EXPECT().UpdateStatuses().Return(errors.New("f")).MinTimes(1)
+
+	mp := mock_pod.NewMockStatusHandler(ctrl)
+	var podHandler pod.StatusHandler = mp
+	mp.EXPECT().UpdateStatuses().Return(errors.New("f")).MinTimes(1)
+
+	StartWatchDog(ctx, 1*time.Second, &st, &api, &handler, &podHandler)
 
-	time.Sleep(3 * time.Second)
+	time.Sleep(2500 * time.Millisecond)
 
 	cancel()
 }
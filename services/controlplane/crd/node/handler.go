@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -41,6 +42,12 @@ type ApateletHandler interface {
 
 	// Stops n apatelets with label
 	StopApatelets(context.Context, int64, string) error
+
+	// Updates the status of all known node configurations based on the apatelets in the store
+	UpdateStatuses() error
+
+	// Updates the status of the node configuration with the given label based on the apatelets in the store
+	UpdateStatus(string) error
 }
 
 type apateletHandler struct {
@@ -49,21 +56,49 @@ type apateletHandler struct {
 	cluster        *kubernetes.Cluster
 	connectionInfo *service.ConnectionInfo
 	runnerRegistry *runner.Registry
+
+	client         *nodev1.ConfigurationClient
+	configurations map[string]configurationState
+	statusLock     sync.Mutex
 }
 
 // NewHandler creates a new ApateletHandler
-func NewHandler(st *store.Store, runnerRegistry *runner.Registry, info *service.ConnectionInfo, cl *kubernetes.Cluster) *ApateletHandler {
+func NewHandler(st *store.Store, runnerRegistry *runner.Registry, info *service.ConnectionInfo, cl *kubernetes.Cluster) (*ApateletHandler, error) {
+	cfg, err := cl.KubeConfig.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get kubeconfig for node handler")
+	}
+
+	client, err := nodev1.NewForConfig(cfg, "default")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create node client from config for node handler")
+	}
+
 	var handler ApateletHandler = &apateletHandler{
 		store:          st,
 		connectionInfo: info,
 		runnerRegistry: runnerRegistry,
 		cluster:        cl,
+
+		client:         client,
+		configurations: make(map[string]configurationState),
 	}
 
-	return &handler
+	return &handler, nil
 }
 
 func (a *apateletHandler) GetDesiredApatelets(ctx context.Context, cfg *nodeconfigv1.NodeConfiguration) error {
+	err := a.getDesiredApatelets(ctx, cfg)
+
+	a.trackConfiguration(cfg, err)
+	if statusErr := a.UpdateStatus(nodev1.GetCrdLabel(cfg)); statusErr != nil {
+		log.Printf("error while updating node configuration status: %v\n", statusErr)
+	}
+
+	return err
+}
+
+func (a *apateletHandler) getDesiredApatelets(ctx context.Context, cfg *nodeconfigv1.NodeConfiguration) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
@@ -157,6 +192,12 @@ func (a *apateletHandler) StopApatelets(ctx context.Context, desired int64, labe
 	// Delete apatelets from kubernetes and apate
 	ids := make([]uuid.UUID, 0, diff)
 
+	// Stop the apatelets with the highest indices, so the indices of the remaining apatelets stay the same
+	nodes = append([]store.Node(nil), nodes...)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Index > nodes[j].Index
+	})
+
 	// Send them the signal to stop
 	for i, node := range nodes {
 		if i >= diff {
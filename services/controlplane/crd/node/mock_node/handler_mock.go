@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/atlarge-research/apate/services/controlplane/crd/node (interfaces: ApateletHandler)
+
+// Package mock_node is a generated GoMock package.
+package mock_node
+
+import (
+	context "context"
+	v1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
+	scenario "github.com/atlarge-research/apate/pkg/scenario"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockApateletHandler is a mock of ApateletHandler interface
+type MockApateletHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockApateletHandlerMockRecorder
+}
+
+// MockApateletHandlerMockRecorder is the mock recorder for MockApateletHandler
+type MockApateletHandlerMockRecorder struct {
+	mock *MockApateletHandler
+}
+
+// NewMockApateletHandler creates a new mock instance
+func NewMockApateletHandler(ctrl *gomock.Controller) *MockApateletHandler {
+	mock := &MockApateletHandler{ctrl: ctrl}
+	mock.recorder = &MockApateletHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockApateletHandler) EXPECT() *MockApateletHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetDesiredApatelets mocks base method
+func (m *MockApateletHandler) GetDesiredApatelets(arg0 context.Context, arg1 *v1.NodeConfiguration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDesiredApatelets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDesiredApatelets indicates an expected call of GetDesiredApatelets
+func (mr *MockApateletHandlerMockRecorder) GetDesiredApatelets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDesiredApatelets", reflect.TypeOf((*MockApateletHandler)(nil).GetDesiredApatelets), arg0, arg1)
+}
+
+// SpawnApatelets mocks base method
+func (m *MockApateletHandler) SpawnApatelets(arg0 context.Context, arg1 int64, arg2 scenario.NodeResources, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpawnApatelets", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SpawnApatelets indicates an expected call of SpawnApatelets
+func (mr *MockApateletHandlerMockRecorder) SpawnApatelets(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpawnApatelets", reflect.TypeOf((*MockApateletHandler)(nil).SpawnApatelets), arg0, arg1, arg2, arg3)
+}
+
+// StopApatelets mocks base method
+func (m *MockApateletHandler) StopApatelets(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopApatelets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopApatelets indicates an expected call of StopApatelets
+func (mr *MockApateletHandlerMockRecorder) StopApatelets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApatelets", reflect.TypeOf((*MockApateletHandler)(nil).StopApatelets), arg0, arg1, arg2)
+}
+
+// UpdateStatus mocks base method
+func (m *MockApateletHandler) UpdateStatus(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockApateletHandlerMockRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockApateletHandler)(nil).UpdateStatus), arg0)
+}
+
+// UpdateStatuses mocks base method
+func (m *MockApateletHandler) UpdateStatuses() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatuses")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatuses indicates an expected call of UpdateStatuses
+func (mr *MockApateletHandlerMockRecorder) UpdateStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatuses", reflect.TypeOf((*MockApateletHandler)(nil).UpdateStatuses))
+}
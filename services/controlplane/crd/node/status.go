@@ -0,0 +1,181 @@
+package node
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/atlarge-research/apate/api/health"
+	nodev1 "github.com/atlarge-research/apate/internal/crd/node"
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+)
+
+// configurationState is what the control plane knows about a node configuration, besides the apatelets in the store
+type configurationState struct {
+	namespace string
+	name      string
+
+	desired            int64
+	observedGeneration int64
+	lastError          string
+}
+
+// trackConfiguration remembers the given node configuration and the result of handling it
+func (a *apateletHandler) trackConfiguration(cfg *nodeconfigv1.NodeConfiguration, handleErr error) {
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+
+	state := configurationState{
+		namespace:          cfg.Namespace,
+		name:               cfg.Name,
+		desired:            cfg.Spec.Replicas,
+		observedGeneration: cfg.Generation,
+	}
+
+	if handleErr != nil {
+		state.lastError = handleErr.Error()
+	}
+
+	a.configurations[nodev1.GetCrdLabel(cfg)] = state
+}
+
+func (a *apateletHandler) UpdateStatuses() error {
+	a.statusLock.Lock()
+	labels := make([]string, 0, len(a.configurations))
+	for label := range a.configurations {
+		labels = append(labels, label)
+	}
+	a.statusLock.Unlock()
+
+	var err error
+	for _, label := range labels {
+		if updateErr := a.UpdateStatus(label); updateErr != nil {
+			log.Printf("error while updating status of %v: %v\n", label, updateErr)
+			err = updateErr
+		}
+	}
+
+	return errors.Wrap(err, "failed to update the status of all node configurations")
+}
+
+func (a *apateletHandler) UpdateStatus(label string) error {
+	a.statusLock.Lock()
+	state, ok := a.configurations[label]
+	a.statusLock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	nodes, err := (*a.store).GetNodesByLabel(label)
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve nodes with label %v", label)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := a.client.Get(state.namespace, state.name)
+		if err != nil {
+			return errors.Cause(err)
+		}
+
+		status := newStatus(&current.Status, state, nodes)
+		if statusEqual(&current.Status, &status) {
+			return nil
+		}
+
+		current.Status = status
+		_, err = a.client.UpdateStatus(current)
+		return errors.Cause(err)
+	})
+
+	if apierrors.IsNotFound(err) {
+		// The node configuration was deleted, so there is nothing left to keep up to date
+		a.statusLock.Lock()
+		delete(a.configurations, label)
+		a.statusLock.Unlock()
+		return nil
+	}
+
+	return errors.Wrapf(err, "failed to update status of %v", label)
+}
+
+// newStatus creates the status of a node configuration, based on its previous status and the apatelets in the store
+func newStatus(previous *nodeconfigv1.NodeConfigurationStatus, state configurationState, nodes []store.Node) nodeconfigv1.NodeConfigurationStatus {
+	status := nodeconfigv1.NodeConfigurationStatus{
+		Replicas:           int64(len(nodes)),
+		ObservedGeneration: state.observedGeneration,
+		LastError:          state.lastError,
+	}
+
+	for _, node := range nodes {
+		switch node.Status {
+		case health.Status_HEALTHY:
+			status.ReadyReplicas++
+		case health.Status_UNHEALTHY:
+			status.UnhealthyReplicas++
+		}
+	}
+
+	available := status.ReadyReplicas == state.desired && status.Replicas == state.desired
+	availableCondition := newCondition(previous, nodeconfigv1.NodeConfigurationAvailable, available)
+	if available {
+		availableCondition.Reason = "ReplicasReady"
+		availableCondition.Message = "All desired apatelets have joined and are healthy"
+	} else {
+		availableCondition.Reason = "ReplicasNotReady"
+		availableCondition.Message = "Not all desired apatelets have joined or are healthy"
+	}
+
+	failureCondition := newCondition(previous, nodeconfigv1.NodeConfigurationReplicaFailure, state.lastError != "")
+	if state.lastError != "" {
+		failureCondition.Reason = "HandlingFailed"
+		failureCondition.Message = state.lastError
+	}
+
+	status.Conditions = []nodeconfigv1.NodeConfigurationCondition{availableCondition, failureCondition}
+	return status
+}
+
+// newCondition creates a condition of the given type, keeping the transition time of the previous status if unchanged
+func newCondition(previous *nodeconfigv1.NodeConfigurationStatus, conditionType nodeconfigv1.NodeConfigurationConditionType, value bool) nodeconfigv1.NodeConfigurationCondition {
+	conditionStatus := corev1.ConditionFalse
+	if value {
+		conditionStatus = corev1.ConditionTrue
+	}
+
+	condition := nodeconfigv1.NodeConfigurationCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	for _, old := range previous.Conditions {
+		if old.Type == conditionType && old.Status == conditionStatus {
+			condition.LastTransitionTime = old.LastTransitionTime
+		}
+	}
+
+	return condition
+}
+
+// statusEqual returns true if both statuses are the same, ignoring condition timestamps
+func statusEqual(a, b *nodeconfigv1.NodeConfigurationStatus) bool {
+	if a.Replicas != b.Replicas || a.ReadyReplicas != b.ReadyReplicas || a.UnhealthyReplicas != b.UnhealthyReplicas ||
+		a.ObservedGeneration != b.ObservedGeneration || a.LastError != b.LastError || len(a.Conditions) != len(b.Conditions) {
+		return false
+	}
+
+	for i := range a.Conditions {
+		ca, cb := a.Conditions[i], b.Conditions[i]
+		if ca.Type != cb.Type || ca.Status != cb.Status || ca.Reason != cb.Reason || ca.Message != cb.Message {
+			return false
+		}
+	}
+
+	return true
+}
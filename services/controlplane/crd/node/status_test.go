@@ -0,0 +1,104 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlarge-research/apate/api/health"
+	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+)
+
+func TestNewStatusCounts(t *testing.T) {
+	t.Parallel()
+
+	nodes := []store.Node{
+		{Status: health.Status_HEALTHY},
+		{Status: health.Status_HEALTHY},
+		{Status: health.Status_UNHEALTHY},
+		{Status: health.Status_UNKNOWN},
+	}
+
+	status := newStatus(&nodeconfigv1.NodeConfigurationStatus{}, configurationState{desired: 4, observedGeneration: 3}, nodes)
+
+	assert.Equal(t, int64(4), status.Replicas)
+	assert.Equal(t, int64(2), status.ReadyReplicas)
+	assert.Equal(t, int64(1), status.UnhealthyReplicas)
+	assert.Equal(t, int64(3), status.ObservedGeneration)
+	assert.Equal(t, "", status.LastError)
+
+	assert.Len(t, status.Conditions, 2)
+	assert.Equal(t, nodeconfigv1.NodeConfigurationAvailable, status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, nodeconfigv1.NodeConfigurationReplicaFailure, status.Conditions[1].Type)
+	assert.Equal(t, corev1.ConditionFalse, status.Conditions[1].Status)
+}
+
+func TestNewStatusAvailable(t *testing.T) {
+	t.Parallel()
+
+	nodes := []store.Node{
+		{Status: health.Status_HEALTHY},
+		{Status: health.Status_HEALTHY},
+	}
+
+	status := newStatus(&nodeconfigv1.NodeConfigurationStatus{}, configurationState{desired: 2}, nodes)
+
+	assert.Equal(t, corev1.ConditionTrue, status.Conditions[0].Status)
+}
+
+func TestNewStatusLastError(t *testing.T) {
+	t.Parallel()
+
+	status := newStatus(&nodeconfigv1.NodeConfigurationStatus{}, configurationState{desired: 1, lastError: "failed"}, []store.Node{})
+
+	assert.Equal(t, "failed", status.LastError)
+	assert.Equal(t, corev1.ConditionTrue, status.Conditions[1].Status)
+	assert.Equal(t, "failed", status.Conditions[1].Message)
+}
+
+func TestNewStatusKeepsTransitionTime(t *testing.T) {
+	t.Parallel()
+
+	transition := metav1.NewTime(time.Unix(42, 0))
+	previous := nodeconfigv1.NodeConfigurationStatus{
+		Conditions: []nodeconfigv1.NodeConfigurationCondition{
+			{
+				Type:               nodeconfigv1.NodeConfigurationAvailable,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: transition,
+			},
+			{
+				Type:               nodeconfigv1.NodeConfigurationReplicaFailure,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: transition,
+			},
+		},
+	}
+
+	status := newStatus(&previous, configurationState{desired: 1}, []store.Node{{Status: health.Status_HEALTHY}})
+
+	// Available did not change, so the transition time should be kept
+	assert.Equal(t, transition, status.Conditions[0].LastTransitionTime)
+
+	// Replica failure changed, so the transition time should be updated
+	assert.NotEqual(t, transition, status.Conditions[1].LastTransitionTime)
+}
+
+func TestStatusEqual(t *testing.T) {
+	t.Parallel()
+
+	a := newStatus(&nodeconfigv1.NodeConfigurationStatus{}, configurationState{desired: 1}, []store.Node{{Status: health.Status_HEALTHY}})
+	b := a.DeepCopy()
+	b.Conditions[0].LastTransitionTime = metav1.NewTime(time.Unix(42, 0))
+
+	// Timestamps are ignored
+	assert.True(t, statusEqual(&a, b))
+
+	b.ReadyReplicas = 0
+	assert.False(t, statusEqual(&a, b))
+}
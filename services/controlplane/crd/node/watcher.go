@@ -31,7 +31,12 @@ func WatchHandler(ctx context.Context, config *kubeconfig.KubeConfig, handler *A
 				log.Printf("error while starting apatelets: %v\n", err)
 			}
 		}()
-	}, func(_, obj interface{}) {
+	}, func(oldObj, obj interface{}) {
+		if node.IsStatusUpdate(oldObj.(*nodeconfigv1.NodeConfiguration), obj.(*nodeconfigv1.NodeConfiguration)) {
+			// Only the status changed (most likely by us), so there is nothing to do
+			return
+		}
+
 		go func() {
 			log.Println("Received updated node CRD on controlplane")
 
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/atlarge-research/apate/services/controlplane/crd/pod (interfaces: StatusHandler)
+
+// Package mock_pod is a generated GoMock package.
+package mock_pod
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStatusHandler is a mock of StatusHandler interface
+type MockStatusHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusHandlerMockRecorder
+}
+
+// MockStatusHandlerMockRecorder is the mock recorder for MockStatusHandler
+type MockStatusHandlerMockRecorder struct {
+	mock *MockStatusHandler
+}
+
+// NewMockStatusHandler creates a new mock instance
+func NewMockStatusHandler(ctrl *gomock.Controller) *MockStatusHandler {
+	mock := &MockStatusHandler{ctrl: ctrl}
+	mock.recorder = &MockStatusHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStatusHandler) EXPECT() *MockStatusHandlerMockRecorder {
+	return m.recorder
+}
+
+// UpdateStatuses mocks base method
+func (m *MockStatusHandler) UpdateStatuses() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatuses")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatuses indicates an expected call of UpdateStatuses
+func (mr *MockStatusHandlerMockRecorder) UpdateStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatuses", reflect.TypeOf((*MockStatusHandler)(nil).UpdateStatuses))
+}
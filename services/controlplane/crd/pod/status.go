@@ -0,0 +1,115 @@
+package pod
+
+import (
+	"log"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+	"github.com/atlarge-research/apate/internal/crd/pod"
+	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
+	"github.com/atlarge-research/apate/pkg/kubernetes/kubeconfig"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+)
+
+// StatusHandler keeps the status of the pod configurations up to date
+type StatusHandler interface {
+	// Updates the status of all pod configurations based on the statuses reported by the apatelets
+	UpdateStatuses() error
+}
+
+type statusHandler struct {
+	store  *store.Store
+	client *pod.ConfigurationClient
+}
+
+// NewStatusHandler creates a new StatusHandler
+func NewStatusHandler(st *store.Store, config *kubeconfig.KubeConfig) (*StatusHandler, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get kubeconfig for pod status handler")
+	}
+
+	client, err := pod.NewForConfig(cfg, "default")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create pod client from config for pod status handler")
+	}
+
+	var handler StatusHandler = &statusHandler{
+		store:  st,
+		client: client,
+	}
+
+	return &handler, nil
+}
+
+func (s *statusHandler) UpdateStatuses() error {
+	reports, err := (*s.store).GetPodStatuses()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve pod statuses")
+	}
+
+	cfgs, err := s.client.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list pod configurations")
+	}
+
+	for i := range cfgs.Items {
+		cfg := &cfgs.Items[i]
+
+		status := newStatus(cfg, reports[pod.GetCrdLabel(cfg)])
+		if equality.Semantic.DeepEqual(cfg.Status, status) {
+			continue
+		}
+
+		cfg.Status = status
+		if _, updateErr := s.client.UpdateStatus(cfg); updateErr != nil {
+			if apierrors.IsConflict(errors.Cause(updateErr)) {
+				// The pod configuration changed in the meantime, it will be updated in the next round
+				continue
+			}
+
+			log.Printf("error while updating status of %v: %v\n", pod.GetCrdLabel(cfg), updateErr)
+			err = updateErr
+		}
+	}
+
+	return errors.Wrap(err, "failed to update the status of all pod configurations")
+}
+
+// newStatus creates the status of a pod configuration by aggregating the statuses reported by the apatelets
+func newStatus(cfg *podconfigv1.PodConfiguration, reports []*controlplane.PodConfigurationStatus) podconfigv1.PodConfigurationStatus {
+	status := podconfigv1.PodConfigurationStatus{}
+
+	fired := make(map[int]int64)
+	errs := make(map[string]struct{})
+	for _, report := range reports {
+		status.MatchedPods += report.MatchedPods
+
+		for _, task := range report.Tasks {
+			fired[int(task.Index)] += task.FiredPods
+		}
+
+		if report.Error != "" {
+			errs[report.Error] = struct{}{}
+		}
+	}
+
+	for i, task := range cfg.Spec.Tasks {
+		status.Tasks = append(status.Tasks, podconfigv1.PodConfigurationTaskStatus{
+			Index:     i,
+			Timestamp: task.Timestamp,
+			FiredPods: fired[i],
+		})
+	}
+
+	for err := range errs {
+		status.Errors = append(status.Errors, err)
+	}
+	sort.Strings(status.Errors)
+
+	return status
+}
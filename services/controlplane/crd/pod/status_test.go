@@ -0,0 +1,65 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
+)
+
+func TestNewStatusAggregates(t *testing.T) {
+	t.Parallel()
+
+	cfg := podconfigv1.PodConfiguration{
+		Spec: podconfigv1.PodConfigurationSpec{
+			Tasks: []podconfigv1.PodConfigurationTask{
+				{Timestamp: "1s"},
+				{Timestamp: "10s", RelativeToPod: true},
+				{Timestamp: "1m"},
+			},
+		},
+	}
+
+	reports := []*controlplane.PodConfigurationStatus{
+		{
+			MatchedPods: 2,
+			Tasks: []*controlplane.TaskStatus{
+				{Index: 0, FiredPods: 2},
+				{Index: 1, FiredPods: 1},
+			},
+		},
+		{
+			MatchedPods: 3,
+			Tasks: []*controlplane.TaskStatus{
+				{Index: 0, FiredPods: 3},
+			},
+			Error: "b",
+		},
+		{
+			Error: "a",
+		},
+		{
+			Error: "b",
+		},
+	}
+
+	status := newStatus(&cfg, reports)
+
+	assert.Equal(t, int64(5), status.MatchedPods)
+	assert.Equal(t, []podconfigv1.PodConfigurationTaskStatus{
+		{Index: 0, Timestamp: "1s", FiredPods: 5},
+		{Index: 1, Timestamp: "10s", FiredPods: 1},
+		{Index: 2, Timestamp: "1m", FiredPods: 0},
+	}, status.Tasks)
+	assert.Equal(t, []string{"a", "b"}, status.Errors)
+}
+
+func TestNewStatusNoReports(t *testing.T) {
+	t.Parallel()
+
+	status := newStatus(&podconfigv1.PodConfiguration{}, nil)
+
+	assert.Equal(t, podconfigv1.PodConfigurationStatus{}, status)
+}
@@ -0,0 +1,308 @@
+// Package scenario starts Scenario resources on the control plane and keeps their status up to date
+package scenario
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiApatelet "github.com/atlarge-research/apate/api/apatelet"
+	"github.com/atlarge-research/apate/api/health"
+	"github.com/atlarge-research/apate/internal/crd/node"
+	"github.com/atlarge-research/apate/internal/crd/pod"
+	"github.com/atlarge-research/apate/internal/crd/scenario"
+	scenariov1 "github.com/atlarge-research/apate/pkg/apis/scenario/v1"
+	"github.com/atlarge-research/apate/pkg/channel"
+	"github.com/atlarge-research/apate/pkg/kubernetes/kubeconfig"
+	"github.com/atlarge-research/apate/services/controlplane/services"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+)
+
+// Handler starts scenarios and keeps their status up to date
+type Handler interface {
+	// Reconcile starts the pending scenario which is ready to start, if any, and updates the status of all scenarios
+	Reconcile(ctx context.Context) error
+}
+
+type handler struct {
+	store          *store.Store
+	stopInformerCh *channel.StopChannel
+
+	client     *scenario.Client
+	nodeClient *node.ConfigurationClient
+	podClient  *pod.ConfigurationClient
+}
+
+// readiness describes whether the configurations referenced by a scenario are ready for it to start
+type readiness struct {
+	// The configurations which don't exist (yet)
+	missing []string
+
+	// The node configurations of which not all apatelets have joined or are healthy
+	notReady []string
+}
+
+// NewHandler creates a new Handler
+// If a started scenario disables the watchers, the given stop channel is closed
+func NewHandler(st *store.Store, config *kubeconfig.KubeConfig, stopInformerCh *channel.StopChannel) (*Handler, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get kubeconfig for scenario handler")
+	}
+
+	client, err := scenario.NewForConfig(cfg, "default")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create scenario client from config for scenario handler")
+	}
+
+	nodeClient, err := node.NewForConfig(cfg, "default")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create node client from config for scenario handler")
+	}
+
+	podClient, err := pod.NewForConfig(cfg, "default")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create pod client from config for scenario handler")
+	}
+
+	var h Handler = &handler{
+		store:          st,
+		stopInformerCh: stopInformerCh,
+		client:         client,
+		nodeClient:     nodeClient,
+		podClient:      podClient,
+	}
+
+	return &h, nil
+}
+
+// StartReconciler reconciles the scenarios using the given handler every delay, until the context is cancelled
+// Scenarios are listed every time instead of watched, so disabling the watchers doesn't stop the reconciler
+func StartReconciler(ctx context.Context, delay time.Duration, h *Handler) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+				if err := (*h).Reconcile(ctx); err != nil {
+					log.Printf("unable to reconcile scenarios: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (h *handler) Reconcile(ctx context.Context) error {
+	scenarios, err := h.client.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list scenarios")
+	}
+
+	// The oldest scenario gets to start first
+	items := scenarios.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreationTimestamp.Before(&items[j].CreationTimestamp)
+	})
+
+	started, startedErr := (*h.store).GetApateletScenario()
+	if startedErr != nil {
+		started = nil
+	}
+
+	claimed := false
+	for i := range items {
+		if phase := items[i].Status.Phase; phase == scenariov1.ScenarioRunning || phase == scenariov1.ScenarioFinished {
+			claimed = true
+		}
+	}
+
+	for i := range items {
+		sc := &items[i]
+
+		var ready readiness
+		if isPending(sc) && started == nil {
+			var readyErr error
+			if ready, readyErr = h.checkReadiness(sc); readyErr != nil {
+				log.Printf("error while checking whether %v is ready: %v\n", scenario.GetCrdLabel(sc), readyErr)
+				err = readyErr
+				continue
+			}
+		}
+
+		status, start := nextStatus(sc, started, claimed, ready, time.Now())
+		if start {
+			apateletScenario, startErr := services.StartScenario(ctx, h.store, h.stopInformerCh, sc.Spec.DisableWatchers)
+			if startErr != nil {
+				log.Printf("error while starting %v: %v\n", scenario.GetCrdLabel(sc), startErr)
+				status = scenariov1.ScenarioStatus{
+					Phase:   scenariov1.ScenarioFailed,
+					Message: startErr.Error(),
+				}
+			} else {
+				started = apateletScenario
+				status = runningStatus(started)
+			}
+		}
+
+		if status.Phase == scenariov1.ScenarioRunning {
+			claimed = true
+		}
+
+		if updateErr := h.updateStatus(sc, status); updateErr != nil {
+			log.Printf("error while updating status of %v: %v\n", scenario.GetCrdLabel(sc), updateErr)
+			err = updateErr
+		}
+	}
+
+	return errors.Wrap(err, "failed to reconcile all scenarios")
+}
+
+// checkReadiness retrieves the configurations referenced by the scenario and checks whether they are ready
+func (h *handler) checkReadiness(sc *scenariov1.Scenario) (readiness, error) {
+	var ready readiness
+
+	for _, name := range sc.Spec.NodeConfigurations {
+		cfg, err := h.nodeClient.Get(sc.Namespace, name)
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			ready.missing = append(ready.missing, "node configuration "+name)
+			continue
+		} else if err != nil {
+			return readiness{}, errors.Wrapf(err, "failed to get node configuration %v", name)
+		}
+
+		nodes, err := (*h.store).GetNodesByLabel(node.GetCrdLabel(cfg))
+		if err != nil {
+			return readiness{}, errors.Wrapf(err, "failed to retrieve nodes of node configuration %v", name)
+		}
+
+		var healthy int64
+		for _, n := range nodes {
+			if n.Status == health.Status_HEALTHY {
+				healthy++
+			}
+		}
+
+		if healthy < cfg.Spec.Replicas {
+			ready.notReady = append(ready.notReady, name)
+		}
+	}
+
+	for _, name := range sc.Spec.PodConfigurations {
+		_, err := h.podClient.Get(sc.Namespace, name)
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			ready.missing = append(ready.missing, "pod configuration "+name)
+		} else if err != nil {
+			return readiness{}, errors.Wrapf(err, "failed to get pod configuration %v", name)
+		}
+	}
+
+	return ready, nil
+}
+
+// updateStatus writes the given status to kubernetes if it differs from the current one
+func (h *handler) updateStatus(sc *scenariov1.Scenario, status scenariov1.ScenarioStatus) error {
+	if equality.Semantic.DeepEqual(sc.Status, status) {
+		return nil
+	}
+
+	sc.Status = status
+	_, err := h.client.UpdateStatus(sc)
+	if apierrors.IsConflict(errors.Cause(err)) || apierrors.IsNotFound(errors.Cause(err)) {
+		// The scenario changed or was deleted in the meantime, it will be handled in the next round if it still exists
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to update scenario status")
+}
+
+// nextStatus determines the status of the scenario, and whether it should be started now
+// Started is the scenario which has already been started on the apatelets, if any, and claimed tells whether
+// another scenario resource already belongs to it
+func nextStatus(sc *scenariov1.Scenario, started *apiApatelet.ApateletScenario, claimed bool, ready readiness, now time.Time) (scenariov1.ScenarioStatus, bool) {
+	duration, err := parseDuration(sc.Spec.Duration)
+
+	switch sc.Status.Phase {
+	case scenariov1.ScenarioFinished, scenariov1.ScenarioFailed:
+		return sc.Status, false
+	case scenariov1.ScenarioRunning:
+		if err == nil && duration > 0 && sc.Status.StartTime != nil && !now.Before(sc.Status.StartTime.Add(duration)) {
+			return scenariov1.ScenarioStatus{
+				Phase:     scenariov1.ScenarioFinished,
+				StartTime: sc.Status.StartTime,
+				Message:   "The duration of the scenario has passed",
+			}, false
+		}
+		return sc.Status, false
+	}
+
+	if err != nil {
+		return scenariov1.ScenarioStatus{Phase: scenariov1.ScenarioFailed, Message: err.Error()}, false
+	}
+
+	if started != nil {
+		if sc.Spec.StartPolicy == scenariov1.StartManual && !claimed {
+			return runningStatus(started), false
+		}
+
+		return scenariov1.ScenarioStatus{
+			Phase:   scenariov1.ScenarioFailed,
+			Message: "Another scenario has already been started",
+		}, false
+	}
+
+	pending := func(message string) scenariov1.ScenarioStatus {
+		return scenariov1.ScenarioStatus{Phase: scenariov1.ScenarioPending, Message: message}
+	}
+
+	if len(ready.missing) > 0 {
+		return pending("Waiting for " + strings.Join(ready.missing, ", ") + " to be created"), false
+	}
+
+	switch sc.Spec.StartPolicy {
+	case scenariov1.StartManual:
+		return pending("Waiting for the scenario to be started using apate-cli run"), false
+	case scenariov1.StartImmediate:
+		return pending(""), true
+	default:
+		if len(ready.notReady) > 0 {
+			return pending("Waiting for the apatelets of node configuration " + strings.Join(ready.notReady, ", ") + " to be ready"), false
+		}
+		return pending(""), true
+	}
+}
+
+// runningStatus creates the status of a scenario which has been started on the apatelets
+func runningStatus(started *apiApatelet.ApateletScenario) scenariov1.ScenarioStatus {
+	startTime := metav1.NewTime(time.Unix(0, started.StartTime))
+	return scenariov1.ScenarioStatus{
+		Phase:     scenariov1.ScenarioRunning,
+		StartTime: &startTime,
+	}
+}
+
+func isPending(sc *scenariov1.Scenario) bool {
+	return sc.Status.Phase == "" || sc.Status.Phase == scenariov1.ScenarioPending
+}
+
+func parseDuration(input string) (time.Duration, error) {
+	if input == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid duration %v", input)
+	} else if duration <= 0 {
+		return 0, errors.Errorf("duration %v should be positive", input)
+	}
+
+	return duration, nil
+}
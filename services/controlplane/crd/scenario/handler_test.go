@@ -0,0 +1,114 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiApatelet "github.com/atlarge-research/apate/api/apatelet"
+	scenariov1 "github.com/atlarge-research/apate/pkg/apis/scenario/v1"
+)
+
+func createScenario(policy scenariov1.ScenarioStartPolicy, duration string) *scenariov1.Scenario {
+	return &scenariov1.Scenario{
+		Spec: scenariov1.ScenarioSpec{
+			NodeConfigurations: []string{"nodes"},
+			PodConfigurations:  []string{"pods"},
+			StartPolicy:        policy,
+			Duration:           duration,
+		},
+	}
+}
+
+func TestNextStatusWaitsForConfigurations(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []scenariov1.ScenarioStartPolicy{scenariov1.StartImmediate, scenariov1.StartWhenReady, scenariov1.StartManual} {
+		status, start := nextStatus(createScenario(policy, ""), nil, false, readiness{missing: []string{"pod configuration pods"}}, time.Now())
+
+		assert.False(t, start)
+		assert.Equal(t, scenariov1.ScenarioPending, status.Phase)
+		assert.Equal(t, "Waiting for pod configuration pods to be created", status.Message)
+	}
+}
+
+func TestNextStatusStartPolicies(t *testing.T) {
+	t.Parallel()
+
+	notReady := readiness{notReady: []string{"nodes"}}
+
+	// Immediate starts even though not all apatelets are ready
+	status, start := nextStatus(createScenario(scenariov1.StartImmediate, ""), nil, false, notReady, time.Now())
+	assert.True(t, start)
+	assert.Equal(t, scenariov1.ScenarioPending, status.Phase)
+
+	// When ready waits for all apatelets
+	status, start = nextStatus(createScenario(scenariov1.StartWhenReady, ""), nil, false, notReady, time.Now())
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioPending, status.Phase)
+	assert.Equal(t, "Waiting for the apatelets of node configuration nodes to be ready", status.Message)
+
+	_, start = nextStatus(createScenario(scenariov1.StartWhenReady, ""), nil, false, readiness{}, time.Now())
+	assert.True(t, start)
+
+	// Manual never starts by itself
+	status, start = nextStatus(createScenario(scenariov1.StartManual, ""), nil, false, readiness{}, time.Now())
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioPending, status.Phase)
+}
+
+func TestNextStatusAlreadyStarted(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Now().Add(time.Minute)
+	started := &apiApatelet.ApateletScenario{StartTime: startTime.UnixNano()}
+
+	// A manual scenario is adopted if it is the first to claim the started scenario
+	status, start := nextStatus(createScenario(scenariov1.StartManual, ""), started, false, readiness{}, time.Now())
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioRunning, status.Phase)
+	assert.Equal(t, startTime.UnixNano(), status.StartTime.UnixNano())
+
+	status, start = nextStatus(createScenario(scenariov1.StartManual, ""), started, true, readiness{}, time.Now())
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioFailed, status.Phase)
+
+	status, start = nextStatus(createScenario(scenariov1.StartImmediate, ""), started, false, readiness{}, time.Now())
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioFailed, status.Phase)
+}
+
+func TestNextStatusDuration(t *testing.T) {
+	t.Parallel()
+
+	sc := createScenario(scenariov1.StartImmediate, "1m")
+	startTime := metav1.NewTime(time.Now())
+	sc.Status = scenariov1.ScenarioStatus{Phase: scenariov1.ScenarioRunning, StartTime: &startTime}
+
+	status, start := nextStatus(sc, nil, true, readiness{}, startTime.Add(30*time.Second))
+	assert.False(t, start)
+	assert.Equal(t, sc.Status, status)
+
+	status, start = nextStatus(sc, nil, true, readiness{}, startTime.Add(time.Minute))
+	assert.False(t, start)
+	assert.Equal(t, scenariov1.ScenarioFinished, status.Phase)
+	assert.Equal(t, &startTime, status.StartTime)
+
+	// Finished scenarios stay finished
+	sc.Status = status
+	status, _ = nextStatus(sc, nil, true, readiness{}, startTime.Add(time.Hour))
+	assert.Equal(t, scenariov1.ScenarioFinished, status.Phase)
+}
+
+func TestNextStatusInvalidDuration(t *testing.T) {
+	t.Parallel()
+
+	for _, duration := range []string{"soon", "-1s"} {
+		status, start := nextStatus(createScenario(scenariov1.StartImmediate, duration), nil, false, readiness{}, time.Now())
+
+		assert.False(t, start)
+		assert.Equal(t, scenariov1.ScenarioFailed, status.Phase)
+	}
+}
@@ -21,11 +21,13 @@ import (
 	"github.com/atlarge-research/apate/internal/service"
 	nodeconfigv1 "github.com/atlarge-research/apate/pkg/apis/nodeconfiguration/v1"
 	podconfigv1 "github.com/atlarge-research/apate/pkg/apis/podconfiguration/v1"
+	scenariov1 "github.com/atlarge-research/apate/pkg/apis/scenario/v1"
 	"github.com/atlarge-research/apate/pkg/env"
 	"github.com/atlarge-research/apate/pkg/kubernetes"
 	"github.com/atlarge-research/apate/pkg/runner"
 	"github.com/atlarge-research/apate/services/controlplane/cluster/watchdog"
 	"github.com/atlarge-research/apate/services/controlplane/crd/node"
+	"github.com/atlarge-research/apate/services/controlplane/crd/scenario"
 	"github.com/atlarge-research/apate/services/controlplane/services"
 	"github.com/atlarge-research/apate/services/controlplane/store"
 
@@ -84,7 +86,11 @@ func StartControlPlaneWithStopCh(ctx context.Context, registry *runner.Registry,
 
 	// Create node informer
 	stopInformer := channel.NewStopChannel()
-	nodeHandler := node.NewHandler(&createdStore, registry, externalInformation, cluster)
+	nodeHandler, err := node.NewHandler(&createdStore, registry, externalInformation, cluster)
+	if err != nil {
+		panicf(errors.Wrap(err, "failed to create node handler"))
+	}
+
 	if err = node.WatchHandler(ctx, cluster.KubeConfig, nodeHandler, stopInformer.GetChannel()); err != nil {
 		panicf(errors.Wrap(err, "failed to watch node handler"))
 	}
@@ -93,6 +99,16 @@ func StartControlPlaneWithStopCh(ctx context.Context, registry *runner.Registry,
 		panicf(errors.Wrap(err, "failed to watch pod handler"))
 	}
 
+	podStatusHandler, err := pod.NewStatusHandler(&createdStore, cluster.KubeConfig)
+	if err != nil {
+		panicf(errors.Wrap(err, "failed to create pod status handler"))
+	}
+
+	scenarioHandler, err := scenario.NewHandler(&createdStore, cluster.KubeConfig, stopInformer)
+	if err != nil {
+		panicf(errors.Wrap(err, "failed to create scenario handler"))
+	}
+
 	// Create prometheus stack
 	createPrometheus := cpEnv.PrometheusEnabled
 	if createPrometheus {
@@ -100,7 +116,7 @@ func StartControlPlaneWithStopCh(ctx context.Context, registry *runner.Registry,
 	}
 
 	// Start gRPC server
-	server, err := createGRPC(&createdStore, cluster, externalInformation, stopInformer)
+	server, err := createGRPC(&createdStore, cluster, nodeHandler, externalInformation, stopInformer)
 	if err != nil {
 		panicf(errors.Wrap(err, "failed to start GRPC server"))
 	}
@@ -126,7 +142,10 @@ func StartControlPlaneWithStopCh(ctx context.Context, registry *runner.Registry,
 	}()
 
 	// Start watchdog
-	watchdog.StartWatchDog(ctx, time.Second*30, &createdStore, &clusterAPI)
+	watchdog.StartWatchDog(ctx, time.Second*30, &createdStore, &clusterAPI, nodeHandler, podStatusHandler)
+
+	// Start scenarios declared using the Scenario CRD
+	scenario.StartReconciler(ctx, time.Second*5, scenarioHandler)
 
 	// Stop the server on signal
 	select {
@@ -149,6 +168,10 @@ func createCRDs(cluster *kubernetes.Cluster) error {
 		return errors.Wrap(err, "failed to register node CRD spec")
 	}
 
+	if err := scenariov1.UpdateInKubernetes(cluster.KubeConfig, false); err != nil {
+		return errors.Wrap(err, "failed to register scenario CRD spec")
+	}
+
 	return nil
 }
 
@@ -192,7 +215,8 @@ func getExternalAddress() (string, error) {
 	return res, nil
 }
 
-func createGRPC(createdStore *store.Store, kubernetesCluster *kubernetes.Cluster, info *service.ConnectionInfo, stopInformerCh *channel.StopChannel) (*service.GRPCServer, error) {
+func createGRPC(createdStore *store.Store, kubernetesCluster *kubernetes.Cluster, nodeHandler *node.ApateletHandler, info *service.ConnectionInfo,
+	stopInformerCh *channel.StopChannel) (*service.GRPCServer, error) {
 	// Retrieve from environment
 	listenAddress := env.ControlPlaneEnv().ListenAddress
 
@@ -208,8 +232,9 @@ func createGRPC(createdStore *store.Store, kubernetesCluster *kubernetes.Cluster
 	// Add services
 	services.RegisterStatusService(server, createdStore)
 	services.RegisterScenarioService(server, createdStore, info, stopInformerCh)
-	services.RegisterClusterOperationService(server, createdStore, kubernetesCluster)
+	services.RegisterClusterOperationService(server, createdStore, kubernetesCluster, nodeHandler)
 	services.RegisterHealthService(server, createdStore)
+	services.RegisterPodStatusService(server, createdStore)
 
 	return server, nil
 }
@@ -20,19 +20,23 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/atlarge-research/apate/internal/service"
+	"github.com/atlarge-research/apate/services/controlplane/crd/node"
 	"github.com/atlarge-research/apate/services/controlplane/store"
 )
 
 type clusterOperationService struct {
 	store             *store.Store
 	kubernetesCluster *kubernetes.Cluster
+	nodeHandler       *node.ApateletHandler
 }
 
 // RegisterClusterOperationService registers a new clusterOperationService with the given gRPC server
-func RegisterClusterOperationService(server *service.GRPCServer, store *store.Store, kubernetesCluster *kubernetes.Cluster) {
+// The node handler is used to update the status of node configurations when apatelets join or leave
+func RegisterClusterOperationService(server *service.GRPCServer, store *store.Store, kubernetesCluster *kubernetes.Cluster, nodeHandler *node.ApateletHandler) {
 	controlplane.RegisterClusterOperationsServer(server.Server, &clusterOperationService{
 		store:             store,
 		kubernetesCluster: kubernetesCluster,
+		nodeHandler:       nodeHandler,
 	})
 }
 
@@ -56,10 +60,10 @@ func (s *clusterOperationService) JoinCluster(ctx context.Context, info *control
 	}
 
 	// Get connection information and create node
-	node := store.NewNode(connectionInfo, nodeResources, nodeResources.Label)
+	apatelet := store.NewNode(connectionInfo, nodeResources, nodeResources.Label)
 
 	// Add to apate store
-	err = st.AddNode(node)
+	time, err := addNode(st, apatelet)
 
 	if err != nil {
 		err = errors.Wrap(err, "failed to add node to queue")
@@ -67,19 +71,14 @@ func (s *clusterOperationService) JoinCluster(ctx context.Context, info *control
 		return nil, err
 	}
 
-	log.Printf("Added node to apate store: %v\n", node)
-
-	// Check start time for scenario
-	time := int64(-1)
-	scenario, err := st.GetApateletScenario()
-	if err == nil {
-		time = scenario.StartTime
-	}
+	log.Printf("Added node to apate store: %v\n", apatelet)
+	s.updateStatus(apatelet.Label)
 
 	return &controlplane.JoinInformation{
 		KubeConfig: s.kubernetesCluster.KubeConfig.Bytes,
-		NodeUuid:   node.UUID.String(),
+		NodeUuid:   apatelet.UUID.String(),
 		NodeLabel:  nodeResources.Label,
+		NodeIndex:  apatelet.Index,
 		StartTime:  time,
 
 		Hardware: &controlplane.NodeHardware{
@@ -92,6 +91,24 @@ func (s *clusterOperationService) JoinCluster(ctx context.Context, info *control
 	}, nil
 }
 
+// addNode adds the node to the store and returns the start time of the scenario, or -1 if none has been started yet
+// A scenario which is being started is waited for, so the node is either started along with the others or receives its start time
+func addNode(st store.Store, node *store.Node) (int64, error) {
+	startLock.Lock()
+	defer startLock.Unlock()
+
+	if err := st.AddNode(node); err != nil {
+		return 0, err
+	}
+
+	scenario, err := st.GetApateletScenario()
+	if err != nil {
+		return -1, nil
+	}
+
+	return scenario.StartTime, nil
+}
+
 // LeaveCluster removes the node from the store
 // This will maybe also remove it from k8s itself, TBD
 func (s *clusterOperationService) LeaveCluster(_ context.Context, leaveInformation *controlplane.LeaveInformation) (*empty.Empty, error) {
@@ -102,15 +119,29 @@ func (s *clusterOperationService) LeaveCluster(_ context.Context, leaveInformati
 		return nil, errors.Wrap(err, "failed to remove node from cluster")
 	}
 
+	// The label is retrieved up front, as the node is no longer in the store afterwards
+	apatelet, getErr := (*s.store).GetNode(id)
+
 	var clusterAPI kubernetes.ClusterAPI = s.kubernetesCluster
 	_, _, err = cluster.RemoveNodeWithUUID(id, s.store, &clusterAPI)
 	if err != nil {
 		return nil, errors.Wrapf(err, "removing node with uuid %v during leave cluster failed", id)
 	}
 
+	if getErr == nil {
+		s.updateStatus(apatelet.Label)
+	}
+
 	return &empty.Empty{}, nil
 }
 
+// updateStatus updates the status of the node configuration with the given label, after its apatelets have changed
+func (s *clusterOperationService) updateStatus(label string) {
+	if err := (*s.nodeHandler).UpdateStatus(label); err != nil {
+		log.Printf("unable to update status of node configuration %v: %v\n", label, err)
+	}
+}
+
 func (s *clusterOperationService) GetKubeConfig(_ context.Context, _ *empty.Empty) (*controlplane.KubeConfig, error) {
 	return &controlplane.KubeConfig{Config: s.kubernetesCluster.KubeConfig.Bytes}, nil
 }
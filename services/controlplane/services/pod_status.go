@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+	"github.com/atlarge-research/apate/internal/service"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+)
+
+type podStatusService struct {
+	store *store.Store
+}
+
+// RegisterPodStatusService registers a new podStatusService with the given gRPC server
+func RegisterPodStatusService(server *service.GRPCServer, store *store.Store) {
+	controlplane.RegisterPodStatusServer(server.Server, &podStatusService{store: store})
+}
+
+func (s *podStatusService) ReportPodStatus(_ context.Context, status *controlplane.ApateletPodStatus) (*empty.Empty, error) {
+	id, err := uuid.Parse(status.NodeUuid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse node uuid (%v)", status.NodeUuid)
+	}
+
+	if err := (*s.store).SetPodStatuses(id, status.Configurations); err != nil {
+		return nil, errors.Wrapf(err, "failed to set pod statuses of node %v", id)
+	}
+
+	return new(empty.Empty), nil
+}
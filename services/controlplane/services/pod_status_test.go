@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/atlarge-research/apate/api/controlplane"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+	"github.com/atlarge-research/apate/services/controlplane/store/mock_store"
+)
+
+func TestReportPodStatus(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Created mocked store
+	ms := mock_store.NewMockStore(ctrl)
+
+	id := uuid.New()
+	statuses := []*controlplane.PodConfigurationStatus{
+		{
+			Label:       "a/b",
+			MatchedPods: 42,
+		},
+	}
+
+	// Create expectations
+	ms.EXPECT().SetPodStatuses(id, statuses).Return(nil)
+
+	var s store.Store = ms
+	ps := podStatusService{&s}
+
+	_, err := ps.ReportPodStatus(context.Background(), &controlplane.ApateletPodStatus{
+		NodeUuid:       id.String(),
+		Configurations: statuses,
+	})
+	assert.NoError(t, err)
+}
+
+func TestReportPodStatusInvalidUUID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Created mocked store
+	ms := mock_store.NewMockStore(ctrl)
+
+	var s store.Store = ms
+	ps := podStatusService{&s}
+
+	_, err := ps.ReportPodStatus(context.Background(), &controlplane.ApateletPodStatus{
+		NodeUuid: "invalid",
+	})
+	assert.Error(t, err)
+}
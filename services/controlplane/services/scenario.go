@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/atlarge-research/apate/pkg/channel"
@@ -23,6 +24,10 @@ import (
 // Should be large enough so that all apatelets have received the scenario and are ready
 const amountOfSecondsToWait = 5
 
+// startLock makes sure the scenario is not started twice at the same time,
+// and that apatelets which join while it is being started are either started along with the others or receive its start time
+var startLock sync.Mutex
+
 type scenarioService struct {
 	store          *store.Store
 	info           *service.ConnectionInfo
@@ -39,35 +44,51 @@ func RegisterScenarioService(server *service.GRPCServer, store *store.Store, inf
 }
 
 func (s *scenarioService) StartScenario(ctx context.Context, startScenario *controlplane.StartScenario) (*empty.Empty, error) {
-	nodes, err := (*s.store).GetNodes()
-	if err != nil {
+	if _, err := StartScenario(ctx, s.store, s.stopInformerCh, startScenario.DisableWatchers); err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
+	return new(empty.Empty), nil
+}
+
+// StartScenario starts the scenario on all apatelets in the store, returning the scenario which was sent to them
+// Starting a scenario again restarts it, if the scenario could not be started on all apatelets the previous one is restored
+func StartScenario(ctx context.Context, st *store.Store, stopInformerCh *channel.StopChannel, disableWatchers bool) (*apiApatelet.ApateletScenario, error) {
+	startLock.Lock()
+	defer startLock.Unlock()
+
+	nodes, err := (*st).GetNodes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get nodes")
+	}
+
 	apateletScenario := &apiApatelet.ApateletScenario{
 		StartTime:       time.Now().Add(time.Second * amountOfSecondsToWait).UnixNano(),
-		DisableWatchers: startScenario.DisableWatchers,
+		DisableWatchers: disableWatchers,
 	}
 
-	if err = (*s.store).SetApateletScenario(apateletScenario); err != nil {
-		err = errors.Wrap(err, "failed to get Apatelet scenario")
-		log.Println(err)
-		return nil, err
+	// The previous scenario is nil if no scenario has been started yet
+	previous, _ := (*st).GetApateletScenario()
+	if err = (*st).SetApateletScenario(apateletScenario); err != nil {
+		return nil, errors.Wrap(err, "failed to set Apatelet scenario")
 	}
 
 	log.Println("Starting scenario on nodes")
 	if err = startOnNodes(ctx, nodes, apateletScenario); err != nil {
-		err = errors.Wrap(err, "failed to get start scenario on nodes")
-		log.Println(err)
-		return nil, err
+		// The scenario is not recorded as started, so it can be started again
+		if restoreErr := (*st).SetApateletScenario(previous); restoreErr != nil {
+			log.Printf("failed to restore the previous Apatelet scenario: %v", restoreErr)
+		}
+
+		return nil, errors.Wrap(err, "failed to start scenario on nodes")
 	}
 
-	if startScenario.DisableWatchers {
-		s.stopInformerCh.Close()
+	if disableWatchers {
+		stopInformerCh.Close()
 	}
 
-	return new(empty.Empty), nil
+	return apateletScenario, nil
 }
 
 func startOnNodes(ctx context.Context, nodes []store.Node, apateletScenario *apiApatelet.ApateletScenario) error {
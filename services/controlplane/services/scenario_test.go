@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	apiApatelet "github.com/atlarge-research/apate/api/apatelet"
+	"github.com/atlarge-research/apate/internal/service"
+	"github.com/atlarge-research/apate/pkg/channel"
+	"github.com/atlarge-research/apate/services/controlplane/store"
+	"github.com/atlarge-research/apate/services/controlplane/store/mock_store"
+)
+
+func TestStartScenarioFailed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Nothing listens on this port, so the apatelet can't be reached
+	ms := mock_store.NewMockStore(ctrl)
+	ms.EXPECT().GetNodes().Return([]store.Node{{ConnectionInfo: *service.NewConnectionInfo("localhost", 1)}}, nil)
+
+	// The scenario is stored before it is started, and the previous one is restored so it can be started again
+	previous := &apiApatelet.ApateletScenario{StartTime: 42}
+	ms.EXPECT().GetApateletScenario().Return(previous, nil)
+	gomock.InOrder(
+		ms.EXPECT().SetApateletScenario(gomock.Not(previous)),
+		ms.EXPECT().SetApateletScenario(previous),
+	)
+
+	var st store.Store = ms
+	stopCh := channel.NewStopChannel()
+
+	_, err := StartScenario(context.Background(), &st, stopCh, true)
+	assert.Error(t, err)
+
+	// The watchers are left alone if the scenario is not started
+	select {
+	case <-stopCh.GetChannel():
+		assert.Fail(t, "stop channel should not be closed")
+	default:
+	}
+}
+
+func TestStartScenarioAgain(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	ms.EXPECT().GetNodes().Return(nil, nil).Times(2)
+	ms.EXPECT().GetApateletScenario().Return(nil, errors.New("no scenario available yet")).Times(2)
+
+	var first, second *apiApatelet.ApateletScenario
+	gomock.InOrder(
+		ms.EXPECT().SetApateletScenario(gomock.Any()).Do(func(scenario *apiApatelet.ApateletScenario) { first = scenario }),
+		ms.EXPECT().SetApateletScenario(gomock.Any()).Do(func(scenario *apiApatelet.ApateletScenario) { second = scenario }),
+	)
+
+	var st store.Store = ms
+	stopCh := channel.NewStopChannel()
+
+	// A scenario which has been started can be started again, like apate-cli run has always allowed
+	_, err := StartScenario(context.Background(), &st, stopCh, false)
+	assert.NoError(t, err)
+
+	scenario, err := StartScenario(context.Background(), &st, stopCh, false)
+	assert.NoError(t, err)
+	assert.Equal(t, scenario, second)
+	assert.True(t, first != second, "a new scenario should be stored")
+}
+
+func TestAddNodeStartTime(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := mock_store.NewMockStore(ctrl)
+	node := &store.Node{}
+
+	// Nodes which join before a scenario has been started don't get a start time
+	gomock.InOrder(
+		ms.EXPECT().AddNode(node),
+		ms.EXPECT().GetApateletScenario().Return(nil, errors.New("no scenario available yet")),
+		ms.EXPECT().AddNode(node),
+		ms.EXPECT().GetApateletScenario().Return(&apiApatelet.ApateletScenario{StartTime: 42}, nil),
+	)
+
+	startTime, err := addNode(ms, node)
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, startTime)
+
+	startTime, err = addNode(ms, node)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, startTime)
+}
@@ -6,6 +6,7 @@ package mock_store
 
 import (
 	apatelet "github.com/atlarge-research/apate/api/apatelet"
+	controlplane "github.com/atlarge-research/apate/api/controlplane"
 	health "github.com/atlarge-research/apate/api/health"
 	scenario "github.com/atlarge-research/apate/pkg/scenario"
 	store "github.com/atlarge-research/apate/services/controlplane/store"
@@ -139,6 +140,21 @@ func (mr *MockStoreMockRecorder) GetNodesByLabel(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesByLabel", reflect.TypeOf((*MockStore)(nil).GetNodesByLabel), arg0)
 }
 
+// GetPodStatuses mocks base method
+func (m *MockStore) GetPodStatuses() (map[string][]*controlplane.PodConfigurationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodStatuses")
+	ret0, _ := ret[0].(map[string][]*controlplane.PodConfigurationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodStatuses indicates an expected call of GetPodStatuses
+func (mr *MockStoreMockRecorder) GetPodStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodStatuses", reflect.TypeOf((*MockStore)(nil).GetPodStatuses))
+}
+
 // GetResourceFromQueue mocks base method
 func (m *MockStore) GetResourceFromQueue() (*scenario.NodeResources, error) {
 	m.ctrl.T.Helper()
@@ -209,3 +225,17 @@ func (mr *MockStoreMockRecorder) SetNodeStatus(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeStatus", reflect.TypeOf((*MockStore)(nil).SetNodeStatus), arg0, arg1)
 }
+
+// SetPodStatuses mocks base method
+func (m *MockStore) SetPodStatuses(arg0 uuid.UUID, arg1 []*controlplane.PodConfigurationStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPodStatuses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPodStatuses indicates an expected call of SetPodStatuses
+func (mr *MockStoreMockRecorder) SetPodStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPodStatuses", reflect.TypeOf((*MockStore)(nil).SetPodStatuses), arg0, arg1)
+}
@@ -15,6 +15,9 @@ type Node struct {
 	Status         health.Status
 	Label          string
 	Resources      *scenario.NodeResources
+
+	// Index is the index of the node among the nodes with the same label, assigned when it is added to the store
+	Index int64
 }
 
 // NewNode creates a new Node based on the given connection information
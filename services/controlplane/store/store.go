@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/atlarge-research/apate/api/apatelet"
+	"github.com/atlarge-research/apate/api/controlplane"
 )
 
 //TODO: Multi-master not soon :tm:
@@ -21,6 +22,7 @@ import (
 // Store represents the store of the control plane
 type Store interface {
 	// AddNode adds the given Node to the Apate cluster
+	// The node is given the lowest index which is not used by any other node with the same label
 	AddNode(*Node) error
 
 	// RemoveNode removes the given Node from the Apate cluster by uuid
@@ -55,11 +57,18 @@ type Store interface {
 
 	// GetApateletScenario gets the ApateletScenario
 	GetApateletScenario() (*apatelet.ApateletScenario, error)
+
+	// SetPodStatuses sets the status of the pod configurations as reported by the node with the given uuid
+	SetPodStatuses(uuid.UUID, []*controlplane.PodConfigurationStatus) error
+
+	// GetPodStatuses returns the status of the pod configurations as reported by all nodes, by pod configuration label
+	GetPodStatuses() (map[string][]*controlplane.PodConfigurationStatus, error)
 }
 
 type store struct {
 	nodes        map[uuid.UUID]Node
 	nodesByLabel map[string][]Node
+	podStatuses  map[uuid.UUID][]*controlplane.PodConfigurationStatus
 	nodeLock     sync.RWMutex
 
 	resourceQueue list.List
@@ -74,6 +83,7 @@ func NewStore() Store {
 	return &store{
 		nodes:        make(map[uuid.UUID]Node),
 		nodesByLabel: make(map[string][]Node),
+		podStatuses:  make(map[uuid.UUID][]*controlplane.PodConfigurationStatus),
 	}
 }
 
@@ -90,12 +100,30 @@ func (s *store) AddNode(node *Node) error {
 		return errors.Errorf("node %s has no label", node.UUID.String())
 	}
 
+	node.Index = lowestFreeIndex(s.nodesByLabel[node.Label])
+
 	s.nodes[node.UUID] = *node
 	s.nodesByLabel[node.Label] = append(s.nodesByLabel[node.Label], *node)
 
 	return nil
 }
 
+// lowestFreeIndex returns the lowest index which is not used by any of the given nodes
+// This keeps the indices of nodes with the same label between 0 and the amount of nodes, even when nodes are replaced
+func lowestFreeIndex(nodes []Node) int64 {
+	used := make(map[int64]bool, len(nodes))
+	for _, node := range nodes {
+		used[node.Index] = true
+	}
+
+	var index int64
+	for used[index] {
+		index++
+	}
+
+	return index
+}
+
 func (s *store) removeNodeByUUID(uuid uuid.UUID) error {
 	node := s.nodes[uuid]
 	if node == (Node{}) {
@@ -117,6 +145,7 @@ func (s *store) removeNodeByUUID(uuid uuid.UUID) error {
 	}
 
 	delete(s.nodes, node.UUID)
+	delete(s.podStatuses, node.UUID)
 	return nil
 }
 
@@ -190,9 +219,36 @@ func (s *store) ClearNodes() error {
 
 	s.nodes = make(map[uuid.UUID]Node)
 	s.nodesByLabel = make(map[string][]Node)
+	s.podStatuses = make(map[uuid.UUID][]*controlplane.PodConfigurationStatus)
 	return nil
 }
 
+func (s *store) SetPodStatuses(uuid uuid.UUID, statuses []*controlplane.PodConfigurationStatus) error {
+	s.nodeLock.Lock()
+	defer s.nodeLock.Unlock()
+
+	if _, ok := s.nodes[uuid]; !ok {
+		return errors.Errorf("node with uuid '%s' not found", uuid.String())
+	}
+
+	s.podStatuses[uuid] = statuses
+	return nil
+}
+
+func (s *store) GetPodStatuses() (map[string][]*controlplane.PodConfigurationStatus, error) {
+	s.nodeLock.RLock()
+	defer s.nodeLock.RUnlock()
+
+	statuses := make(map[string][]*controlplane.PodConfigurationStatus)
+	for _, nodeStatuses := range s.podStatuses {
+		for _, status := range nodeStatuses {
+			statuses[status.Label] = append(statuses[status.Label], status)
+		}
+	}
+
+	return statuses, nil
+}
+
 func (s *store) AddResourcesToQueue(resources []scenario.NodeResources) error {
 	s.resourceLock.Lock()
 	defer s.resourceLock.Unlock()
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/atlarge-research/apate/api/controlplane"
 	"github.com/atlarge-research/apate/internal/service"
 )
 
@@ -224,3 +225,75 @@ func TestGetNodesByLabel(t *testing.T) {
 	assert.Equal(t, 1, len(nodes))
 	assert.Equal(t, *node, nodes[0])
 }
+
+// TestNodeIndices ensures nodes with the same label get the lowest free index
+func TestNodeIndices(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	newNode := func(label string) *Node {
+		return NewNode(*service.NewConnectionInfo("yeet", 42), &scenario.NodeResources{UUID: uuid.New()}, label)
+	}
+
+	first, second, third, other := newNode("a"), newNode("a"), newNode("a"), newNode("b")
+	for _, node := range []*Node{first, second, third, other} {
+		assert.NoError(t, store.AddNode(node))
+	}
+
+	assert.Equal(t, int64(0), first.Index)
+	assert.Equal(t, int64(1), second.Index)
+	assert.Equal(t, int64(2), third.Index)
+	assert.Equal(t, int64(0), other.Index)
+
+	// A replacement takes the index of the removed node
+	assert.NoError(t, store.RemoveNode(second.UUID))
+	replacement := newNode("a")
+	assert.NoError(t, store.AddNode(replacement))
+	assert.Equal(t, int64(1), replacement.Index)
+
+	stored, err := store.GetNode(replacement.UUID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stored.Index)
+}
+
+// TestPodStatuses ensures pod statuses are grouped by label and removed together with their node
+func TestPodStatuses(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	node1 := NewNode(*service.NewConnectionInfo("yeet", 42), &scenario.NodeResources{UUID: uuid.New()}, "label")
+	node2 := NewNode(*service.NewConnectionInfo("yeet", 43), &scenario.NodeResources{UUID: uuid.New()}, "label")
+	assert.NoError(t, store.AddNode(node1))
+	assert.NoError(t, store.AddNode(node2))
+
+	assert.NoError(t, store.SetPodStatuses(node1.UUID, []*controlplane.PodConfigurationStatus{
+		{Label: "a/b", MatchedPods: 1},
+		{Label: "a/c", MatchedPods: 2},
+	}))
+	assert.NoError(t, store.SetPodStatuses(node2.UUID, []*controlplane.PodConfigurationStatus{
+		{Label: "a/b", MatchedPods: 3},
+	}))
+
+	statuses, err := store.GetPodStatuses()
+	assert.NoError(t, err)
+	assert.Len(t, statuses["a/b"], 2)
+	assert.Len(t, statuses["a/c"], 1)
+
+	assert.NoError(t, store.RemoveNode(node1.UUID))
+
+	statuses, err = store.GetPodStatuses()
+	assert.NoError(t, err)
+	assert.Len(t, statuses["a/b"], 1)
+	assert.Equal(t, int64(3), statuses["a/b"][0].MatchedPods)
+	assert.Len(t, statuses["a/c"], 0)
+}
+
+// TestPodStatusesUnknownNode ensures pod statuses can only be set for known nodes
+func TestPodStatusesUnknownNode(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+
+	err := store.SetPodStatuses(uuid.New(), []*controlplane.PodConfigurationStatus{})
+	assert.Error(t, err)
+}
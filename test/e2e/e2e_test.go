@@ -64,6 +64,7 @@ func setup(t *testing.T, kindClusterName string, runType env.RunType) {
 	initEnv.KubeConfigLocation = "/tmp/apate/test-" + uuid.New().String()
 	initEnv.PodCRDLocation = dir + "/config/crd/apate.opendc.org_podconfigurations.yaml"
 	initEnv.NodeCRDLocation = dir + "/config/crd/apate.opendc.org_nodeconfigurations.yaml"
+	initEnv.ScenarioCRDLocation = dir + "/config/crd/apate.opendc.org_scenarios.yaml"
 	initEnv.ManagerConfigLocation = dir + "/config/gitlab-kind.yml"
 	initEnv.PrometheusConfigLocation = dir + "/config/prometheus.yml"
 	initEnv.KinDClusterName = kindClusterName
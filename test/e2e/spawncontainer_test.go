@@ -64,6 +64,7 @@ func TestSpawnControlPlaneDocker(t *testing.T) {
 	assert.Contains(t, envVars, "CP_PROMETHEUS=true")
 	assert.Contains(t, envVars, "CP_PROMETHEUS_NAMESPACE=apate-prometheus")
 	assert.Contains(t, envVars, "CP_POD_CRD_LOCATION=config/crd/apate.opendc.org_podconfigurations.yaml")
+	assert.Contains(t, envVars, "CP_SCENARIO_CRD_LOCATION=config/crd/apate.opendc.org_scenarios.yaml")
 
 	assert.Equal(t, "/app/controlplane", inspect.Config.Cmd[0])
 